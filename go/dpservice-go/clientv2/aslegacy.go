@@ -0,0 +1,217 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+	legacy "github.com/ironcore-dev/dpservice/go/dpservice-go/client"
+)
+
+// AsLegacy adapts c to the legacy client interface, the inverse of AsV2.
+// Every legacy method calls the matching v2 domain method, so hooks,
+// retries and the other defaults of c still apply. The ignored error codes
+// of a legacy call are passed on with WithIgnoredCodes; like the legacy
+// client, only the first slice is used.
+func AsLegacy(c Client) legacy.Client {
+	return &legacyAdapter{c: c}
+}
+
+type legacyAdapter struct{ c Client }
+
+var _ legacy.Client = (*legacyAdapter)(nil)
+
+// ignoredOpts converts the legacy variadic []uint32 form to call options.
+func ignoredOpts(ignoredErrors [][]uint32) []CallOption {
+	if len(ignoredErrors) == 0 || len(ignoredErrors[0]) == 0 {
+		return nil
+	}
+	return []CallOption{WithIgnoredCodes(ignoredErrors[0]...)}
+}
+
+func (a *legacyAdapter) GetLoadBalancer(ctx context.Context, id string, ignoredErrors ...[]uint32) (*api.LoadBalancer, error) {
+	return a.c.LoadBalancers().Get(ctx, id, ignoredOpts(ignoredErrors)...)
+}
+
+func (a *legacyAdapter) ListLoadBalancers(ctx context.Context, ignoredErrors ...[]uint32) (*api.LoadBalancerList, error) {
+	return a.c.LoadBalancers().List(ctx, ignoredOpts(ignoredErrors)...)
+}
+
+func (a *legacyAdapter) CreateLoadBalancer(ctx context.Context, lb *api.LoadBalancer, ignoredErrors ...[]uint32) (*api.LoadBalancer, error) {
+	return a.c.LoadBalancers().Create(ctx, lb, ignoredOpts(ignoredErrors)...)
+}
+
+func (a *legacyAdapter) DeleteLoadBalancer(ctx context.Context, id string, ignoredErrors ...[]uint32) (*api.LoadBalancer, error) {
+	return a.c.LoadBalancers().Delete(ctx, id, ignoredOpts(ignoredErrors)...)
+}
+
+func (a *legacyAdapter) ListLoadBalancerPrefixes(ctx context.Context, interfaceID string, ignoredErrors ...[]uint32) (*api.PrefixList, error) {
+	return a.c.LoadBalancers().Prefixes().List(ctx, interfaceID, ignoredOpts(ignoredErrors)...)
+}
+
+func (a *legacyAdapter) CreateLoadBalancerPrefix(ctx context.Context, prefix *api.LoadBalancerPrefix, ignoredErrors ...[]uint32) (*api.LoadBalancerPrefix, error) {
+	return a.c.LoadBalancers().Prefixes().Create(ctx, prefix, ignoredOpts(ignoredErrors)...)
+}
+
+func (a *legacyAdapter) DeleteLoadBalancerPrefix(ctx context.Context, interfaceID string, prefix *netip.Prefix, ignoredErrors ...[]uint32) (*api.LoadBalancerPrefix, error) {
+	return a.c.LoadBalancers().Prefixes().Delete(ctx, interfaceID, prefix, ignoredOpts(ignoredErrors)...)
+}
+
+func (a *legacyAdapter) ListLoadBalancerTargets(ctx context.Context, loadbalancerID string, ignoredErrors ...[]uint32) (*api.LoadBalancerTargetList, error) {
+	return a.c.LoadBalancers().Targets().List(ctx, loadbalancerID, ignoredOpts(ignoredErrors)...)
+}
+
+func (a *legacyAdapter) CreateLoadBalancerTarget(ctx context.Context, lbtarget *api.LoadBalancerTarget, ignoredErrors ...[]uint32) (*api.LoadBalancerTarget, error) {
+	return a.c.LoadBalancers().Targets().Create(ctx, lbtarget, ignoredOpts(ignoredErrors)...)
+}
+
+func (a *legacyAdapter) DeleteLoadBalancerTarget(ctx context.Context, id string, targetIP *netip.Addr, ignoredErrors ...[]uint32) (*api.LoadBalancerTarget, error) {
+	return a.c.LoadBalancers().Targets().Delete(ctx, id, targetIP, ignoredOpts(ignoredErrors)...)
+}
+
+func (a *legacyAdapter) GetInterface(ctx context.Context, id string, ignoredErrors ...[]uint32) (*api.Interface, error) {
+	return a.c.Interfaces().Get(ctx, id, ignoredOpts(ignoredErrors)...)
+}
+
+func (a *legacyAdapter) ListInterfaces(ctx context.Context, ignoredErrors ...[]uint32) (*api.InterfaceList, error) {
+	return a.c.Interfaces().List(ctx, ignoredOpts(ignoredErrors)...)
+}
+
+func (a *legacyAdapter) CreateInterface(ctx context.Context, iface *api.Interface, ignoredErrors ...[]uint32) (*api.Interface, error) {
+	return a.c.Interfaces().Create(ctx, iface, ignoredOpts(ignoredErrors)...)
+}
+
+func (a *legacyAdapter) DeleteInterface(ctx context.Context, id string, ignoredErrors ...[]uint32) (*api.Interface, error) {
+	return a.c.Interfaces().Delete(ctx, id, ignoredOpts(ignoredErrors)...)
+}
+
+func (a *legacyAdapter) GetVirtualIP(ctx context.Context, interfaceID string, ignoredErrors ...[]uint32) (*api.VirtualIP, error) {
+	return a.c.Interfaces().VIP().Get(ctx, interfaceID, ignoredOpts(ignoredErrors)...)
+}
+
+func (a *legacyAdapter) CreateVirtualIP(ctx context.Context, virtualIP *api.VirtualIP, ignoredErrors ...[]uint32) (*api.VirtualIP, error) {
+	return a.c.Interfaces().VIP().Create(ctx, virtualIP, ignoredOpts(ignoredErrors)...)
+}
+
+func (a *legacyAdapter) DeleteVirtualIP(ctx context.Context, interfaceID string, ignoredErrors ...[]uint32) (*api.VirtualIP, error) {
+	return a.c.Interfaces().VIP().Delete(ctx, interfaceID, ignoredOpts(ignoredErrors)...)
+}
+
+func (a *legacyAdapter) ListPrefixes(ctx context.Context, interfaceID string, ignoredErrors ...[]uint32) (*api.PrefixList, error) {
+	return a.c.Interfaces().Prefixes().List(ctx, interfaceID, ignoredOpts(ignoredErrors)...)
+}
+
+func (a *legacyAdapter) CreatePrefix(ctx context.Context, prefix *api.Prefix, ignoredErrors ...[]uint32) (*api.Prefix, error) {
+	return a.c.Interfaces().Prefixes().Create(ctx, prefix, ignoredOpts(ignoredErrors)...)
+}
+
+func (a *legacyAdapter) DeletePrefix(ctx context.Context, interfaceID string, prefix *netip.Prefix, ignoredErrors ...[]uint32) (*api.Prefix, error) {
+	return a.c.Interfaces().Prefixes().Delete(ctx, interfaceID, prefix, ignoredOpts(ignoredErrors)...)
+}
+
+func (a *legacyAdapter) ListRoutes(ctx context.Context, vni uint32, ignoredErrors ...[]uint32) (*api.RouteList, error) {
+	return a.c.Routes().List(ctx, vni, ignoredOpts(ignoredErrors)...)
+}
+
+func (a *legacyAdapter) CreateRoute(ctx context.Context, route *api.Route, ignoredErrors ...[]uint32) (*api.Route, error) {
+	return a.c.Routes().Create(ctx, route, ignoredOpts(ignoredErrors)...)
+}
+
+func (a *legacyAdapter) DeleteRoute(ctx context.Context, vni uint32, prefix *netip.Prefix, ignoredErrors ...[]uint32) (*api.Route, error) {
+	return a.c.Routes().Delete(ctx, vni, prefix, ignoredOpts(ignoredErrors)...)
+}
+
+func (a *legacyAdapter) GetNat(ctx context.Context, interfaceID string, ignoredErrors ...[]uint32) (*api.Nat, error) {
+	return a.c.NATs().Get(ctx, interfaceID, ignoredOpts(ignoredErrors)...)
+}
+
+func (a *legacyAdapter) CreateNat(ctx context.Context, nat *api.Nat, ignoredErrors ...[]uint32) (*api.Nat, error) {
+	return a.c.NATs().Create(ctx, nat, ignoredOpts(ignoredErrors)...)
+}
+
+func (a *legacyAdapter) DeleteNat(ctx context.Context, interfaceID string, ignoredErrors ...[]uint32) (*api.Nat, error) {
+	return a.c.NATs().Delete(ctx, interfaceID, ignoredOpts(ignoredErrors)...)
+}
+
+func (a *legacyAdapter) ListLocalNats(ctx context.Context, natIP *netip.Addr, ignoredErrors ...[]uint32) (*api.NatList, error) {
+	return a.c.NATs().ListLocal(ctx, natIP, ignoredOpts(ignoredErrors)...)
+}
+
+func (a *legacyAdapter) CreateNeighborNat(ctx context.Context, nat *api.NeighborNat, ignoredErrors ...[]uint32) (*api.NeighborNat, error) {
+	return a.c.NATs().CreateNeighbor(ctx, nat, ignoredOpts(ignoredErrors)...)
+}
+
+// ListNats accepts the same natType values as the legacy client.
+func (a *legacyAdapter) ListNats(ctx context.Context, natIP *netip.Addr, natType string, ignoredErrors ...[]uint32) (*api.NatList, error) {
+	switch strings.ToLower(natType) {
+	case "local", "1":
+		return a.c.NATs().ListLocal(ctx, natIP, ignoredOpts(ignoredErrors)...)
+	case "neigh", "2", "neighbor":
+		return a.c.NATs().ListNeighbors(ctx, natIP, ignoredOpts(ignoredErrors)...)
+	case "any", "0", "":
+		return a.c.NATs().ListAny(ctx, natIP, ignoredOpts(ignoredErrors)...)
+	default:
+		return &api.NatList{}, fmt.Errorf("nat type can be only: Any = 0/Local = 1/Neigh(bor) = 2")
+	}
+}
+
+func (a *legacyAdapter) DeleteNeighborNat(ctx context.Context, neigbhorNat *api.NeighborNat, ignoredErrors ...[]uint32) (*api.NeighborNat, error) {
+	return a.c.NATs().DeleteNeighbor(ctx, neigbhorNat, ignoredOpts(ignoredErrors)...)
+}
+
+func (a *legacyAdapter) ListNeighborNats(ctx context.Context, natIP *netip.Addr, ignoredErrors ...[]uint32) (*api.NatList, error) {
+	return a.c.NATs().ListNeighbors(ctx, natIP, ignoredOpts(ignoredErrors)...)
+}
+
+func (a *legacyAdapter) ListFirewallRules(ctx context.Context, interfaceID string, ignoredErrors ...[]uint32) (*api.FirewallRuleList, error) {
+	return a.c.Firewall().List(ctx, interfaceID, ignoredOpts(ignoredErrors)...)
+}
+
+func (a *legacyAdapter) CreateFirewallRule(ctx context.Context, fwRule *api.FirewallRule, ignoredErrors ...[]uint32) (*api.FirewallRule, error) {
+	return a.c.Firewall().Create(ctx, fwRule, ignoredOpts(ignoredErrors)...)
+}
+
+func (a *legacyAdapter) GetFirewallRule(ctx context.Context, interfaceID string, ruleID string, ignoredErrors ...[]uint32) (*api.FirewallRule, error) {
+	return a.c.Firewall().Get(ctx, interfaceID, ruleID, ignoredOpts(ignoredErrors)...)
+}
+
+func (a *legacyAdapter) DeleteFirewallRule(ctx context.Context, interfaceID string, ruleID string, ignoredErrors ...[]uint32) (*api.FirewallRule, error) {
+	return a.c.Firewall().Delete(ctx, interfaceID, ruleID, ignoredOpts(ignoredErrors)...)
+}
+
+func (a *legacyAdapter) CheckInitialized(ctx context.Context, ignoredErrors ...[]uint32) (*api.Initialized, error) {
+	return a.c.System().CheckInitialized(ctx, ignoredOpts(ignoredErrors)...)
+}
+
+func (a *legacyAdapter) Initialize(ctx context.Context, ignoredErrors ...[]uint32) (*api.Initialized, error) {
+	return a.c.System().Initialize(ctx, ignoredOpts(ignoredErrors)...)
+}
+
+func (a *legacyAdapter) GetVni(ctx context.Context, vni uint32, vniType uint8, ignoredErrors ...[]uint32) (*api.Vni, error) {
+	return a.c.System().GetVni(ctx, vni, vniType, ignoredOpts(ignoredErrors)...)
+}
+
+func (a *legacyAdapter) ResetVni(ctx context.Context, vni uint32, vniType uint8, ignoredErrors ...[]uint32) (*api.Vni, error) {
+	return a.c.System().ResetVni(ctx, vni, vniType, ignoredOpts(ignoredErrors)...)
+}
+
+func (a *legacyAdapter) GetVersion(ctx context.Context, version *api.Version, ignoredErrors ...[]uint32) (*api.Version, error) {
+	return a.c.System().GetVersion(ctx, version, ignoredOpts(ignoredErrors)...)
+}
+
+func (a *legacyAdapter) CaptureStart(ctx context.Context, capture *api.CaptureStart, ignoredErrors ...[]uint32) (*api.CaptureStart, error) {
+	return a.c.Capture().Start(ctx, capture, ignoredOpts(ignoredErrors)...)
+}
+
+func (a *legacyAdapter) CaptureStop(ctx context.Context, ignoredErrors ...[]uint32) (*api.CaptureStop, error) {
+	return a.c.Capture().Stop(ctx, ignoredOpts(ignoredErrors)...)
+}
+
+func (a *legacyAdapter) CaptureStatus(ctx context.Context, ignoredErrors ...[]uint32) (*api.CaptureStatus, error) {
+	return a.c.Capture().Status(ctx, ignoredOpts(ignoredErrors)...)
+}
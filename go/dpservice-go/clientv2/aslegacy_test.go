@@ -0,0 +1,45 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+	dperrors "github.com/ironcore-dev/dpservice/go/dpservice-go/errors"
+)
+
+func TestAsLegacy(t *testing.T) {
+	fake := &fakeLegacy{
+		getInterface: func(context.Context, string) (*api.Interface, error) {
+			return &api.Interface{}, dperrors.NewStatusError(dperrors.NO_VM, "no vm")
+		},
+	}
+	var calls []string
+	c := AsLegacy(AsV2(fake, WithBefore(func(domain, method string, ctx context.Context) context.Context {
+		calls = append(calls, domain+"."+method)
+		return ctx
+	})))
+	ctx := context.Background()
+
+	if _, err := c.GetInterface(ctx, "vm1"); !IsNotFound(err) {
+		t.Fatalf("expected NOT_FOUND error, got %v", err)
+	}
+	if _, err := c.GetInterface(ctx, "vm1", []uint32{dperrors.NO_VM}); err != nil {
+		t.Fatalf("expected ignored code to suppress the error, got %v", err)
+	}
+	if _, err := c.ListNats(ctx, nil, "Neighbor"); err != nil {
+		t.Fatalf("ListNats: %v", err)
+	}
+	if _, err := c.ListNats(ctx, nil, "bogus"); err == nil {
+		t.Fatal("expected error for unknown nat type")
+	}
+
+	want := []string{"Interfaces.Get", "Interfaces.Get", "NATs.ListNeighbors"}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("hooks saw %v, want %v", calls, want)
+	}
+}
@@ -0,0 +1,112 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+
+	legacy "github.com/ironcore-dev/dpservice/go/dpservice-go/client"
+	dpdkproto "github.com/ironcore-dev/dpservice/go/dpservice-go/proto"
+)
+
+// Option configures a client built with New. Every CallOption is also an
+// Option and becomes a client default, so WithLogger, WithMetrics or
+// WithRetry can be passed to New directly.
+type Option interface {
+	applyClient(*clientConfig)
+}
+
+type clientConfig struct {
+	rpc      dpdkproto.DPDKironcoreClient
+	legacy   legacy.Client
+	addr     string
+	dialOpts []grpc.DialOption
+	// sources counts the options selecting how to reach the server.
+	sources  int
+	defaults []CallOption
+	// connectTimeout bounds dialing WithAddress, see WithConnectTimeout.
+	connectTimeout time.Duration
+}
+
+type optionFunc func(*clientConfig)
+
+func (f optionFunc) applyClient(c *clientConfig) {
+	f(c)
+}
+
+func (o CallOption) applyClient(c *clientConfig) {
+	c.defaults = append(c.defaults, o)
+}
+
+// WithProtoClient makes New use a generated gRPC client, see NewFromProto.
+func WithProtoClient(rpc dpdkproto.DPDKironcoreClient) Option {
+	return optionFunc(func(c *clientConfig) {
+		c.rpc = rpc
+		c.sources++
+	})
+}
+
+// WithLegacyClient makes New adapt a legacy client, see AsV2.
+func WithLegacyClient(l legacy.Client) Option {
+	return optionFunc(func(c *clientConfig) {
+		c.legacy = l
+		c.sources++
+	})
+}
+
+// WithAddress makes New dial addr and own the connection, see
+// NewFromAddress.
+func WithAddress(addr string, dialOpts ...grpc.DialOption) Option {
+	return optionFunc(func(c *clientConfig) {
+		c.addr = addr
+		c.dialOpts = dialOpts
+		c.sources++
+	})
+}
+
+// WithConnectTimeout bounds how long New waits for the connection made with
+// WithAddress. dpservice-go dials lazily like grpc, so the timeout only has
+// an effect together with grpc.WithBlock, which makes New wait until the
+// connection is ready; New then fails with a *TimeoutError instead of
+// hanging if the server is unreachable. NewFromAddress is bounded by its
+// ctx in the same way.
+func WithConnectTimeout(d time.Duration) Option {
+	return optionFunc(func(c *clientConfig) {
+		c.connectTimeout = d
+	})
+}
+
+// New builds a Client from options. Exactly one of WithProtoClient,
+// WithLegacyClient or WithAddress must be given; all CallOptions become
+// client defaults.
+func New(opts ...Option) (Client, error) {
+	var cfg clientConfig
+	for _, opt := range opts {
+		opt.applyClient(&cfg)
+	}
+
+	if cfg.sources != 1 {
+		return nil, fmt.Errorf("exactly one of WithProtoClient, WithLegacyClient or WithAddress is required, got %d", cfg.sources)
+	}
+	switch {
+	case cfg.rpc != nil:
+		return NewFromProto(cfg.rpc, cfg.defaults...), nil
+	case cfg.legacy != nil:
+		return AsV2(cfg.legacy, cfg.defaults...), nil
+	case cfg.addr != "":
+		ctx := context.Background()
+		if cfg.connectTimeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, cfg.connectTimeout)
+			defer cancel()
+		}
+		return newFromAddress(ctx, cfg.addr, cfg.dialOpts, cfg.defaults)
+	}
+	return nil, errors.New("client source must not be nil or empty")
+}
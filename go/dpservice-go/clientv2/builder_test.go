@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+	dperrors "github.com/ironcore-dev/dpservice/go/dpservice-go/errors"
+)
+
+func TestNew(t *testing.T) {
+	fake := &fakeLegacy{
+		getInterface: func(context.Context, string) (*api.Interface, error) {
+			return &api.Interface{}, dperrors.NewStatusError(dperrors.NOT_FOUND, "not found")
+		},
+	}
+
+	c, err := New(WithLegacyClient(fake), WithIgnoredCodes(dperrors.NOT_FOUND))
+	if err != nil {
+		t.Fatalf("expected a client, got %v", err)
+	}
+	if _, err := c.Interfaces().Get(context.Background(), "vm1"); err != nil {
+		t.Fatalf("expected CallOptions to become client defaults, got %v", err)
+	}
+
+	c, err = New(WithAddress("127.0.0.1:1", grpc.WithTransportCredentials(insecure.NewCredentials())))
+	if err != nil {
+		t.Fatalf("expected a client for an address, got %v", err)
+	}
+	if err := c.Close(); err != nil {
+		t.Fatalf("expected the owned connection to close, got %v", err)
+	}
+}
+
+func TestNewConnectTimeout(t *testing.T) {
+	start := time.Now()
+	c, err := New(WithAddress("127.0.0.1:1", grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock()),
+		WithConnectTimeout(50*time.Millisecond))
+	var timeoutErr *TimeoutError
+	if !errors.As(err, &timeoutErr) || c != nil {
+		t.Fatalf("expected a *TimeoutError, got %v, %v", c, err)
+	}
+	if d := time.Since(start); d > 5*time.Second {
+		t.Fatalf("expected New to give up after the connect timeout, took %v", d)
+	}
+}
+
+func TestNewRequiresOneSource(t *testing.T) {
+	for name, opts := range map[string][]Option{
+		"none": {WithTimeout(0)},
+		"two":  {WithLegacyClient(&fakeLegacy{}), WithAddress("127.0.0.1:1")},
+		"nil":  {WithLegacyClient(nil)},
+	} {
+		if c, err := New(opts...); err == nil || c != nil {
+			t.Errorf("%s: expected an error, got %v, %v", name, c, err)
+		}
+	}
+}
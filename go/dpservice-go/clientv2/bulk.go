@@ -0,0 +1,285 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/semaphore"
+)
+
+// defaultConcurrency bounds the number of in-flight RPCs issued by bulk helpers
+// unless overridden with WithConcurrency.
+const defaultConcurrency = 8
+
+// BulkItemError describes the failure of a single item of a bulk operation.
+type BulkItemError struct {
+	// Index is the position of the failed item in the input slice.
+	Index int
+	// Err is the error returned for that item.
+	Err error
+}
+
+func (e BulkItemError) Error() string {
+	return fmt.Sprintf("item %d: %v", e.Index, e.Err)
+}
+
+func (e BulkItemError) Unwrap() error {
+	return e.Err
+}
+
+// BulkError aggregates the per-item failures of a bulk operation. Bulk helpers
+// return a nil *BulkError when every item succeeded.
+type BulkError struct {
+	// Items holds one entry per failed input item, ordered by Index.
+	Items []BulkItemError
+}
+
+func (e *BulkError) Error() string {
+	if len(e.Items) == 1 {
+		return "bulk operation failed: " + e.Items[0].Error()
+	}
+	msgs := make([]string, len(e.Items))
+	for i, item := range e.Items {
+		msgs[i] = item.Error()
+	}
+	return fmt.Sprintf("bulk operation failed for %d items: %s", len(e.Items), strings.Join(msgs, "; "))
+}
+
+// Unwrap exposes the per-item errors to errors.Is and errors.As.
+func (e *BulkError) Unwrap() []error {
+	errs := make([]error, len(e.Items))
+	for i, item := range e.Items {
+		errs[i] = item.Err
+	}
+	return errs
+}
+
+// Failed returns the input indices of the failed items in ascending order.
+func (e *BulkError) Failed() []int {
+	if e == nil {
+		return nil
+	}
+	idx := make([]int, len(e.Items))
+	for i, item := range e.Items {
+		idx[i] = item.Index
+	}
+	return idx
+}
+
+// WithConcurrency bounds the number of concurrent RPCs issued by bulk helpers.
+// Values below 1 are ignored.
+func WithConcurrency(n int) CallOption {
+	return func(o *callOptions) {
+		if n > 0 {
+			o.concurrency = n
+		}
+	}
+}
+
+// WithChunkSize makes bulk helpers process their items in chunks of n: a
+// chunk starts once every item of the previous one has finished, and up to
+// WithConcurrency items of a chunk run at the same time. Very large batches
+// thereby reach the server at a steadier pace; every RPC still waits for the
+// rate limiters of WithRateLimiter. Failures of all chunks are merged into
+// one BulkError indexed by input position. Values below 1 are ignored.
+func WithChunkSize(n int) CallOption {
+	return func(o *callOptions) {
+		if n > 0 {
+			o.chunkSize = n
+		}
+	}
+}
+
+// WithSemaphore makes bulk helpers acquire weight units of sem for every item
+// they process, in addition to their own WithConcurrency bound. Sharing one
+// semaphore lets bulk calls of several clients and subsystems draw from a
+// common concurrency budget.
+func WithSemaphore(sem *semaphore.Weighted, weight int64) CallOption {
+	return func(o *callOptions) {
+		if sem != nil && weight > 0 {
+			o.semaphore = sem
+			o.semaphoreWeight = weight
+		}
+	}
+}
+
+// WithPerItemTimeout bounds every RPC issued by a bulk helper by d. Without
+// it, each item gets a fair share of the time left until the deadline of the
+// whole operation, i.e. the remaining time divided by the number of rounds of
+// WithConcurrency items still to run. Values below 1 are ignored.
+func WithPerItemTimeout(d time.Duration) CallOption {
+	return func(o *callOptions) {
+		if d > 0 {
+			o.perItemTimeout = d
+		}
+	}
+}
+
+// bulkContext derives the context of a whole bulk operation, bounded by
+// WithTimeout and detached if requested. Bulk helpers must pass
+// bulkItemOptions to their sub-calls so that both are not applied again.
+func (o callOptions) bulkContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if o.detached {
+		ctx = context.WithoutCancel(ctx)
+	}
+	if o.timeout > 0 {
+		return context.WithTimeout(ctx, o.timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// bulkItemOptions returns opts for the sub-calls of a bulk operation, whose
+// timeout and detachment were already applied by bulkContext.
+func bulkItemOptions(opts []CallOption) []CallOption {
+	return append(opts[:len(opts):len(opts)], func(o *callOptions) {
+		o.timeout = 0
+		o.detached = false
+	})
+}
+
+// itemTimeout returns the budget of the item started when remaining items,
+// including itself, are left, or 0 if the item is not bounded.
+func itemTimeout(ctx context.Context, o callOptions, limit, remaining int) time.Duration {
+	if o.perItemTimeout > 0 {
+		return o.perItemTimeout
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0
+	}
+	rounds := (remaining + limit - 1) / limit
+	return time.Until(deadline) / time.Duration(rounds)
+}
+
+// failAll returns a BulkError failing each of n items with err.
+func failAll(n int, err error) *BulkError {
+	bulkErr := &BulkError{Items: make([]BulkItemError, n)}
+	for i := range bulkErr.Items {
+		bulkErr.Items[i] = BulkItemError{Index: i, Err: err}
+	}
+	return bulkErr
+}
+
+// runBulk calls fn for every index in [0, n) with at most o.concurrency calls
+// in flight, holding o.semaphore if set, and collects the failures into a
+// BulkError. Each call runs on a context bounded by its item timeout, while
+// the deadline of ctx, usually derived by bulkContext, bounds the batch: items
+// that cannot start before it passes fail with the context error. With
+// o.chunkSize set, the items run in sequential chunks of that size.
+func runBulk(ctx context.Context, n int, o callOptions, fn func(ctx context.Context, i int) error) *BulkError {
+	limit := o.concurrency
+	if limit <= 0 {
+		limit = defaultConcurrency
+	}
+	if o.chunkSize > 0 {
+		limit = min(limit, o.chunkSize)
+	}
+
+	errs := make([]error, n)
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		if o.chunkSize > 0 && i > 0 && i%o.chunkSize == 0 {
+			wg.Wait()
+		}
+		if err := ctx.Err(); err != nil {
+			errs[i] = err
+			continue
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			errs[i] = ctx.Err()
+			continue
+		}
+		if o.semaphore != nil {
+			if err := o.semaphore.Acquire(ctx, o.semaphoreWeight); err != nil {
+				<-sem
+				errs[i] = err
+				continue
+			}
+		}
+		itemCtx, cancel := ctx, context.CancelFunc(func() {})
+		if d := itemTimeout(ctx, o, limit, n-i); d > 0 {
+			itemCtx, cancel = context.WithTimeout(ctx, d)
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() {
+				cancel()
+				if o.semaphore != nil {
+					o.semaphore.Release(o.semaphoreWeight)
+				}
+				<-sem
+			}()
+			errs[i] = fn(itemCtx, i)
+		}(i)
+	}
+	wg.Wait()
+
+	var bulkErr *BulkError
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+		if bulkErr == nil {
+			bulkErr = &BulkError{}
+		}
+		bulkErr.Items = append(bulkErr.Items, BulkItemError{Index: i, Err: err})
+	}
+	return bulkErr
+}
+
+// RetryFailed calls fn again for the items of a bulk operation that failed
+// with prev, with the concurrency, semaphore and timeouts of opts, and
+// returns the failures that remain, indexed by their position in items. It
+// returns nil once every item has succeeded, so convergence loops can call it
+// until the error is nil:
+//
+//	_, bulkErr := v2.NATs().CreateMany(ctx, nats)
+//	for attempt := 0; bulkErr != nil && attempt < 3; attempt++ {
+//		bulkErr = clientv2.RetryFailed(ctx, bulkErr, nats, func(ctx context.Context, nat *api.Nat) error {
+//			_, err := v2.NATs().Create(ctx, nat)
+//			return err
+//		})
+//	}
+//
+// Indices of prev outside items fail again with an error.
+func RetryFailed[T any](ctx context.Context, prev *BulkError, items []T, fn func(ctx context.Context, item T) error, opts ...CallOption) *BulkError {
+	if prev == nil || len(prev.Items) == 0 {
+		return nil
+	}
+	o := buildCallOptions(opts...)
+	ctx, cancel := o.bulkContext(ctx)
+	defer cancel()
+
+	failed := prev.Failed()
+	if err := contextDone(ctx, "RetryFailed"); err != nil {
+		bulkErr := failAll(len(failed), err)
+		for i := range bulkErr.Items {
+			bulkErr.Items[i].Index = failed[i]
+		}
+		return bulkErr
+	}
+	bulkErr := runBulk(ctx, len(failed), o, func(ctx context.Context, i int) error {
+		idx := failed[i]
+		if idx < 0 || idx >= len(items) {
+			return fmt.Errorf("index %d out of range of %d items", idx, len(items))
+		}
+		return fn(ctx, items[idx])
+	})
+	if bulkErr == nil {
+		return nil
+	}
+	for i := range bulkErr.Items {
+		bulkErr.Items[i].Index = failed[bulkErr.Items[i].Index]
+	}
+	return bulkErr
+}
@@ -0,0 +1,338 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/sync/semaphore"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+	dperrors "github.com/ironcore-dev/dpservice/go/dpservice-go/errors"
+)
+
+func TestNATsCreateMany(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	fake := &fakeLegacy{
+		createNat: func(_ context.Context, nat *api.Nat) (*api.Nat, error) {
+			n := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+
+			switch nat.InterfaceID {
+			case "exists":
+				return nat, dperrors.NewStatusError(dperrors.SNAT_EXISTS, "exists")
+			case "broken":
+				return nat, dperrors.NewStatusError(dperrors.NO_VM, "no vm")
+			}
+			return nat, nil
+		},
+	}
+
+	var nats []*api.Nat
+	for _, id := range []string{"vm1", "exists", "vm2", "broken", "vm3", "vm4"} {
+		nats = append(nats, &api.Nat{NatMeta: api.NatMeta{InterfaceID: id}})
+	}
+
+	list, bulkErr := AsV2(fake).NATs().CreateMany(context.Background(), nats,
+		WithIgnoredCodes(dperrors.SNAT_EXISTS), WithConcurrency(2))
+
+	if got := bulkErr.Failed(); len(got) != 1 || got[0] != 3 {
+		t.Fatalf("expected only index 3 to fail, got %v", got)
+	}
+	if !dperrors.IsStatusErrorCode(bulkErr, dperrors.NO_VM) {
+		t.Fatalf("expected BulkError to wrap the item status error, got %v", bulkErr)
+	}
+	if len(list.Items) != 5 || list.Items[1].InterfaceID != "exists" {
+		t.Fatalf("expected 5 entries in input order, got %+v", list.Items)
+	}
+	if p := peak.Load(); p > 2 {
+		t.Fatalf("expected at most 2 concurrent creates, got %d", p)
+	}
+}
+
+func TestNATsCreateManyItemOptions(t *testing.T) {
+	fake := &fakeLegacy{
+		createNat: func(ctx context.Context, nat *api.Nat) (*api.Nat, error) {
+			if _, ok := ctx.Deadline(); !ok {
+				t.Errorf("%s: expected the sub-call to inherit the batch deadline", nat.InterfaceID)
+			}
+			switch nat.InterfaceID {
+			case "snat":
+				return nat, dperrors.NewStatusError(dperrors.SNAT_EXISTS, "exists")
+			case "exists":
+				return nat, dperrors.NewStatusError(dperrors.ALREADY_EXISTS, "exists")
+			}
+			return nat, nil
+		},
+	}
+
+	nats := []*api.Nat{
+		{NatMeta: api.NatMeta{InterfaceID: "vm1"}},
+		{NatMeta: api.NatMeta{InterfaceID: "snat"}},
+		{NatMeta: api.NatMeta{InterfaceID: "exists"}},
+	}
+	list, bulkErr := AsV2(fake).NATs().CreateMany(context.Background(), nats,
+		WithIgnoreAlreadyExists(), WithTimeout(time.Minute))
+	if bulkErr != nil {
+		t.Fatalf("expected existing NATs to count as created, got %v", bulkErr)
+	}
+	if len(list.Items) != 3 {
+		t.Fatalf("expected 3 entries, got %+v", list.Items)
+	}
+}
+
+func TestRunBulkSharedSemaphore(t *testing.T) {
+	sem := semaphore.NewWeighted(4)
+	var inFlight, peak atomic.Int32
+	fn := func(context.Context, int) error {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		return nil
+	}
+
+	// Two bulk calls with a per-call bound of 4 share a budget of 4 units at
+	// weight 2, so at most two items may run at the same time overall.
+	o := buildCallOptions(WithConcurrency(4), WithSemaphore(sem, 2))
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if bulkErr := runBulk(context.Background(), 6, o, fn); bulkErr != nil {
+				t.Errorf("unexpected error: %v", bulkErr)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if p := peak.Load(); p > 2 {
+		t.Fatalf("expected at most 2 concurrent items, got %d", p)
+	}
+	if !sem.TryAcquire(4) {
+		t.Fatalf("expected all semaphore units to be released")
+	}
+}
+
+func TestRunBulkChunkSize(t *testing.T) {
+	var mu sync.Mutex
+	var inFlight, peak, done int
+	bulkErr := runBulk(context.Background(), 7, buildCallOptions(WithChunkSize(3), WithConcurrency(8)), func(_ context.Context, i int) error {
+		mu.Lock()
+		if started := i - i%3; done < started {
+			t.Errorf("item %d started before the %d items of earlier chunks finished", i, started)
+		}
+		inFlight++
+		peak = max(peak, inFlight)
+		mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		done++
+		mu.Unlock()
+		if i%2 == 1 {
+			return errors.New("odd")
+		}
+		return nil
+	})
+
+	if peak > 3 {
+		t.Fatalf("expected at most one chunk of 3 items in flight, got %d", peak)
+	}
+	if got := bulkErr.Failed(); len(got) != 3 || got[0] != 1 || got[1] != 3 || got[2] != 5 {
+		t.Fatalf("expected the failures of all chunks merged, got %v", got)
+	}
+}
+
+func TestRunBulkNoErrors(t *testing.T) {
+	bulkErr := runBulk(context.Background(), 10, callOptions{}, func(context.Context, int) error { return nil })
+	if bulkErr != nil {
+		t.Fatalf("expected nil BulkError, got %v", bulkErr)
+	}
+}
+
+func TestRunBulkBatchDeadline(t *testing.T) {
+	o := buildCallOptions(WithTimeout(30*time.Millisecond), WithPerItemTimeout(time.Minute), WithConcurrency(1))
+	ctx, cancel := o.bulkContext(context.Background())
+	defer cancel()
+
+	var started atomic.Int32
+	bulkErr := runBulk(ctx, 10, o, func(ctx context.Context, _ int) error {
+		started.Add(1)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	if got := len(bulkErr.Failed()); got != 10 {
+		t.Fatalf("expected all 10 items to fail, got %d", got)
+	}
+	if n := started.Load(); n != 1 {
+		t.Fatalf("expected the batch to abort after the first item, %d started", n)
+	}
+	if !errors.Is(bulkErr, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", bulkErr)
+	}
+}
+
+func TestRunBulkFairItemBudget(t *testing.T) {
+	o := buildCallOptions(WithConcurrency(2))
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// 6 items at concurrency 2 run in 3 rounds, so the first item may use
+	// about a third of the batch budget.
+	var budget time.Duration
+	_ = runBulk(ctx, 6, o, func(ctx context.Context, i int) error {
+		if i == 0 {
+			deadline, _ := ctx.Deadline()
+			budget = time.Until(deadline)
+		}
+		return nil
+	})
+	if budget <= 0 || budget > 400*time.Millisecond {
+		t.Fatalf("expected a budget of about a third of a second, got %v", budget)
+	}
+}
+
+func TestNATsCreateManyPerItemTimeout(t *testing.T) {
+	fake := &fakeLegacy{
+		createNat: func(ctx context.Context, nat *api.Nat) (*api.Nat, error) {
+			if nat.InterfaceID == "slow" {
+				<-ctx.Done()
+				return nat, ctx.Err()
+			}
+			return nat, nil
+		},
+	}
+	nats := []*api.Nat{
+		{NatMeta: api.NatMeta{InterfaceID: "vm1"}},
+		{NatMeta: api.NatMeta{InterfaceID: "slow"}},
+		{NatMeta: api.NatMeta{InterfaceID: "vm2"}},
+	}
+
+	start := time.Now()
+	list, bulkErr := AsV2(fake).NATs().CreateMany(context.Background(), nats,
+		WithTimeout(time.Minute), WithPerItemTimeout(20*time.Millisecond))
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected the slow item to be cut off by its own timeout, took %v", elapsed)
+	}
+	if got := bulkErr.Failed(); len(got) != 1 || got[0] != 1 {
+		t.Fatalf("expected only index 1 to fail, got %v", got)
+	}
+	if !errors.Is(bulkErr, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", bulkErr)
+	}
+	if len(list.Items) != 2 {
+		t.Fatalf("expected 2 created NATs, got %d", len(list.Items))
+	}
+}
+
+func TestRetryFailed(t *testing.T) {
+	items := []string{"a", "b", "c", "d"}
+	prev := &BulkError{Items: []BulkItemError{
+		{Index: 1, Err: errors.New("b failed")},
+		{Index: 3, Err: errors.New("d failed")},
+	}}
+
+	var mu sync.Mutex
+	var retried []string
+	bulkErr := RetryFailed(context.Background(), prev, items, func(_ context.Context, item string) error {
+		mu.Lock()
+		retried = append(retried, item)
+		mu.Unlock()
+		if item == "d" {
+			return errors.New("d failed again")
+		}
+		return nil
+	}, WithConcurrency(1))
+	if len(retried) != 2 || retried[0] != "b" || retried[1] != "d" {
+		t.Fatalf("expected only b and d to be retried, got %v", retried)
+	}
+	if failed := bulkErr.Failed(); len(failed) != 1 || failed[0] != 3 {
+		t.Fatalf("expected d to remain failed at index 3, got %v", bulkErr)
+	}
+
+	bulkErr = RetryFailed(context.Background(), bulkErr, items, func(context.Context, string) error { return nil })
+	if bulkErr != nil {
+		t.Fatalf("expected nil once every item succeeded, got %v", bulkErr)
+	}
+	if RetryFailed(context.Background(), nil, items, func(context.Context, string) error { return nil }) != nil {
+		t.Fatalf("expected nil for a nil BulkError")
+	}
+}
+
+func TestHelpersCanceledContext(t *testing.T) {
+	fake := &fakeLegacy{}
+	c := AsV2(fake)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	isCanceled := func(err error) bool {
+		var canceled *CanceledError
+		return errors.As(err, &canceled) && errors.Is(err, context.Canceled)
+	}
+	bulkCanceled := func(bulkErr *BulkError, n int) bool {
+		if bulkErr == nil || len(bulkErr.Items) != n {
+			return false
+		}
+		for _, item := range bulkErr.Items {
+			if !isCanceled(item.Err) {
+				return false
+			}
+		}
+		return true
+	}
+
+	if _, bulkErr := c.NATs().CreateMany(ctx, []*api.Nat{{}, {}}); !bulkCanceled(bulkErr, 2) {
+		t.Errorf("CreateMany: expected every item to fail with a CanceledError, got %v", bulkErr)
+	}
+	if _, bulkErr := c.Interfaces().GetMany(ctx, []string{"vm1"}); !bulkCanceled(bulkErr, 1) {
+		t.Errorf("GetMany: expected a CanceledError, got %v", bulkErr)
+	}
+	if _, bulkErr := c.Routes().ListMany(ctx, []uint32{100}); !bulkCanceled(bulkErr, 1) {
+		t.Errorf("ListMany: expected a CanceledError, got %v", bulkErr)
+	}
+	if bulkErr := c.System().ResetAllVnis(ctx, 0, []uint32{100}); !bulkCanceled(bulkErr, 1) {
+		t.Errorf("ResetAllVnis: expected a CanceledError, got %v", bulkErr)
+	}
+	if _, err := c.NATs().ListByInterface(ctx); !isCanceled(err) {
+		t.Errorf("ListByInterface: expected a CanceledError, got %v", err)
+	}
+	if _, err := c.LoadBalancers().ListWithInterfaces(ctx); !isCanceled(err) {
+		t.Errorf("ListWithInterfaces: expected a CanceledError, got %v", err)
+	}
+	if _, err := c.System().Summary(ctx); !isCanceled(err) {
+		t.Errorf("Summary: expected a CanceledError, got %v", err)
+	}
+	if _, err := c.Interfaces().WaitFor(ctx, "vm1", func(*api.Interface) bool { return true }, time.Millisecond); !isCanceled(err) {
+		t.Errorf("WaitFor: expected a CanceledError, got %v", err)
+	}
+	prev := &BulkError{Items: []BulkItemError{{Index: 3, Err: errors.New("failed")}}}
+	retried := RetryFailed(ctx, prev, []int{0, 1, 2, 3}, func(context.Context, int) error { return nil })
+	if !isCanceled(errors.Join(retried.Unwrap()...)) || retried.Failed()[0] != 3 {
+		t.Errorf("RetryFailed: expected index 3 to fail with a CanceledError, got %v", retried)
+	}
+
+	if len(fake.Calls()) != 0 {
+		t.Fatalf("expected no RPC with a canceled context, got %v", fake.Calls())
+	}
+}
@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+	dperrors "github.com/ironcore-dev/dpservice/go/dpservice-go/errors"
+)
+
+// Capabilities reports which optional operations a server supports.
+type Capabilities struct {
+	// Version is the server version, or nil if GetVersion is not supported.
+	Version *api.Version
+	// Supported maps probed operations, keyed "Domain.Method", to whether the
+	// server implements them.
+	Supported map[string]bool
+}
+
+// Supports reports whether domain.method is available on the server.
+// Operations that are not part of the probed optional set are assumed to be
+// supported.
+func (c *Capabilities) Supports(domain, method string) bool {
+	supported, probed := c.Supported[domain+"."+method]
+	return !probed || supported
+}
+
+// capabilityProbe is a read-only call deciding support for a group of
+// operations backed by the same server feature.
+type capabilityProbe struct {
+	covers []string
+	probe  func(ctx context.Context, c *core, opts ...CallOption) error
+}
+
+var capabilityProbes = []capabilityProbe{
+	{
+		covers: []string{DomainCapture + ".Start", DomainCapture + ".Stop", DomainCapture + ".Status"},
+		probe: func(ctx context.Context, c *core, opts ...CallOption) error {
+			_, err := (&captureClient{core: c}).Status(ctx, opts...)
+			return err
+		},
+	},
+	{
+		covers: []string{DomainSystem + ".GetVni", DomainSystem + ".ResetVni"},
+		probe: func(ctx context.Context, c *core, opts ...CallOption) error {
+			_, err := (&systemClient{core: c}).GetVni(ctx, 0, 0, opts...)
+			return err
+		},
+	},
+	{
+		covers: []string{DomainNATs + ".ListLocal"},
+		probe: func(ctx context.Context, c *core, opts ...CallOption) error {
+			ip := netip.IPv4Unspecified()
+			_, err := (&natClient{core: c}).ListLocal(ctx, &ip, opts...)
+			return err
+		},
+	},
+	{
+		covers: []string{DomainNATs + ".ListNeighbors", DomainNATs + ".CreateNeighbor", DomainNATs + ".DeleteNeighbor"},
+		probe: func(ctx context.Context, c *core, opts ...CallOption) error {
+			ip := netip.IPv4Unspecified()
+			_, err := (&natClient{core: c}).ListNeighbors(ctx, &ip, opts...)
+			return err
+		},
+	},
+	{
+		covers: []string{DomainLoadBalancers + ".List"},
+		probe: func(ctx context.Context, c *core, opts ...CallOption) error {
+			_, err := (&lbClient{core: c}).List(ctx, opts...)
+			return err
+		},
+	},
+}
+
+func (c *systemClient) Capabilities(ctx context.Context, opts ...CallOption) (*Capabilities, error) {
+	caps := &Capabilities{Supported: map[string]bool{}}
+
+	version, err := c.GetVersion(ctx, &api.Version{}, opts...)
+	if ok, err := probeSupported(err); err != nil {
+		return nil, err
+	} else if ok {
+		caps.Version = version
+	}
+
+	for _, p := range capabilityProbes {
+		ok, err := probeSupported(p.probe(ctx, c.core, opts...))
+		if err != nil {
+			return nil, err
+		}
+		for _, op := range p.covers {
+			caps.Supported[op] = ok
+		}
+	}
+	return caps, nil
+}
+
+// probeSupported interprets the error of a probe call. Only an answer of the
+// server proves that the RPC exists: a dpservice status error or a gRPC
+// status other than Unimplemented. Transport failures and errors raised by
+// the client, such as a closed client or an invalid argument, leave support
+// undecided and are returned.
+func probeSupported(err error) (bool, error) {
+	if err == nil {
+		return true, nil
+	}
+	var statusErr *dperrors.StatusError
+	if errors.As(err, &statusErr) {
+		return true, nil
+	}
+	if s, ok := status.FromError(err); ok {
+		switch s.Code() {
+		case codes.Unimplemented:
+			return false, nil
+		case codes.Unavailable, codes.DeadlineExceeded, codes.Canceled:
+			return false, err
+		}
+		return true, nil
+	}
+	return false, err
+}
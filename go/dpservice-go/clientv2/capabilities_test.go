@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	stderrors "errors"
+	"net/netip"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/errors"
+)
+
+func TestCapabilities(t *testing.T) {
+	unimplemented := status.Error(codes.Unimplemented, "unknown method")
+	fake := &fakeLegacy{
+		getVersion: func(_ context.Context, v *api.Version) (*api.Version, error) {
+			v.Spec.ServiceVersion = "v0.3.1"
+			return v, nil
+		},
+		captureStatus: func(context.Context) (*api.CaptureStatus, error) {
+			return &api.CaptureStatus{}, unimplemented
+		},
+		getVni: func(context.Context, uint32, uint8) (*api.Vni, error) {
+			return &api.Vni{}, errors.NewStatusError(errors.NO_VNI, "no vni")
+		},
+		listNeighborNats: func(context.Context, *netip.Addr) (*api.NatList, error) {
+			return &api.NatList{}, unimplemented
+		},
+	}
+
+	caps, err := AsV2(fake).System().Capabilities(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if caps.Version == nil || caps.Version.Spec.ServiceVersion != "v0.3.1" {
+		t.Fatalf("expected server version to be reported, got %+v", caps.Version)
+	}
+	for _, tc := range []struct {
+		domain, method string
+		want           bool
+	}{
+		{DomainCapture, "Start", false},
+		{DomainCapture, "Status", false},
+		{DomainSystem, "ResetVni", true},
+		{DomainNATs, "ListLocal", true},
+		{DomainNATs, "CreateNeighbor", false},
+		{DomainLoadBalancers, "List", true},
+		{DomainInterfaces, "Get", true},
+	} {
+		if got := caps.Supports(tc.domain, tc.method); got != tc.want {
+			t.Errorf("Supports(%s, %s) = %v, want %v", tc.domain, tc.method, got, tc.want)
+		}
+	}
+}
+
+func TestCapabilitiesUnavailable(t *testing.T) {
+	fake := &fakeLegacy{
+		getVersion: func(context.Context, *api.Version) (*api.Version, error) {
+			return &api.Version{}, status.Error(codes.Unavailable, "connection refused")
+		},
+	}
+	if _, err := AsV2(fake).System().Capabilities(context.Background()); status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected Unavailable error, got %v", err)
+	}
+}
+
+func TestCapabilitiesClosedClient(t *testing.T) {
+	c := AsV2(&fakeLegacy{})
+	if err := c.Close(); err != nil {
+		t.Fatalf("unexpected close error: %v", err)
+	}
+	var closed *ClientClosedError
+	if caps, err := c.System().Capabilities(context.Background()); !stderrors.As(err, &closed) {
+		t.Fatalf("expected a closed client to leave support undecided, got %+v, %v", caps, err)
+	}
+}
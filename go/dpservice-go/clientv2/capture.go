@@ -0,0 +1,109 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"time"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+)
+
+// CaptureSink is where dpservice sends captured packets, built by UDPSink.
+// dpservice supports a single kind of sink: it encapsulates the packets in
+// IPv6/UDP and sends them over the underlay to a sink node, the hypervisor
+// itself or a remote host. Writing them to a pcap file is left to the sink
+// node, e.g. with tcpdump on its physical interface; package capturestats
+// summarizes such a file.
+type CaptureSink struct {
+	addr    netip.AddrPort
+	srcPort uint16
+}
+
+// UDPSink returns the sink sending captured packets to the UDP port of the
+// IPv6 underlay address of a sink node. The packets are sent from the same
+// port unless WithSourcePort says otherwise.
+func UDPSink(addr netip.AddrPort) CaptureSink {
+	return CaptureSink{addr: addr, srcPort: addr.Port()}
+}
+
+// WithSourcePort returns the sink with the UDP source port of the packets
+// set to port.
+func (s CaptureSink) WithSourcePort(port uint16) CaptureSink {
+	s.srcPort = port
+	return s
+}
+
+// Config returns the capture configuration of the sink, or an error if the
+// address is not an IPv6 address or a port is zero.
+func (s CaptureSink) Config() (*api.CaptureConfig, error) {
+	addr := s.addr.Addr()
+	switch {
+	case !addr.IsValid():
+		return nil, errors.New("invalid capture sink: no address")
+	case !addr.Is6() || addr.Is4In6():
+		return nil, fmt.Errorf("invalid capture sink %s: not an IPv6 underlay address", addr)
+	case s.addr.Port() == 0:
+		return nil, fmt.Errorf("invalid capture sink %s: no destination port", s.addr)
+	case s.srcPort == 0:
+		return nil, fmt.Errorf("invalid capture sink %s: no source port", s.addr)
+	}
+	return &api.CaptureConfig{SinkNodeIP: &addr, UdpSrcPort: uint32(s.srcPort), UdpDstPort: uint32(s.addr.Port())}, nil
+}
+
+// NewCaptureStart returns the request for Capture().Start capturing the
+// given interfaces into sink, or the error of sink.Config.
+func NewCaptureStart(sink CaptureSink, interfaces ...api.CaptureInterface) (*api.CaptureStart, error) {
+	config, err := sink.Config()
+	if err != nil {
+		return nil, err
+	}
+	return &api.CaptureStart{
+		TypeMeta:         api.TypeMeta{Kind: api.CaptureStartKind},
+		CaptureStartMeta: api.CaptureStartMeta{Config: config},
+		Spec:             api.CaptureStartSpec{Interfaces: interfaces},
+	}, nil
+}
+
+// PFCapture returns the capture of the physical function with index pf.
+// dpservice can only capture PF 0.
+func PFCapture(pf int) api.CaptureInterface {
+	return api.CaptureInterface{InterfaceType: "pf", InterfaceInfo: fmt.Sprint(pf)}
+}
+
+// VFCapture returns the capture of the virtual function of an interface.
+func VFCapture(interfaceID string) api.CaptureInterface {
+	return api.CaptureInterface{InterfaceType: "vf", InterfaceInfo: interfaceID}
+}
+
+// checkCaptureStart rejects a capture request the legacy client cannot
+// send.
+func checkCaptureStart(capture *api.CaptureStart) error {
+	if capture == nil || capture.Config == nil {
+		return &InvalidArgumentError{Domain: DomainCapture, Method: "Start", Argument: "capture", Reason: "capture config is nil"}
+	}
+	return checkAddr(DomainCapture, "Start", "sink node IP", capture.Config.SinkNodeIP)
+}
+
+func (c *captureClient) StopIfRunning(ctx context.Context, maxAge time.Duration, opts ...CallOption) (*api.CaptureStatus, error) {
+	status, err := c.Status(ctx, opts...)
+	if err != nil || !status.Spec.OperationStatus {
+		return status, err
+	}
+	if _, err := c.Stop(ctx, opts...); err != nil {
+		return status, err
+	}
+	return status, nil
+}
+
+// ErrNoCapturePacketCount is the cause of the *NotSupportedError returned by
+// Capture().WaitForPackets.
+var ErrNoCapturePacketCount = errors.New("dpservice does not report how many packets a capture has seen")
+
+func (c *captureClient) WaitForPackets(_ context.Context, _ uint64, _ time.Duration, _ ...CallOption) (*api.CaptureStatus, error) {
+	return nil, &NotSupportedError{Domain: DomainCapture, Method: "WaitForPackets", Err: ErrNoCapturePacketCount}
+}
@@ -0,0 +1,112 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+)
+
+func TestUDPSink(t *testing.T) {
+	sink := UDPSink(netip.MustParseAddrPort("[fc00:2::64:0:1]:30100"))
+	config, err := sink.Config()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.SinkNodeIP.String() != "fc00:2::64:0:1" || config.UdpDstPort != 30100 || config.UdpSrcPort != 30100 {
+		t.Fatalf("expected the sink port as source and destination, got %+v", config)
+	}
+	if config, err := sink.WithSourcePort(30000).Config(); err != nil || config.UdpSrcPort != 30000 || config.UdpDstPort != 30100 {
+		t.Fatalf("expected source port 30000, got %v, %+v", err, config)
+	}
+
+	for _, invalid := range []CaptureSink{
+		{},
+		UDPSink(netip.MustParseAddrPort("192.0.2.1:30100")),
+		UDPSink(netip.MustParseAddrPort("[::ffff:192.0.2.1]:30100")),
+		UDPSink(netip.MustParseAddrPort("[fc00:2::64:0:1]:0")),
+		UDPSink(netip.MustParseAddrPort("[fc00:2::64:0:1]:30100")).WithSourcePort(0),
+	} {
+		if _, err := NewCaptureStart(invalid); err == nil {
+			t.Errorf("expected %+v to be rejected", invalid)
+		}
+	}
+}
+
+func TestCaptureStart(t *testing.T) {
+	var started *api.CaptureStart
+	fake := &fakeLegacy{
+		captureStart: func(_ context.Context, capture *api.CaptureStart) (*api.CaptureStart, error) {
+			started = capture
+			return capture, nil
+		},
+	}
+	capture := AsV2(fake).Capture()
+
+	req, err := NewCaptureStart(UDPSink(netip.MustParseAddrPort("[fc00:2::64:0:1]:30100")), PFCapture(0), VFCapture("vm1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := capture.Start(context.Background(), req); err != nil || started != req {
+		t.Fatalf("expected the built request to be sent, got %v", err)
+	}
+	want := []api.CaptureInterface{{InterfaceType: "pf", InterfaceInfo: "0"}, {InterfaceType: "vf", InterfaceInfo: "vm1"}}
+	if !reflect.DeepEqual(started.Spec.Interfaces, want) || started.Kind != api.CaptureStartKind {
+		t.Fatalf("expected interfaces %+v, got %+v", want, started)
+	}
+
+	var invalid *InvalidArgumentError
+	for _, req := range []*api.CaptureStart{nil, {}, {CaptureStartMeta: api.CaptureStartMeta{Config: &api.CaptureConfig{}}}} {
+		if _, err := capture.Start(context.Background(), req); !errors.As(err, &invalid) {
+			t.Errorf("expected an InvalidArgumentError for %+v, got %v", req, err)
+		}
+	}
+	if calls := fake.Calls(); len(calls) != 1 {
+		t.Fatalf("expected invalid requests to make no RPC, got %v", calls)
+	}
+}
+
+func TestCaptureStopIfRunning(t *testing.T) {
+	running := true
+	fake := &fakeLegacy{
+		captureStatus: func(context.Context) (*api.CaptureStatus, error) {
+			return &api.CaptureStatus{Spec: api.CaptureGetStatusSpec{OperationStatus: running}}, nil
+		},
+		captureStop: func(context.Context) (*api.CaptureStop, error) {
+			running = false
+			return &api.CaptureStop{}, nil
+		},
+	}
+	capture := AsV2(fake).Capture()
+
+	status, err := capture.StopIfRunning(context.Background(), time.Hour)
+	if err != nil || !status.Spec.OperationStatus || running {
+		t.Fatalf("expected the running capture to be stopped, got %v, %+v", err, status)
+	}
+	status, err = capture.StopIfRunning(context.Background(), time.Hour)
+	if err != nil || status.Spec.OperationStatus {
+		t.Fatalf("expected no capture to be running, got %v, %+v", err, status)
+	}
+	if want := []string{"CaptureStatus", "CaptureStop", "CaptureStatus"}; !reflect.DeepEqual(fake.Calls(), want) {
+		t.Fatalf("expected calls %v, got %v", want, fake.Calls())
+	}
+}
+
+func TestCaptureWaitForPackets(t *testing.T) {
+	fake := &fakeLegacy{}
+	_, err := AsV2(fake).Capture().WaitForPackets(context.Background(), 10, time.Millisecond)
+	var notSupported *NotSupportedError
+	if !errors.As(err, &notSupported) || !errors.Is(err, ErrNoCapturePacketCount) {
+		t.Fatalf("expected NotSupportedError wrapping ErrNoCapturePacketCount, got %v", err)
+	}
+	if len(fake.Calls()) != 0 {
+		t.Fatalf("expected no RPC, got %v", fake.Calls())
+	}
+}
@@ -0,0 +1,232 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package capturestats summarizes a dpservice packet capture. dpservice does
+// not keep capture results; it sends every captured frame, encapsulated in
+// IPv6/UDP, to the sink node configured with clientv2.UDPSink, where it can
+// be recorded to a pcap file, e.g. with
+//
+//	tcpdump -ni any udp dst port 30100 -w capture.pcap
+//
+// ReadFile decodes such a file and counts the captured frames:
+//
+//	summary, err := capturestats.ReadFile("capture.pcap", 30100)
+//	for mac, packets := range summary.BySource {
+//		fmt.Println(mac, packets)
+//	}
+package capturestats
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+)
+
+// Summary holds the counts of the captured frames of a pcap file.
+type Summary struct {
+	// Packets is the number of captured frames.
+	Packets int
+	// Bytes is the total original length of the captured frames, without
+	// the encapsulation.
+	Bytes int
+	// Skipped is the number of records that are not captured frames, such
+	// as other traffic or records truncated within the encapsulation.
+	Skipped int
+	// BySource counts the frames per source MAC address. The encapsulation
+	// does not say which interface a frame was captured on, but frames
+	// received on a virtual function are sent by its VM and carry its MAC.
+	BySource map[string]int
+	// ByProtocol counts the frames per protocol, e.g. "IPv4/TCP", "IPv6/UDP"
+	// or "ARP". Unknown protocols are given by number, e.g. "IPv4/47" or
+	// "0x88cc".
+	ByProtocol map[string]int
+}
+
+// ReadFile summarizes the captured frames in the pcap file at path, see
+// Read.
+func ReadFile(path string, dstPort uint16) (*Summary, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Read(f, dstPort)
+}
+
+// Link types of the pcap files Read accepts.
+const (
+	linkTypeEthernet = 1
+	linkTypeLinuxSLL = 113
+	linkTypeSLL2     = 276
+)
+
+const (
+	etherTypeIPv4 = 0x0800
+	etherTypeARP  = 0x0806
+	etherTypeVLAN = 0x8100
+	etherTypeIPv6 = 0x86dd
+
+	// maxRecordLen bounds the captured length of a record, so that a
+	// corrupt file cannot make Read allocate gigabytes.
+	maxRecordLen = 256 << 10
+
+	ipv6HeaderLen = 40
+	udpHeaderLen  = 8
+	protoUDP      = 17
+)
+
+// Read summarizes the captured frames in a pcap stream, in the classic
+// libpcap format with Ethernet or Linux cooked (tcpdump -i any) link
+// headers. Records are captured frames if they are IPv6/UDP packets to
+// dstPort, the destination port of the sink; a dstPort of zero accepts any
+// UDP port. A record longer than the snaplen of the file, or than 256 KiB,
+// ends the read with an error. pcapng files are not supported.
+func Read(r io.Reader, dstPort uint16) (*Summary, error) {
+	br := bufio.NewReader(r)
+	var header [24]byte
+	if _, err := io.ReadFull(br, header[:]); err != nil {
+		return nil, fmt.Errorf("read pcap header: %w", err)
+	}
+	var order binary.ByteOrder
+	switch magic := binary.LittleEndian.Uint32(header[:4]); magic {
+	case 0xa1b2c3d4, 0xa1b23c4d:
+		order = binary.LittleEndian
+	case 0xd4c3b2a1, 0x4d3cb2a1:
+		order = binary.BigEndian
+	case 0x0a0d0d0a:
+		return nil, errors.New("pcapng files are not supported, convert them with editcap -F pcap")
+	default:
+		return nil, fmt.Errorf("not a pcap file (magic %#08x)", magic)
+	}
+	linkType := order.Uint32(header[20:24]) & 0x0fffffff
+	// A snaplen of zero is written by some tools and means no limit.
+	snapLen := order.Uint32(header[16:20])
+	if snapLen == 0 || snapLen > maxRecordLen {
+		snapLen = maxRecordLen
+	}
+
+	summary := &Summary{BySource: map[string]int{}, ByProtocol: map[string]int{}}
+	var record [16]byte
+	for {
+		if _, err := io.ReadFull(br, record[:]); err != nil {
+			if errors.Is(err, io.EOF) {
+				return summary, nil
+			}
+			return summary, fmt.Errorf("read pcap record: %w", err)
+		}
+		inclLen := order.Uint32(record[8:12])
+		if inclLen > snapLen {
+			return summary, fmt.Errorf("pcap record of %d bytes exceeds the limit of %d bytes", inclLen, snapLen)
+		}
+		data := make([]byte, inclLen)
+		if _, err := io.ReadFull(br, data); err != nil {
+			return summary, fmt.Errorf("read pcap record: %w", err)
+		}
+		// The original length is never below the captured one in a valid
+		// file.
+		origLen := max(int(order.Uint32(record[12:16])), len(data))
+
+		offset, ok := decapsulate(data, linkType, dstPort)
+		if !ok {
+			summary.Skipped++
+			continue
+		}
+		summary.Packets++
+		summary.Bytes += origLen - offset
+		src, proto := classify(data[offset:])
+		summary.BySource[src]++
+		summary.ByProtocol[proto]++
+	}
+}
+
+// decapsulate returns the offset of the captured frame in a record, if the
+// record is a captured frame sent to dstPort.
+func decapsulate(data []byte, linkType uint32, dstPort uint16) (int, bool) {
+	var etherType uint16
+	var offset int
+	switch linkType {
+	case linkTypeEthernet:
+		offset = 14
+		if len(data) < offset {
+			return 0, false
+		}
+		etherType = binary.BigEndian.Uint16(data[12:14])
+	case linkTypeLinuxSLL:
+		offset = 16
+		if len(data) < offset {
+			return 0, false
+		}
+		etherType = binary.BigEndian.Uint16(data[14:16])
+	case linkTypeSLL2:
+		offset = 20
+		if len(data) < offset {
+			return 0, false
+		}
+		etherType = binary.BigEndian.Uint16(data[0:2])
+	default:
+		return 0, false
+	}
+	if etherType != etherTypeIPv6 || len(data) < offset+ipv6HeaderLen+udpHeaderLen+14 {
+		return 0, false
+	}
+	if data[offset+6] != protoUDP {
+		return 0, false
+	}
+	offset += ipv6HeaderLen
+	if port := binary.BigEndian.Uint16(data[offset+2 : offset+4]); dstPort != 0 && port != dstPort {
+		return 0, false
+	}
+	return offset + udpHeaderLen, true
+}
+
+// classify returns the source MAC and the protocol of an Ethernet frame of
+// at least 14 bytes.
+func classify(frame []byte) (src, proto string) {
+	src = net.HardwareAddr(frame[6:12]).String()
+	etherType := binary.BigEndian.Uint16(frame[12:14])
+	payload := frame[14:]
+	if etherType == etherTypeVLAN && len(payload) >= 4 {
+		etherType = binary.BigEndian.Uint16(payload[2:4])
+		payload = payload[4:]
+	}
+
+	switch etherType {
+	case etherTypeIPv4:
+		if len(payload) < 20 {
+			return src, "IPv4"
+		}
+		return src, "IPv4/" + ipProtocol(payload[9])
+	case etherTypeIPv6:
+		if len(payload) < ipv6HeaderLen {
+			return src, "IPv6"
+		}
+		return src, "IPv6/" + ipProtocol(payload[6])
+	case etherTypeARP:
+		return src, "ARP"
+	}
+	return src, fmt.Sprintf("%#04x", etherType)
+}
+
+// ipProtocol names the common IP protocols, including the IP-in-IPv6
+// tunnels of the underlay.
+func ipProtocol(proto byte) string {
+	switch proto {
+	case 1:
+		return "ICMP"
+	case 4:
+		return "IPIP"
+	case 6:
+		return "TCP"
+	case 17:
+		return "UDP"
+	case 41:
+		return "IPv6"
+	case 58:
+		return "ICMPv6"
+	}
+	return fmt.Sprint(proto)
+}
@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package capturestats_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/clientv2/capturestats"
+)
+
+// frame returns an Ethernet frame from src with the given ether type and
+// payload.
+func frame(src byte, etherType uint16, payload []byte) []byte {
+	f := []byte{0, 0, 0, 0, 0, 1, 0x02, 0, 0, 0, 0, src, 0, 0}
+	binary.BigEndian.PutUint16(f[12:], etherType)
+	return append(f, payload...)
+}
+
+// ipv4 returns an IPv4 header carrying proto.
+func ipv4(proto byte) []byte {
+	h := make([]byte, 20)
+	h[0], h[9] = 0x45, proto
+	return h
+}
+
+// ipv6 returns an IPv6 header carrying proto.
+func ipv6(proto byte) []byte {
+	h := make([]byte, 40)
+	h[0], h[6] = 0x60, proto
+	return h
+}
+
+// encapsulate wraps a captured frame the way dpservice sends it to the sink.
+func encapsulate(captured []byte, dstPort uint16) []byte {
+	udp := make([]byte, 8)
+	binary.BigEndian.PutUint16(udp[0:], 30000)
+	binary.BigEndian.PutUint16(udp[2:], dstPort)
+	return frame(0xff, 0x86dd, append(append(ipv6(17), udp...), captured...))
+}
+
+// pcap returns a little-endian pcap file with Ethernet link headers.
+func pcap(records ...[]byte) []byte {
+	var buf bytes.Buffer
+	header := make([]byte, 24)
+	binary.LittleEndian.PutUint32(header[0:], 0xa1b2c3d4)
+	binary.LittleEndian.PutUint16(header[4:], 2)
+	binary.LittleEndian.PutUint16(header[6:], 4)
+	binary.LittleEndian.PutUint32(header[16:], 65535)
+	binary.LittleEndian.PutUint32(header[20:], 1)
+	buf.Write(header)
+	for _, data := range records {
+		record := make([]byte, 16)
+		binary.LittleEndian.PutUint32(record[8:], uint32(len(data)))
+		binary.LittleEndian.PutUint32(record[12:], uint32(len(data)))
+		buf.Write(record)
+		buf.Write(data)
+	}
+	return buf.Bytes()
+}
+
+func TestRead(t *testing.T) {
+	tcp := frame(0x01, 0x0800, ipv4(6))
+	icmp6 := frame(0x02, 0x86dd, ipv6(58))
+	arp := frame(0x01, 0x0806, make([]byte, 28))
+	file := pcap(
+		encapsulate(tcp, 30100),
+		encapsulate(icmp6, 30100),
+		encapsulate(arp, 30100),
+		encapsulate(tcp, 4789),
+		frame(0x03, 0x0800, ipv4(17)),
+	)
+
+	summary, err := capturestats.Read(bytes.NewReader(file), 30100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &capturestats.Summary{
+		Packets: 3,
+		Bytes:   len(tcp) + len(icmp6) + len(arp),
+		Skipped: 2,
+		BySource: map[string]int{
+			"02:00:00:00:00:01": 2,
+			"02:00:00:00:00:02": 1,
+		},
+		ByProtocol: map[string]int{"IPv4/TCP": 1, "IPv6/ICMPv6": 1, "ARP": 1},
+	}
+	if !reflect.DeepEqual(summary, want) {
+		t.Fatalf("expected %+v, got %+v", want, summary)
+	}
+
+	if summary, err := capturestats.Read(bytes.NewReader(file), 0); err != nil || summary.Packets != 4 || summary.Skipped != 1 {
+		t.Fatalf("expected any port to be accepted, got %v, %+v", err, summary)
+	}
+}
+
+func TestReadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "capture.pcap")
+	if err := os.WriteFile(path, pcap(encapsulate(frame(0x01, 0x0800, ipv4(1)), 30100)), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	summary, err := capturestats.ReadFile(path, 30100)
+	if err != nil || summary.ByProtocol["IPv4/ICMP"] != 1 {
+		t.Fatalf("expected one ICMP frame, got %v, %+v", err, summary)
+	}
+
+	pcapng := []byte{0x0a, 0x0d, 0x0d, 0x0a, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+	if _, err := capturestats.Read(bytes.NewReader(pcapng), 0); err == nil {
+		t.Fatal("expected pcapng to be rejected")
+	}
+	if _, err := capturestats.Read(bytes.NewReader(pcap()[:10]), 0); err == nil {
+		t.Fatal("expected a truncated header to be rejected")
+	}
+
+	huge := pcap(encapsulate(frame(0x01, 0x0800, ipv4(1)), 30100))
+	binary.LittleEndian.PutUint32(huge[24+8:], 0xffffffff)
+	if summary, err := capturestats.Read(bytes.NewReader(huge), 0); err == nil || summary.Packets != 0 {
+		t.Fatalf("expected a record longer than the snaplen to be rejected, got %v, %+v", err, summary)
+	}
+
+	short := pcap(encapsulate(frame(0x01, 0x0800, ipv4(1)), 30100))
+	binary.LittleEndian.PutUint32(short[24+12:], 0)
+	if summary, err := capturestats.Read(bytes.NewReader(short), 0); err != nil || summary.Bytes < 0 {
+		t.Fatalf("expected an original length below the captured one to be tolerated, got %v, %+v", err, summary)
+	}
+}
@@ -5,79 +5,255 @@ package clientv2
 
 import (
 	"context"
+	"fmt"
 	"net/netip"
+	"reflect"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 
 	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
 	legacy "github.com/ironcore-dev/dpservice/go/dpservice-go/client"
 	dpdkproto "github.com/ironcore-dev/dpservice/go/dpservice-go/proto"
 )
 
-// CallOption allows customizing client call behavior.
-type CallOption func(*callOptions)
+// Client is the root v2 client exposing domain-specific sub-clients. It is
+// safe for concurrent use, see the package documentation.
+type Client interface {
+	LoadBalancers() LoadBalancers
+	Interfaces() Interfaces
+	Routes() Routes
+	NATs() NATs
+	Firewall() Firewall
+	System() System
+	Capture() Capture
 
-type callOptions struct {
-	ignoredCodes []uint32
+	// Close makes new and in-flight calls fail with a *ClientClosedError
+	// and closes the connection if the client owns one (see
+	// NewFromAddress). Closing an already closed client is a no-op.
+	Close() error
 }
 
-// WithIgnoredCodes configures error codes that should be treated as non-fatal.
-func WithIgnoredCodes(codes ...uint32) CallOption {
-	return func(o *callOptions) {
-		o.ignoredCodes = append(o.ignoredCodes, codes...)
-	}
+// Domain names identify the sub-client a call belongs to, for example in
+// errors and hooks.
+const (
+	DomainLoadBalancers        = "LoadBalancers"
+	DomainLoadBalancerPrefixes = "LoadBalancers.Prefixes"
+	DomainLoadBalancerTargets  = "LoadBalancers.Targets"
+	DomainInterfaces           = "Interfaces"
+	DomainVirtualIPs           = "Interfaces.VIP"
+	DomainInterfacePrefixes    = "Interfaces.Prefixes"
+	DomainRoutes               = "Routes"
+	DomainNATs                 = "NATs"
+	DomainFirewall             = "Firewall"
+	DomainSystem               = "System"
+	DomainCapture              = "Capture"
+)
+
+// NewFromProto builds a v2 Client from a grpc/proto client. The given
+// options are applied to every call as client defaults.
+func NewFromProto(rpc dpdkproto.DPDKironcoreClient, defaults ...CallOption) Client {
+	return &rootAdapter{core: newCore(legacy.NewClient(&rpcClient{DPDKironcoreClient: rpc}), defaults)}
 }
 
-func buildCallOptions(opts ...CallOption) callOptions {
-	var o callOptions
-	for _, opt := range opts {
-		if opt != nil {
-			opt(&o)
+// NewFromAddress dials addr with grpc.DialContext and returns a client
+// owning the connection, which Close closes. dialOpts must configure the
+// transport credentials, e.g. grpc.WithTransportCredentials(insecure.NewCredentials()).
+//
+// The connection is made lazily unless dialOpts include grpc.WithBlock. With
+// it, NewFromAddress waits until the connection is ready and returns a
+// *TimeoutError or *CanceledError if ctx ends first, so ctx should carry a
+// deadline when the server may be unreachable.
+func NewFromAddress(ctx context.Context, addr string, dialOpts ...grpc.DialOption) (Client, error) {
+	return newFromAddress(ctx, addr, dialOpts, nil)
+}
+
+func newFromAddress(ctx context.Context, addr string, dialOpts []grpc.DialOption, defaults []CallOption) (Client, error) {
+	dialOpts = append(dialOpts[:len(dialOpts):len(dialOpts)], grpc.WithStatsHandler(wireTapHandler{}))
+	conn, err := grpc.DialContext(ctx, addr, dialOpts...)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, waitError(ctx, "connection to "+addr, err)
 		}
+		return nil, fmt.Errorf("dial %s: %w", addr, err)
 	}
-	return o
+	rpc := dpdkproto.NewDPDKironcoreClient(conn)
+	return &rootAdapter{core: newCore(legacy.NewClient(&rpcClient{DPDKironcoreClient: rpc}), defaults), conn: conn}, nil
 }
 
-// toLegacyIgnored converts CallOptions to the legacy variadic []uint32 form.
-func toLegacyIgnored(opts ...CallOption) [][]uint32 {
-	o := buildCallOptions(opts...)
-	if len(o.ignoredCodes) == 0 {
-		return nil
-	}
-	return [][]uint32{o.ignoredCodes}
+// AsV2 adapts an existing legacy client to the v2 Client. The given options
+// are applied to every call as client defaults.
+func AsV2(c legacy.Client, defaults ...CallOption) Client {
+	return &rootAdapter{core: newCore(c, defaults)}
 }
 
-// Client is the root v2 client exposing domain-specific sub-clients.
-type Client interface {
-	LoadBalancers() LoadBalancers
-	Interfaces() Interfaces
-	Routes() Routes
-	NATs() NATs
-	Firewall() Firewall
-	System() System
-	Capture() Capture
+// core holds the state shared by the root client and all of its sub-clients.
+type core struct {
+	legacy   legacy.Client
+	defaults []CallOption
+	// closed is canceled by Close to fail new and in-flight calls.
+	closed context.Context
+	close  context.CancelFunc
+	// version caches the server version for WithRequireServerVersion and is
+	// shared with the cores of WithDefaults.
+	version *versionCache
 }
 
-// NewFromProto builds a v2 Client from a grpc/proto client.
-func NewFromProto(rpc dpdkproto.DPDKironcoreClient) Client {
-	return &rootAdapter{legacy: legacy.NewClient(rpc)}
+func newCore(c legacy.Client, defaults []CallOption) *core {
+	closed, cancel := context.WithCancel(context.Background())
+	return &core{legacy: c, defaults: defaults, closed: closed, close: cancel, version: &versionCache{}}
 }
 
-// AsV2 adapts an existing legacy client to the v2 Client.
-func AsV2(c legacy.Client) Client {
-	return &rootAdapter{legacy: c}
+// callOptions resolves the client defaults followed by the per-call options.
+func (c *core) callOptions(opts []CallOption) callOptions {
+	if len(c.defaults) == 0 {
+		return buildCallOptions(opts...)
+	}
+	all := make([]CallOption, 0, len(c.defaults)+len(opts))
+	all = append(all, c.defaults...)
+	return buildCallOptions(append(all, opts...)...)
+}
+
+// invoke runs fn, the legacy implementation of domain.method, with the
+// resolved call options and maps its error to the v2 error types.
+func invoke[T any](ctx context.Context, c *core, domain, method string, opts []CallOption, fn func(ctx context.Context, ignored ...[]uint32) (T, error)) (T, error) {
+	if c.closed.Err() != nil {
+		var zero T
+		return zero, &ClientClosedError{Domain: domain, Method: method}
+	}
+	o := c.callOptions(opts)
+	if len(o.fields) > 0 {
+		if err := checkFields(reflect.TypeOf((*T)(nil)).Elem(), o.fields); err != nil {
+			var zero T
+			return zero, err
+		}
+	}
+	if o.family != AddressFamilyAny && filtersFamily(*new(T)) {
+		if err := o.requireServerSide(domain, method); err != nil {
+			var zero T
+			return zero, err
+		}
+	}
+	if o.detached {
+		ctx = context.WithoutCancel(ctx)
+	}
+	if o.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.timeout)
+		defer cancel()
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	defer context.AfterFunc(c.closed, cancel)()
+	if err := c.checkServerVersion(ctx, o, domain, method); err != nil {
+		var zero T
+		return zero, err
+	}
+	if o.idempotencyKey != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, IdempotencyKeyMetadata, o.idempotencyKey)
+	}
+	if o.serverTimeout > 0 {
+		ctx = metadata.AppendToOutgoingContext(ctx, ServerTimeoutMetadata, serverTimeoutValue(o.serverTimeout))
+	}
+	if o.trailer != nil {
+		ctx = withGRPCCallOptions(ctx, grpc.Trailer(o.trailer))
+	}
+	listTrailer := truncationTrailer[T]()
+	if listTrailer != nil {
+		ctx = withGRPCCallOptions(ctx, grpc.Trailer(listTrailer))
+	}
+	for _, before := range o.before {
+		if hookCtx := before(domain, method, ctx); hookCtx != nil {
+			ctx = hookCtx
+		}
+	}
+	ctx, endSpan := startSpan(ctx, o, domain, method)
+	// Inject after the hooks so that spans they start are propagated.
+	ctx = injectPropagation(ctx, o)
+	if o.wireTap != nil {
+		ctx = withWireTap(ctx, domain, method, o.wireTap)
+	}
+
+	start := time.Now()
+	res, err := serialized(ctx, o, domain, method, func(ctx context.Context) (T, error) {
+		return retry(ctx, o, domain, method, func() (T, error) {
+			if err := waitRateLimits(ctx, o, domain, method); err != nil {
+				var zero T
+				return zero, err
+			}
+			return viaTransport(ctx, o, domain, method, func(ctx context.Context) (T, error) {
+				return callShared(ctx, o, domain, method, fn)
+			})
+		})
+	})
+	err = wrapError(domain, method, err)
+	if err != nil && c.closed.Err() != nil {
+		err = &ClientClosedError{Domain: domain, Method: method, Err: err}
+	}
+	if err != nil && o.trailer != nil {
+		err = &TrailerError{Err: err, Trailer: *o.trailer}
+	}
+	logCall(ctx, o.loggerFor(ctx), domain, method, time.Since(start), err)
+	if err == nil {
+		o.reportIgnored(domain, method, res)
+	}
+	if list, ok := normalizeList(res).(T); ok {
+		res = list
+	}
+	if err == nil {
+		err = serverTruncated(domain, method, res, listTrailer)
+	}
+	if o.family != AddressFamilyAny {
+		filterFamily(res, o.family)
+	}
+	if err == nil && o.maxItems > 0 {
+		err = limitItems(domain, method, res, o)
+	}
+	if len(o.fields) > 0 {
+		projectFields(res, o.fields)
+	}
+
+	took := time.Since(start)
+	endSpan(err)
+	observeCall(ctx, o, domain, method, res, took, err)
+	if o.events != nil {
+		o.events.send(Event{Domain: domain, Method: method, Start: start, Duration: took, Err: err})
+	}
+	for _, after := range o.after {
+		after(domain, method, err)
+	}
+	return res, err
 }
 
 // rootAdapter implements Client by delegating to the legacy client.
 type rootAdapter struct {
-	legacy legacy.Client
+	*core
+	// conn is set if the client owns its connection.
+	conn      *grpc.ClientConn
+	closeOnce atomic.Bool
 }
 
-func (r *rootAdapter) LoadBalancers() LoadBalancers { return &lbClient{legacy: r.legacy} }
-func (r *rootAdapter) Interfaces() Interfaces       { return &ifaceClient{legacy: r.legacy} }
-func (r *rootAdapter) Routes() Routes               { return &routeClient{legacy: r.legacy} }
-func (r *rootAdapter) NATs() NATs                   { return &natClient{legacy: r.legacy} }
-func (r *rootAdapter) Firewall() Firewall           { return &fwClient{legacy: r.legacy} }
-func (r *rootAdapter) System() System               { return &systemClient{legacy: r.legacy} }
-func (r *rootAdapter) Capture() Capture             { return &captureClient{legacy: r.legacy} }
+func (r *rootAdapter) Close() error {
+	if !r.closeOnce.CompareAndSwap(false, true) {
+		return nil
+	}
+	r.close()
+	if r.conn != nil {
+		return r.conn.Close()
+	}
+	return nil
+}
+
+func (r *rootAdapter) LoadBalancers() LoadBalancers { return &lbClient{core: r.core} }
+func (r *rootAdapter) Interfaces() Interfaces       { return &ifaceClient{core: r.core} }
+func (r *rootAdapter) Routes() Routes               { return &routeClient{core: r.core} }
+func (r *rootAdapter) NATs() NATs                   { return &natClient{core: r.core} }
+func (r *rootAdapter) Firewall() Firewall           { return &fwClient{core: r.core} }
+func (r *rootAdapter) System() System               { return &systemClient{core: r.core} }
+func (r *rootAdapter) Capture() Capture             { return &captureClient{core: r.core} }
 
 //
 // Load Balancers
@@ -88,62 +264,177 @@ type LoadBalancers interface {
 	List(ctx context.Context, opts ...CallOption) (*api.LoadBalancerList, error)
 	Create(ctx context.Context, lb *api.LoadBalancer, opts ...CallOption) (*api.LoadBalancer, error)
 	Delete(ctx context.Context, id string, opts ...CallOption) (*api.LoadBalancer, error)
+	// Ensure makes the server match the desired load balancer, see Interfaces.Ensure.
+	Ensure(ctx context.Context, lb *api.LoadBalancer, opts ...CallOption) (*api.LoadBalancer, bool, error)
+	// CreateOrGet creates the load balancer and reports whether it did, see
+	// Interfaces.CreateOrGet.
+	CreateOrGet(ctx context.Context, lb *api.LoadBalancer, opts ...CallOption) (*api.LoadBalancer, bool, error)
+	// Describe fetches a load balancer together with its targets and, if
+	// interfaceID is set, the loadbalancer prefixes of that interface. Failing
+	// sections are reported in the detail instead of failing the call.
+	Describe(ctx context.Context, lbID, interfaceID string, opts ...CallOption) (*LoadBalancerDetail, error)
+	// VerifyProgrammed checks that the load balancer has exactly the wanted
+	// targets and, on interfaceID, the wanted loadbalancer prefixes, and
+	// reports the missing and extra entries. dpservice does not record which
+	// load balancer a loadbalancer prefix belongs to, so every prefix of the
+	// interface counts. Unlike Describe, it fails if any part cannot be
+	// fetched.
+	VerifyProgrammed(ctx context.Context, lbID, interfaceID string, wantTargets []netip.Addr, wantPrefixes []netip.Prefix, opts ...CallOption) (*LBVerification, error)
+	// WaitFor polls Get every poll interval until pred holds for the load
+	// balancer and returns it. See Interfaces.WaitFor.
+	WaitFor(ctx context.Context, id string, pred func(*api.LoadBalancer) bool, poll time.Duration, opts ...CallOption) (*api.LoadBalancer, error)
+	// SelectTarget returns the target the load balancer picks for flow. It
+	// rebuilds the Maglev lookup table of the server from the current
+	// targets and indexes it with the flow hash, DefaultFlowHash unless
+	// WithFlowHash or WithTargetHasher is given. Flows to ports the load
+	// balancer does not balance and load balancers without targets yield
+	// NOT_FOUND.
+	SelectTarget(ctx context.Context, lbID string, flow FlowTuple, opts ...CallOption) (*api.LoadBalancerTarget, error)
+	// ListWithInterfaces lists the load balancers together with the
+	// interface routing each one's virtual IP. dpservice does not store an
+	// interface on a load balancer, so the interface is the first one, in
+	// list order, of the same VNI holding a loadbalancer prefix that covers
+	// the virtual IP. The loadbalancer prefixes of all interfaces are listed
+	// concurrently, bounded by WithConcurrency, and interfaces deleted in the
+	// meantime are skipped. If some of them cannot be listed, the result is
+	// returned along with a *BulkError indexed by interface list position.
+	ListWithInterfaces(ctx context.Context, opts ...CallOption) ([]LBWithInterface, error)
 
 	Prefixes() LoadBalancerPrefixes
 	Targets() LoadBalancerTargets
+	// WithDefaults returns a LoadBalancers client whose calls, and those of its
+	// sub-clients, apply opts after the client defaults and before the
+	// per-call options.
+	WithDefaults(opts ...CallOption) LoadBalancers
 }
 
 type LoadBalancerPrefixes interface {
 	List(ctx context.Context, interfaceID string, opts ...CallOption) (*api.PrefixList, error)
+	// Get returns the loadbalancer prefix of the interface, found by listing
+	// them. A NOT_FOUND status error is returned if none matches.
+	Get(ctx context.Context, interfaceID string, prefix netip.Prefix, opts ...CallOption) (*api.LoadBalancerPrefix, error)
+	// Exists reports whether the interface has the loadbalancer prefix.
+	Exists(ctx context.Context, interfaceID string, prefix netip.Prefix, opts ...CallOption) (bool, error)
 	Create(ctx context.Context, prefix *api.LoadBalancerPrefix, opts ...CallOption) (*api.LoadBalancerPrefix, error)
 	Delete(ctx context.Context, interfaceID string, prefix *netip.Prefix, opts ...CallOption) (*api.LoadBalancerPrefix, error)
+	// WithDefaults scopes additional defaults to this client, see
+	// LoadBalancers.WithDefaults.
+	WithDefaults(opts ...CallOption) LoadBalancerPrefixes
 }
 
 type LoadBalancerTargets interface {
 	List(ctx context.Context, loadBalancerID string, opts ...CallOption) (*api.LoadBalancerTargetList, error)
+	// Get returns the target of lbID with the given IP. The server has no
+	// single-target lookup, so the targets are listed and a NOT_FOUND status
+	// error is returned if none matches.
+	Get(ctx context.Context, lbID string, targetIP netip.Addr, opts ...CallOption) (*api.LoadBalancerTarget, error)
 	Create(ctx context.Context, target *api.LoadBalancerTarget, opts ...CallOption) (*api.LoadBalancerTarget, error)
 	Delete(ctx context.Context, lbID string, targetIP *netip.Addr, opts ...CallOption) (*api.LoadBalancerTarget, error)
+	// SetWeight would set the share of traffic of a target. dpservice
+	// balances targets evenly and has no weights, so after validating its
+	// arguments it always fails with a *NotSupportedError wrapping
+	// ErrNoTargetWeights, without any RPC, for callers to feature-detect.
+	SetWeight(ctx context.Context, lbID string, targetIP netip.Addr, weight uint32, opts ...CallOption) (*api.LoadBalancerTarget, error)
+	// WithDefaults scopes additional defaults to this client, see
+	// LoadBalancers.WithDefaults.
+	WithDefaults(opts ...CallOption) LoadBalancerTargets
 }
 
-type lbClient struct{ legacy legacy.Client }
+type lbClient struct{ *core }
 
 func (c *lbClient) Get(ctx context.Context, id string, opts ...CallOption) (*api.LoadBalancer, error) {
-	return c.legacy.GetLoadBalancer(ctx, id, toLegacyIgnored(opts...)...)
+	if err := checkID(DomainLoadBalancers, "Get", "id", id); err != nil {
+		return nil, err
+	}
+	return invoke(ctx, c.core, DomainLoadBalancers, "Get", withFlightKey(withCallArgs(opts, id), id), func(ctx context.Context, ignored ...[]uint32) (*api.LoadBalancer, error) {
+		return c.legacy.GetLoadBalancer(ctx, id, ignored...)
+	})
 }
 func (c *lbClient) List(ctx context.Context, opts ...CallOption) (*api.LoadBalancerList, error) {
-	return c.legacy.ListLoadBalancers(ctx, toLegacyIgnored(opts...)...)
+	return invoke(ctx, c.core, DomainLoadBalancers, "List", opts, func(ctx context.Context, ignored ...[]uint32) (*api.LoadBalancerList, error) {
+		return c.legacy.ListLoadBalancers(ctx, ignored...)
+	})
 }
 func (c *lbClient) Create(ctx context.Context, lb *api.LoadBalancer, opts ...CallOption) (*api.LoadBalancer, error) {
-	return c.legacy.CreateLoadBalancer(ctx, lb, toLegacyIgnored(opts...)...)
+	o := c.callOptions(opts)
+	lb = withGeneratedID(o, DomainLoadBalancers, normalized(o, lb), func(lb *api.LoadBalancer) *string { return &lb.ID })
+	return invoke(ctx, c.core, DomainLoadBalancers, "Create", withExisting(withCallArgs(opts, lb), func(ctx context.Context) (*api.LoadBalancer, error) {
+		return c.Get(ctx, lb.ID, opts...)
+	}), func(ctx context.Context, ignored ...[]uint32) (*api.LoadBalancer, error) {
+		return c.legacy.CreateLoadBalancer(ctx, lb, ignored...)
+	})
 }
 func (c *lbClient) Delete(ctx context.Context, id string, opts ...CallOption) (*api.LoadBalancer, error) {
-	return c.legacy.DeleteLoadBalancer(ctx, id, toLegacyIgnored(opts...)...)
+	if err := checkID(DomainLoadBalancers, "Delete", "id", id); err != nil {
+		return nil, err
+	}
+	return invoke(ctx, c.core, DomainLoadBalancers, "Delete", withCallArgs(opts, id), func(ctx context.Context, ignored ...[]uint32) (*api.LoadBalancer, error) {
+		return c.legacy.DeleteLoadBalancer(ctx, id, ignored...)
+	})
 }
-func (c *lbClient) Prefixes() LoadBalancerPrefixes { return &lbPrefixesClient{legacy: c.legacy} }
-func (c *lbClient) Targets() LoadBalancerTargets   { return &lbTargetsClient{legacy: c.legacy} }
+func (c *lbClient) Prefixes() LoadBalancerPrefixes { return &lbPrefixesClient{core: c.core} }
+func (c *lbClient) Targets() LoadBalancerTargets   { return &lbTargetsClient{core: c.core} }
 
-type lbPrefixesClient struct{ legacy legacy.Client }
+type lbPrefixesClient struct{ *core }
 
 func (c *lbPrefixesClient) List(ctx context.Context, interfaceID string, opts ...CallOption) (*api.PrefixList, error) {
-	return c.legacy.ListLoadBalancerPrefixes(ctx, interfaceID, toLegacyIgnored(opts...)...)
+	if err := checkID(DomainLoadBalancerPrefixes, "List", "interfaceID", interfaceID); err != nil {
+		return nil, err
+	}
+	return invoke(ctx, c.core, DomainLoadBalancerPrefixes, "List", withCallArgs(opts, interfaceID), func(ctx context.Context, ignored ...[]uint32) (*api.PrefixList, error) {
+		return c.legacy.ListLoadBalancerPrefixes(ctx, interfaceID, ignored...)
+	})
 }
 func (c *lbPrefixesClient) Create(ctx context.Context, prefix *api.LoadBalancerPrefix, opts ...CallOption) (*api.LoadBalancerPrefix, error) {
-	return c.legacy.CreateLoadBalancerPrefix(ctx, prefix, toLegacyIgnored(opts...)...)
+	prefix = normalized(c.callOptions(opts), prefix)
+	return invoke(ctx, c.core, DomainLoadBalancerPrefixes, "Create", withExisting(withCallArgs(opts, prefix), func(ctx context.Context) (*api.LoadBalancerPrefix, error) {
+		return c.Get(ctx, prefix.InterfaceID, prefix.Spec.Prefix, opts...)
+	}), func(ctx context.Context, ignored ...[]uint32) (*api.LoadBalancerPrefix, error) {
+		return c.legacy.CreateLoadBalancerPrefix(ctx, prefix, ignored...)
+	})
 }
 func (c *lbPrefixesClient) Delete(ctx context.Context, interfaceID string, prefix *netip.Prefix, opts ...CallOption) (*api.LoadBalancerPrefix, error) {
-	return c.legacy.DeleteLoadBalancerPrefix(ctx, interfaceID, prefix, toLegacyIgnored(opts...)...)
+	if err := checkID(DomainLoadBalancerPrefixes, "Delete", "interfaceID", interfaceID); err != nil {
+		return nil, err
+	}
+	if err := checkPrefix(DomainLoadBalancerPrefixes, "Delete", "prefix", prefix); err != nil {
+		return nil, err
+	}
+	prefix = normalized(c.callOptions(opts), prefix)
+	return invoke(ctx, c.core, DomainLoadBalancerPrefixes, "Delete", withCallArgs(opts, interfaceID, prefix), func(ctx context.Context, ignored ...[]uint32) (*api.LoadBalancerPrefix, error) {
+		return c.legacy.DeleteLoadBalancerPrefix(ctx, interfaceID, prefix, ignored...)
+	})
 }
 
-type lbTargetsClient struct{ legacy legacy.Client }
+type lbTargetsClient struct{ *core }
 
 func (c *lbTargetsClient) List(ctx context.Context, loadBalancerID string, opts ...CallOption) (*api.LoadBalancerTargetList, error) {
-	return c.legacy.ListLoadBalancerTargets(ctx, loadBalancerID, toLegacyIgnored(opts...)...)
+	if err := checkID(DomainLoadBalancerTargets, "List", "loadBalancerID", loadBalancerID); err != nil {
+		return nil, err
+	}
+	return invoke(ctx, c.core, DomainLoadBalancerTargets, "List", withCallArgs(opts, loadBalancerID), func(ctx context.Context, ignored ...[]uint32) (*api.LoadBalancerTargetList, error) {
+		return c.legacy.ListLoadBalancerTargets(ctx, loadBalancerID, ignored...)
+	})
 }
 func (c *lbTargetsClient) Create(ctx context.Context, target *api.LoadBalancerTarget, opts ...CallOption) (*api.LoadBalancerTarget, error) {
-	return c.legacy.CreateLoadBalancerTarget(ctx, target, toLegacyIgnored(opts...)...)
+	target = normalized(c.callOptions(opts), target)
+	return invoke(ctx, c.core, DomainLoadBalancerTargets, "Create", withExisting(withCallArgs(opts, target), func(ctx context.Context) (*api.LoadBalancerTarget, error) {
+		return c.Get(ctx, target.LoadbalancerID, *target.Spec.TargetIP, opts...)
+	}), func(ctx context.Context, ignored ...[]uint32) (*api.LoadBalancerTarget, error) {
+		return c.legacy.CreateLoadBalancerTarget(ctx, target, ignored...)
+	})
 }
 func (c *lbTargetsClient) Delete(ctx context.Context, lbID string, targetIP *netip.Addr, opts ...CallOption) (*api.LoadBalancerTarget, error) {
-	return c.legacy.DeleteLoadBalancerTarget(ctx, lbID, targetIP, toLegacyIgnored(opts...)...)
+	if err := checkID(DomainLoadBalancerTargets, "Delete", "lbID", lbID); err != nil {
+		return nil, err
+	}
+	if err := checkAddr(DomainLoadBalancerTargets, "Delete", "targetIP", targetIP); err != nil {
+		return nil, err
+	}
+	targetIP = normalized(c.callOptions(opts), targetIP)
+	return invoke(ctx, c.core, DomainLoadBalancerTargets, "Delete", withCallArgs(opts, lbID, targetIP), func(ctx context.Context, ignored ...[]uint32) (*api.LoadBalancerTarget, error) {
+		return c.legacy.DeleteLoadBalancerTarget(ctx, lbID, targetIP, ignored...)
+	})
 }
 
 //
@@ -155,64 +446,207 @@ type Interfaces interface {
 	List(ctx context.Context, opts ...CallOption) (*api.InterfaceList, error)
 	Create(ctx context.Context, iface *api.Interface, opts ...CallOption) (*api.Interface, error)
 	Delete(ctx context.Context, id string, opts ...CallOption) (*api.Interface, error)
+	// Ensure makes the server match the desired interface and reports whether
+	// it changed anything: a missing interface is created, one that differs
+	// by Fingerprint is deleted and created again, as dpservice has no update
+	// RPC, and an equal one is returned as is. Only the fields Get returns
+	// are compared: PXE settings are ignored and an empty Device matches the
+	// device the server picked. Replacing is not atomic, and
+	// the server refuses to delete an interface that still has dependents
+	// such as prefixes or a virtual IP.
+	Ensure(ctx context.Context, iface *api.Interface, opts ...CallOption) (*api.Interface, bool, error)
+	// CreateExclusive creates the interface like Create, but if the ID is
+	// already taken it returns an *InterfaceConflictError holding the
+	// existing interface, so that a controller can tell whether it is its
+	// own. dpservice has no notion of interface ownership and no RPC to take
+	// an interface over, so there is no Claim or Release.
+	CreateExclusive(ctx context.Context, iface *api.Interface, opts ...CallOption) (*api.Interface, error)
+	// CreateOrGet creates the interface like Create and reports true, or,
+	// if the ID is already taken, returns the existing interface as fetched
+	// by Get and reports false. Unlike Ensure it does not compare the two, so
+	// the existing interface may differ from iface. A Create recovered under
+	// WithIdempotencyKey counts as created.
+	CreateOrGet(ctx context.Context, iface *api.Interface, opts ...CallOption) (*api.Interface, bool, error)
+	// GetByDevice returns the interface using a device, e.g. a PCI address,
+	// found by listing the interfaces. A NOT_FOUND status error is returned
+	// if none uses it and an *AmbiguousDeviceError if several do.
+	GetByDevice(ctx context.Context, device string, opts ...CallOption) (*api.Interface, error)
+	// GroupByVNI lists the interfaces once and groups them by VNI, keeping
+	// list order within each group. Only VNIs with interfaces have a list.
+	GroupByVNI(ctx context.Context, opts ...CallOption) (map[uint32]*api.InterfaceList, error)
+	// DeleteCascade removes the firewall rules, prefixes, loadbalancer
+	// prefixes, virtual IP and NAT of an interface before deleting the
+	// interface itself. Missing resources are skipped; other failures are
+	// joined into the returned error.
+	DeleteCascade(ctx context.Context, interfaceID string, opts ...CallOption) error
+	// GetMany fetches the given interfaces concurrently (see
+	// WithConcurrency). Missing interfaces are omitted from the map unless
+	// WithIncludeMissing is set; other failures are reported per input index
+	// in the BulkError.
+	GetMany(ctx context.Context, ids []string, opts ...CallOption) (map[string]*api.Interface, *BulkError)
+	// WaitFor polls Get every poll interval until pred holds for the
+	// interface and returns it. A missing interface is polled again, other
+	// errors end the wait. If ctx ends first, a *TimeoutError or
+	// *CanceledError is returned.
+	WaitFor(ctx context.Context, id string, pred func(*api.Interface) bool, poll time.Duration, opts ...CallOption) (*api.Interface, error)
+	// WaitUntilGone polls Get every poll interval until the interface is
+	// missing and returns nil, e.g. to confirm that DeleteCascade finished
+	// before reusing the ID. Other errors end the wait. If ctx ends first, a
+	// *TimeoutError or *CanceledError is returned.
+	WaitUntilGone(ctx context.Context, interfaceID string, poll time.Duration, opts ...CallOption) error
+	// WatchDiffs lists the interfaces every poll interval and sends an
+	// InterfaceEvent for each interface added, modified or deleted since the
+	// previous successful List; the first List reports every interface as
+	// added. Polls without changes send nothing. List errors are sent on the
+	// error channel and the previous list is kept. Both channels must be
+	// received from, and both are closed once ctx is done. dpservice has no
+	// watch RPC, so changes undone between two polls are not seen.
+	WatchDiffs(ctx context.Context, poll time.Duration, opts ...CallOption) (<-chan InterfaceEvent, <-chan error)
 
 	VIP() VirtualIPs
 	Prefixes() InterfacePrefixes
 	Firewall() Firewall
+	// WithDefaults scopes additional defaults to this client, see
+	// LoadBalancers.WithDefaults.
+	WithDefaults(opts ...CallOption) Interfaces
 }
 
 type VirtualIPs interface {
 	Get(ctx context.Context, interfaceID string, opts ...CallOption) (*api.VirtualIP, error)
 	Create(ctx context.Context, vip *api.VirtualIP, opts ...CallOption) (*api.VirtualIP, error)
 	Delete(ctx context.Context, interfaceID string, opts ...CallOption) (*api.VirtualIP, error)
+	// Ensure makes the server match the desired virtual IP, see Interfaces.Ensure.
+	Ensure(ctx context.Context, vip *api.VirtualIP, opts ...CallOption) (*api.VirtualIP, bool, error)
+	// WaitFor polls Get every poll interval until pred holds for the
+	// virtual IP and returns it. See Interfaces.WaitFor.
+	WaitFor(ctx context.Context, interfaceID string, pred func(*api.VirtualIP) bool, poll time.Duration, opts ...CallOption) (*api.VirtualIP, error)
+	// WithDefaults scopes additional defaults to this client, see
+	// LoadBalancers.WithDefaults.
+	WithDefaults(opts ...CallOption) VirtualIPs
 }
 
 type InterfacePrefixes interface {
 	List(ctx context.Context, interfaceID string, opts ...CallOption) (*api.PrefixList, error)
+	// ListAll lists the prefixes of all interfaces, each with its
+	// InterfaceID set, in interface list order. It costs one List of the
+	// interfaces plus one List per interface, issued concurrently (see
+	// WithConcurrency). Interfaces deleted in the meantime are skipped; if
+	// the prefixes of others cannot be listed, the remaining prefixes are
+	// returned along with a *BulkError indexed by interface list position.
+	ListAll(ctx context.Context, opts ...CallOption) (*api.PrefixList, error)
+	// Get returns the prefix of the interface, found by listing them. A
+	// NOT_FOUND status error is returned if none matches.
+	Get(ctx context.Context, interfaceID string, prefix netip.Prefix, opts ...CallOption) (*api.Prefix, error)
+	// Exists reports whether the interface has the prefix.
+	Exists(ctx context.Context, interfaceID string, prefix netip.Prefix, opts ...CallOption) (bool, error)
+	// Create creates the prefix. The Vni of prefix is not sent; with
+	// WithResolveVNI, a zero Vni of the returned prefix is filled in from
+	// the interface.
 	Create(ctx context.Context, prefix *api.Prefix, opts ...CallOption) (*api.Prefix, error)
 	Delete(ctx context.Context, interfaceID string, prefix *netip.Prefix, opts ...CallOption) (*api.Prefix, error)
+	// WithDefaults scopes additional defaults to this client, see
+	// LoadBalancers.WithDefaults.
+	WithDefaults(opts ...CallOption) InterfacePrefixes
 }
 
-type ifaceClient struct{ legacy legacy.Client }
+type ifaceClient struct{ *core }
 
 func (c *ifaceClient) Get(ctx context.Context, id string, opts ...CallOption) (*api.Interface, error) {
-	return c.legacy.GetInterface(ctx, id, toLegacyIgnored(opts...)...)
+	if err := checkID(DomainInterfaces, "Get", "id", id); err != nil {
+		return nil, err
+	}
+	return invoke(ctx, c.core, DomainInterfaces, "Get", withFlightKey(withCallArgs(opts, id), id), func(ctx context.Context, ignored ...[]uint32) (*api.Interface, error) {
+		return c.legacy.GetInterface(ctx, id, ignored...)
+	})
 }
 func (c *ifaceClient) List(ctx context.Context, opts ...CallOption) (*api.InterfaceList, error) {
-	return c.legacy.ListInterfaces(ctx, toLegacyIgnored(opts...)...)
+	return invoke(ctx, c.core, DomainInterfaces, "List", opts, func(ctx context.Context, ignored ...[]uint32) (*api.InterfaceList, error) {
+		return c.legacy.ListInterfaces(ctx, ignored...)
+	})
 }
 func (c *ifaceClient) Create(ctx context.Context, iface *api.Interface, opts ...CallOption) (*api.Interface, error) {
-	return c.legacy.CreateInterface(ctx, iface, toLegacyIgnored(opts...)...)
+	o := c.callOptions(opts)
+	iface = withGeneratedID(o, DomainInterfaces, normalized(o, iface), func(iface *api.Interface) *string { return &iface.ID })
+	return invoke(ctx, c.core, DomainInterfaces, "Create", withExisting(withCallArgs(opts, iface), func(ctx context.Context) (*api.Interface, error) {
+		return c.Get(ctx, iface.ID, opts...)
+	}), func(ctx context.Context, ignored ...[]uint32) (*api.Interface, error) {
+		return c.legacy.CreateInterface(ctx, iface, ignored...)
+	})
 }
 func (c *ifaceClient) Delete(ctx context.Context, id string, opts ...CallOption) (*api.Interface, error) {
-	return c.legacy.DeleteInterface(ctx, id, toLegacyIgnored(opts...)...)
+	if err := checkID(DomainInterfaces, "Delete", "id", id); err != nil {
+		return nil, err
+	}
+	return invoke(ctx, c.core, DomainInterfaces, "Delete", withCallArgs(opts, id), func(ctx context.Context, ignored ...[]uint32) (*api.Interface, error) {
+		return c.legacy.DeleteInterface(ctx, id, ignored...)
+	})
 }
-func (c *ifaceClient) VIP() VirtualIPs             { return &vipClient{legacy: c.legacy} }
-func (c *ifaceClient) Prefixes() InterfacePrefixes { return &ifacePrefixesClient{legacy: c.legacy} }
-func (c *ifaceClient) Firewall() Firewall          { return &fwClient{legacy: c.legacy} }
+func (c *ifaceClient) VIP() VirtualIPs             { return &vipClient{core: c.core} }
+func (c *ifaceClient) Prefixes() InterfacePrefixes { return &ifacePrefixesClient{core: c.core} }
+func (c *ifaceClient) Firewall() Firewall          { return &fwClient{core: c.core} }
 
-type vipClient struct{ legacy legacy.Client }
+type vipClient struct{ *core }
 
 func (c *vipClient) Get(ctx context.Context, interfaceID string, opts ...CallOption) (*api.VirtualIP, error) {
-	return c.legacy.GetVirtualIP(ctx, interfaceID, toLegacyIgnored(opts...)...)
+	if err := checkID(DomainVirtualIPs, "Get", "interfaceID", interfaceID); err != nil {
+		return nil, err
+	}
+	return invoke(ctx, c.core, DomainVirtualIPs, "Get", withFlightKey(withCallArgs(opts, interfaceID), interfaceID), func(ctx context.Context, ignored ...[]uint32) (*api.VirtualIP, error) {
+		return c.legacy.GetVirtualIP(ctx, interfaceID, ignored...)
+	})
 }
 func (c *vipClient) Create(ctx context.Context, vip *api.VirtualIP, opts ...CallOption) (*api.VirtualIP, error) {
-	return c.legacy.CreateVirtualIP(ctx, vip, toLegacyIgnored(opts...)...)
+	vip = normalized(c.callOptions(opts), vip)
+	return invoke(ctx, c.core, DomainVirtualIPs, "Create", withExisting(withCallArgs(opts, vip), func(ctx context.Context) (*api.VirtualIP, error) {
+		return c.Get(ctx, vip.InterfaceID, opts...)
+	}), func(ctx context.Context, ignored ...[]uint32) (*api.VirtualIP, error) {
+		return c.legacy.CreateVirtualIP(ctx, vip, ignored...)
+	})
 }
 func (c *vipClient) Delete(ctx context.Context, interfaceID string, opts ...CallOption) (*api.VirtualIP, error) {
-	return c.legacy.DeleteVirtualIP(ctx, interfaceID, toLegacyIgnored(opts...)...)
+	if err := checkID(DomainVirtualIPs, "Delete", "interfaceID", interfaceID); err != nil {
+		return nil, err
+	}
+	return invoke(ctx, c.core, DomainVirtualIPs, "Delete", withCallArgs(opts, interfaceID), func(ctx context.Context, ignored ...[]uint32) (*api.VirtualIP, error) {
+		return c.legacy.DeleteVirtualIP(ctx, interfaceID, ignored...)
+	})
 }
 
-type ifacePrefixesClient struct{ legacy legacy.Client }
+type ifacePrefixesClient struct{ *core }
 
 func (c *ifacePrefixesClient) List(ctx context.Context, interfaceID string, opts ...CallOption) (*api.PrefixList, error) {
-	return c.legacy.ListPrefixes(ctx, interfaceID, toLegacyIgnored(opts...)...)
+	if err := checkID(DomainInterfacePrefixes, "List", "interfaceID", interfaceID); err != nil {
+		return nil, err
+	}
+	return invoke(ctx, c.core, DomainInterfacePrefixes, "List", withCallArgs(opts, interfaceID), func(ctx context.Context, ignored ...[]uint32) (*api.PrefixList, error) {
+		return c.legacy.ListPrefixes(ctx, interfaceID, ignored...)
+	})
 }
 func (c *ifacePrefixesClient) Create(ctx context.Context, prefix *api.Prefix, opts ...CallOption) (*api.Prefix, error) {
-	return c.legacy.CreatePrefix(ctx, prefix, toLegacyIgnored(opts...)...)
+	prefix = normalized(c.callOptions(opts), prefix)
+	if c.callOptions(opts).resolveVNI {
+		var err error
+		if prefix, err = c.resolveVNI(ctx, prefix, opts); err != nil {
+			return nil, err
+		}
+	}
+	return invoke(ctx, c.core, DomainInterfacePrefixes, "Create", withExisting(withCallArgs(opts, prefix), func(ctx context.Context) (*api.Prefix, error) {
+		return c.Get(ctx, prefix.InterfaceID, prefix.Spec.Prefix, opts...)
+	}), func(ctx context.Context, ignored ...[]uint32) (*api.Prefix, error) {
+		return c.legacy.CreatePrefix(ctx, prefix, ignored...)
+	})
 }
 func (c *ifacePrefixesClient) Delete(ctx context.Context, interfaceID string, prefix *netip.Prefix, opts ...CallOption) (*api.Prefix, error) {
-	return c.legacy.DeletePrefix(ctx, interfaceID, prefix, toLegacyIgnored(opts...)...)
+	if err := checkID(DomainInterfacePrefixes, "Delete", "interfaceID", interfaceID); err != nil {
+		return nil, err
+	}
+	if err := checkPrefix(DomainInterfacePrefixes, "Delete", "prefix", prefix); err != nil {
+		return nil, err
+	}
+	prefix = normalized(c.callOptions(opts), prefix)
+	return invoke(ctx, c.core, DomainInterfacePrefixes, "Delete", withCallArgs(opts, interfaceID, prefix), func(ctx context.Context, ignored ...[]uint32) (*api.Prefix, error) {
+		return c.legacy.DeletePrefix(ctx, interfaceID, prefix, ignored...)
+	})
 }
 
 //
@@ -221,20 +655,59 @@ func (c *ifacePrefixesClient) Delete(ctx context.Context, interfaceID string, pr
 
 type Routes interface {
 	List(ctx context.Context, vni uint32, opts ...CallOption) (*api.RouteList, error)
+	// ListMany lists the routes of the given VNIs concurrently (see
+	// WithConcurrency) and returns them keyed by VNI. VNIs whose routes
+	// cannot be listed are omitted from the map and reported per input index
+	// in the BulkError.
+	ListMany(ctx context.Context, vnis []uint32, opts ...CallOption) (map[uint32]*api.RouteList, *BulkError)
+	// Get returns the route of vni for prefix, found by listing the routes.
+	// A NOT_FOUND status error is returned if none matches.
+	Get(ctx context.Context, vni uint32, prefix netip.Prefix, opts ...CallOption) (*api.Route, error)
+	// Exists reports whether vni has a route for prefix.
+	Exists(ctx context.Context, vni uint32, prefix netip.Prefix, opts ...CallOption) (bool, error)
+	// WaitForPrefix polls Get every poll interval until vni has a route for
+	// prefix and returns it. See Interfaces.WaitFor.
+	WaitForPrefix(ctx context.Context, vni uint32, prefix netip.Prefix, poll time.Duration, opts ...CallOption) (*api.Route, error)
 	Create(ctx context.Context, route *api.Route, opts ...CallOption) (*api.Route, error)
+	// CreateVia creates the route of vni for dest via the given next hop.
+	// The next hop is the underlay address of the host serving nextHopVNI,
+	// so it is always IPv6, also for IPv4 destinations; anything else is
+	// rejected with an *InvalidArgumentError before calling the server.
+	CreateVia(ctx context.Context, vni uint32, dest netip.Prefix, nextHopVNI uint32, nextHopIP netip.Addr, opts ...CallOption) (*api.Route, error)
 	Delete(ctx context.Context, vni uint32, prefix *netip.Prefix, opts ...CallOption) (*api.Route, error)
+	// Ensure makes the server match the desired route, see Interfaces.Ensure.
+	Ensure(ctx context.Context, route *api.Route, opts ...CallOption) (*api.Route, bool, error)
+	// Verify compares the routes of vni with desired without changing
+	// anything and returns the missing, extra and mismatched routes.
+	Verify(ctx context.Context, vni uint32, desired []*api.Route, opts ...CallOption) (*RouteDiff, error)
+	// WithDefaults scopes additional defaults to this client, see
+	// LoadBalancers.WithDefaults.
+	WithDefaults(opts ...CallOption) Routes
 }
 
-type routeClient struct{ legacy legacy.Client }
+type routeClient struct{ *core }
 
 func (c *routeClient) List(ctx context.Context, vni uint32, opts ...CallOption) (*api.RouteList, error) {
-	return c.legacy.ListRoutes(ctx, vni, toLegacyIgnored(opts...)...)
+	return invoke(ctx, c.core, DomainRoutes, "List", withCallArgs(opts, vni), func(ctx context.Context, ignored ...[]uint32) (*api.RouteList, error) {
+		return c.legacy.ListRoutes(ctx, vni, ignored...)
+	})
 }
 func (c *routeClient) Create(ctx context.Context, route *api.Route, opts ...CallOption) (*api.Route, error) {
-	return c.legacy.CreateRoute(ctx, route, toLegacyIgnored(opts...)...)
+	route = normalized(c.callOptions(opts), route)
+	return invoke(ctx, c.core, DomainRoutes, "Create", withExisting(withCallArgs(opts, route), func(ctx context.Context) (*api.Route, error) {
+		return c.Get(ctx, route.VNI, *route.Spec.Prefix, opts...)
+	}), func(ctx context.Context, ignored ...[]uint32) (*api.Route, error) {
+		return c.legacy.CreateRoute(ctx, route, ignored...)
+	})
 }
 func (c *routeClient) Delete(ctx context.Context, vni uint32, prefix *netip.Prefix, opts ...CallOption) (*api.Route, error) {
-	return c.legacy.DeleteRoute(ctx, vni, prefix, toLegacyIgnored(opts...)...)
+	if err := checkPrefix(DomainRoutes, "Delete", "prefix", prefix); err != nil {
+		return nil, err
+	}
+	prefix = normalized(c.callOptions(opts), prefix)
+	return invoke(ctx, c.core, DomainRoutes, "Delete", withCallArgs(opts, vni, prefix), func(ctx context.Context, ignored ...[]uint32) (*api.Route, error) {
+		return c.legacy.DeleteRoute(ctx, vni, prefix, ignored...)
+	})
 }
 
 //
@@ -245,39 +718,123 @@ type NATs interface {
 	Get(ctx context.Context, interfaceID string, opts ...CallOption) (*api.Nat, error)
 	Create(ctx context.Context, nat *api.Nat, opts ...CallOption) (*api.Nat, error)
 	Delete(ctx context.Context, interfaceID string, opts ...CallOption) (*api.Nat, error)
+	// Ensure makes the server match the desired NAT, see Interfaces.Ensure.
+	Ensure(ctx context.Context, nat *api.Nat, opts ...CallOption) (*api.Nat, bool, error)
+	// WaitFor polls Get every poll interval until pred holds for the NAT and
+	// returns it. See Interfaces.WaitFor.
+	WaitFor(ctx context.Context, interfaceID string, pred func(*api.Nat) bool, poll time.Duration, opts ...CallOption) (*api.Nat, error)
+	// CreateMany creates the given NATs concurrently. The returned list holds
+	// the successfully created entries in input order; failures are reported
+	// per input index in the BulkError.
+	CreateMany(ctx context.Context, nats []*api.Nat, opts ...CallOption) (*api.NatList, *BulkError)
+	// ListByInterface lists all interfaces and fetches their NATs with bounded
+	// concurrency (see WithConcurrency). The map holds every interface ID,
+	// with a nil NAT for interfaces without one. If some lookups fail, the
+	// partial map is returned together with a *BulkError whose indices refer
+	// to the interface list order.
+	ListByInterface(ctx context.Context, opts ...CallOption) (map[string]*api.Nat, error)
+	// ListAllIPs returns the sorted, deduplicated NAT IPs in use. It costs one
+	// interface listing plus one NAT lookup per interface, issued with
+	// bounded concurrency (see WithConcurrency). Neighbor NATs can only be
+	// listed by NAT IP and share the IPs of the local NATs, so they are not
+	// scanned. Any failed lookup fails the call.
+	ListAllIPs(ctx context.Context, opts ...CallOption) ([]netip.Addr, error)
 
 	ListAny(ctx context.Context, natIP *netip.Addr, opts ...CallOption) (*api.NatList, error)
 	ListLocal(ctx context.Context, natIP *netip.Addr, opts ...CallOption) (*api.NatList, error)
 	ListNeighbors(ctx context.Context, natIP *netip.Addr, opts ...CallOption) (*api.NatList, error)
 	CreateNeighbor(ctx context.Context, n *api.NeighborNat, opts ...CallOption) (*api.NeighborNat, error)
 	DeleteNeighbor(ctx context.Context, n *api.NeighborNat, opts ...CallOption) (*api.NeighborNat, error)
+	// GetNeighborByRoute returns the neighbor NAT of natIP whose underlay
+	// route lies in underlay, filtering ListNeighbors client-side. If none
+	// does, it fails with a NotFound status error; if several do, e.g. for
+	// different port ranges, it fails with an *AmbiguousNeighborNATError.
+	GetNeighborByRoute(ctx context.Context, natIP netip.Addr, underlay netip.Prefix, opts ...CallOption) (*api.NeighborNat, error)
+	// WithDefaults scopes additional defaults to this client, see
+	// LoadBalancers.WithDefaults.
+	WithDefaults(opts ...CallOption) NATs
 }
 
-type natClient struct{ legacy legacy.Client }
+type natClient struct{ *core }
 
 func (c *natClient) Get(ctx context.Context, interfaceID string, opts ...CallOption) (*api.Nat, error) {
-	return c.legacy.GetNat(ctx, interfaceID, toLegacyIgnored(opts...)...)
+	if err := checkID(DomainNATs, "Get", "interfaceID", interfaceID); err != nil {
+		return nil, err
+	}
+	return invoke(ctx, c.core, DomainNATs, "Get", withFlightKey(withCallArgs(opts, interfaceID), interfaceID), func(ctx context.Context, ignored ...[]uint32) (*api.Nat, error) {
+		return c.legacy.GetNat(ctx, interfaceID, ignored...)
+	})
 }
 func (c *natClient) Create(ctx context.Context, nat *api.Nat, opts ...CallOption) (*api.Nat, error) {
-	return c.legacy.CreateNat(ctx, nat, toLegacyIgnored(opts...)...)
+	nat = normalized(c.callOptions(opts), nat)
+	return invoke(ctx, c.core, DomainNATs, "Create", withExisting(withCallArgs(opts, nat), func(ctx context.Context) (*api.Nat, error) {
+		return c.Get(ctx, nat.InterfaceID, opts...)
+	}), func(ctx context.Context, ignored ...[]uint32) (*api.Nat, error) {
+		return c.legacy.CreateNat(ctx, nat, ignored...)
+	})
 }
 func (c *natClient) Delete(ctx context.Context, interfaceID string, opts ...CallOption) (*api.Nat, error) {
-	return c.legacy.DeleteNat(ctx, interfaceID, toLegacyIgnored(opts...)...)
+	if err := checkID(DomainNATs, "Delete", "interfaceID", interfaceID); err != nil {
+		return nil, err
+	}
+	return invoke(ctx, c.core, DomainNATs, "Delete", withCallArgs(opts, interfaceID), func(ctx context.Context, ignored ...[]uint32) (*api.Nat, error) {
+		return c.legacy.DeleteNat(ctx, interfaceID, ignored...)
+	})
+}
+func (c *natClient) CreateMany(ctx context.Context, nats []*api.Nat, opts ...CallOption) (*api.NatList, *BulkError) {
+	o := c.callOptions(opts)
+	ctx, cancel := o.bulkContext(ctx)
+	defer cancel()
+
+	list := &api.NatList{TypeMeta: api.TypeMeta{Kind: api.NatListKind}, Items: make([]api.Nat, 0, len(nats))}
+	if err := contextDone(ctx, DomainNATs+".CreateMany"); err != nil {
+		return list, failAll(len(nats), err)
+	}
+
+	itemOpts := bulkItemOptions(opts)
+	created := make([]*api.Nat, len(nats))
+	bulkErr := runBulk(ctx, len(nats), o, func(ctx context.Context, i int) error {
+		nat, err := c.Create(ctx, nats[i], itemOpts...)
+		if err != nil {
+			return err
+		}
+		created[i] = nat
+		return nil
+	})
+
+	for _, nat := range created {
+		if nat != nil {
+			list.Items = append(list.Items, *nat)
+		}
+	}
+	return list, bulkErr
 }
 func (c *natClient) ListAny(ctx context.Context, natIP *netip.Addr, opts ...CallOption) (*api.NatList, error) {
-	return c.legacy.ListNats(ctx, natIP, "any", toLegacyIgnored(opts...)...)
+	return invoke(ctx, c.core, DomainNATs, "ListAny", withCallArgs(opts, natIP), func(ctx context.Context, ignored ...[]uint32) (*api.NatList, error) {
+		return c.legacy.ListNats(ctx, natIP, "any", ignored...)
+	})
 }
 func (c *natClient) ListLocal(ctx context.Context, natIP *netip.Addr, opts ...CallOption) (*api.NatList, error) {
-	return c.legacy.ListLocalNats(ctx, natIP, toLegacyIgnored(opts...)...)
+	return invoke(ctx, c.core, DomainNATs, "ListLocal", withCallArgs(opts, natIP), func(ctx context.Context, ignored ...[]uint32) (*api.NatList, error) {
+		return c.legacy.ListLocalNats(ctx, natIP, ignored...)
+	})
 }
 func (c *natClient) ListNeighbors(ctx context.Context, natIP *netip.Addr, opts ...CallOption) (*api.NatList, error) {
-	return c.legacy.ListNeighborNats(ctx, natIP, toLegacyIgnored(opts...)...)
+	return invoke(ctx, c.core, DomainNATs, "ListNeighbors", withCallArgs(opts, natIP), func(ctx context.Context, ignored ...[]uint32) (*api.NatList, error) {
+		return c.legacy.ListNeighborNats(ctx, natIP, ignored...)
+	})
 }
 func (c *natClient) CreateNeighbor(ctx context.Context, n *api.NeighborNat, opts ...CallOption) (*api.NeighborNat, error) {
-	return c.legacy.CreateNeighborNat(ctx, n, toLegacyIgnored(opts...)...)
+	n = normalized(c.callOptions(opts), n)
+	return invoke(ctx, c.core, DomainNATs, "CreateNeighbor", withCallArgs(opts, n), func(ctx context.Context, ignored ...[]uint32) (*api.NeighborNat, error) {
+		return c.legacy.CreateNeighborNat(ctx, n, ignored...)
+	})
 }
 func (c *natClient) DeleteNeighbor(ctx context.Context, n *api.NeighborNat, opts ...CallOption) (*api.NeighborNat, error) {
-	return c.legacy.DeleteNeighborNat(ctx, n, toLegacyIgnored(opts...)...)
+	n = normalized(c.callOptions(opts), n)
+	return invoke(ctx, c.core, DomainNATs, "DeleteNeighbor", withCallArgs(opts, n), func(ctx context.Context, ignored ...[]uint32) (*api.NeighborNat, error) {
+		return c.legacy.DeleteNeighborNat(ctx, n, ignored...)
+	})
 }
 
 //
@@ -285,25 +842,98 @@ func (c *natClient) DeleteNeighbor(ctx context.Context, n *api.NeighborNat, opts
 //
 
 type Firewall interface {
+	// List returns the rules of the interface in server order, or sorted by
+	// the priority field with WithSortByPriority.
 	List(ctx context.Context, interfaceID string, opts ...CallOption) (*api.FirewallRuleList, error)
 	Get(ctx context.Context, interfaceID string, ruleID string, opts ...CallOption) (*api.FirewallRule, error)
 	Create(ctx context.Context, rule *api.FirewallRule, opts ...CallOption) (*api.FirewallRule, error)
 	Delete(ctx context.Context, interfaceID string, ruleID string, opts ...CallOption) (*api.FirewallRule, error)
+	// Ensure makes the server match the desired firewall rule, see Interfaces.Ensure.
+	Ensure(ctx context.Context, rule *api.FirewallRule, opts ...CallOption) (*api.FirewallRule, bool, error)
+	// WaitFor polls Get every poll interval until pred holds for the rule
+	// and returns it. See Interfaces.WaitFor.
+	WaitFor(ctx context.Context, interfaceID, ruleID string, pred func(*api.FirewallRule) bool, poll time.Duration, opts ...CallOption) (*api.FirewallRule, error)
+	// Duplicates returns the rules that match the same traffic with the same
+	// action as a rule created before them, in server order, without
+	// deleting anything. The priority is ignored, as dpservice tries rules in
+	// the order they were created (WithSortByPriority has no effect here).
+	Duplicates(ctx context.Context, interfaceID string, opts ...CallOption) ([]*api.FirewallRule, error)
+	// Deduplicate deletes the rules reported by Duplicates, keeping the
+	// earliest created rule of every group, the one the server matches
+	// first. It returns the removed rules and the joined errors of failed
+	// deletions.
+	Deduplicate(ctx context.Context, interfaceID string, opts ...CallOption) (removed []*api.FirewallRule, err error)
+	// FindShadowed returns the rules, in server order, that can never match
+	// because a rule created before them matches all their traffic: the same
+	// direction, source and destination prefixes containing theirs, and a
+	// protocol filter covering theirs, where nil prefixes and filters and -1
+	// port, type and code bounds match anything. dpservice tries rules in the
+	// order they were created and ignores the priority, which dpdk.proto
+	// documents as "For future use. No effect at the moment", so the priority
+	// is ignored here too (WithSortByPriority has no effect). Actions are
+	// ignored, so shadowed rules may be redundant or dead, and a rule only
+	// counts as shadowed by a single earlier rule, not by several that cover
+	// it together.
+	FindShadowed(ctx context.Context, interfaceID string, opts ...CallOption) ([]*api.FirewallRule, error)
+	// CreateSet creates rules on interfaceID with bounded concurrency (see
+	// WithConcurrency), setting their InterfaceID on copies. It returns the
+	// created rules together with a *BulkError indexed by rules. With
+	// WithRollbackOnError, a partial failure deletes the created rules again.
+	CreateSet(ctx context.Context, interfaceID string, rules []*api.FirewallRule, opts ...CallOption) (*api.FirewallRuleList, *BulkError)
+	// WithDefaults scopes additional defaults to this client, see
+	// LoadBalancers.WithDefaults.
+	WithDefaults(opts ...CallOption) Firewall
 }
 
-type fwClient struct{ legacy legacy.Client }
+type fwClient struct{ *core }
 
 func (c *fwClient) List(ctx context.Context, interfaceID string, opts ...CallOption) (*api.FirewallRuleList, error) {
-	return c.legacy.ListFirewallRules(ctx, interfaceID, toLegacyIgnored(opts...)...)
+	if err := checkID(DomainFirewall, "List", "interfaceID", interfaceID); err != nil {
+		return nil, err
+	}
+	rules, err := invoke(ctx, c.core, DomainFirewall, "List", withCallArgs(opts, interfaceID), func(ctx context.Context, ignored ...[]uint32) (*api.FirewallRuleList, error) {
+		return c.legacy.ListFirewallRules(ctx, interfaceID, ignored...)
+	})
+	if err == nil && c.callOptions(opts).sortByPriority {
+		SortFirewallRules(rules.Items)
+	}
+	return rules, err
 }
 func (c *fwClient) Get(ctx context.Context, interfaceID string, ruleID string, opts ...CallOption) (*api.FirewallRule, error) {
-	return c.legacy.GetFirewallRule(ctx, interfaceID, ruleID, toLegacyIgnored(opts...)...)
+	if err := checkID(DomainFirewall, "Get", "interfaceID", interfaceID); err != nil {
+		return nil, err
+	}
+	if err := checkID(DomainFirewall, "Get", "ruleID", ruleID); err != nil {
+		return nil, err
+	}
+	return invoke(ctx, c.core, DomainFirewall, "Get", withFlightKey(withCallArgs(opts, interfaceID, ruleID), interfaceID+"\x00"+ruleID), func(ctx context.Context, ignored ...[]uint32) (*api.FirewallRule, error) {
+		return c.legacy.GetFirewallRule(ctx, interfaceID, ruleID, ignored...)
+	})
 }
 func (c *fwClient) Create(ctx context.Context, rule *api.FirewallRule, opts ...CallOption) (*api.FirewallRule, error) {
-	return c.legacy.CreateFirewallRule(ctx, rule, toLegacyIgnored(opts...)...)
+	o := c.callOptions(opts)
+	rule = withGeneratedID(o, DomainFirewall, normalized(o, rule), func(rule *api.FirewallRule) *string { return &rule.Spec.RuleID })
+	if o.validate {
+		if err := ValidateFirewallRule(rule); err != nil {
+			return nil, err
+		}
+	}
+	return invoke(ctx, c.core, DomainFirewall, "Create", withExisting(withCallArgs(opts, rule), func(ctx context.Context) (*api.FirewallRule, error) {
+		return c.Get(ctx, rule.InterfaceID, rule.Spec.RuleID, opts...)
+	}), func(ctx context.Context, ignored ...[]uint32) (*api.FirewallRule, error) {
+		return c.legacy.CreateFirewallRule(ctx, rule, ignored...)
+	})
 }
 func (c *fwClient) Delete(ctx context.Context, interfaceID string, ruleID string, opts ...CallOption) (*api.FirewallRule, error) {
-	return c.legacy.DeleteFirewallRule(ctx, interfaceID, ruleID, toLegacyIgnored(opts...)...)
+	if err := checkID(DomainFirewall, "Delete", "interfaceID", interfaceID); err != nil {
+		return nil, err
+	}
+	if err := checkID(DomainFirewall, "Delete", "ruleID", ruleID); err != nil {
+		return nil, err
+	}
+	return invoke(ctx, c.core, DomainFirewall, "Delete", withCallArgs(opts, interfaceID, ruleID), func(ctx context.Context, ignored ...[]uint32) (*api.FirewallRule, error) {
+		return c.legacy.DeleteFirewallRule(ctx, interfaceID, ruleID, ignored...)
+	})
 }
 
 //
@@ -313,27 +943,57 @@ func (c *fwClient) Delete(ctx context.Context, interfaceID string, ruleID string
 type System interface {
 	CheckInitialized(ctx context.Context, opts ...CallOption) (*api.Initialized, error)
 	Initialize(ctx context.Context, opts ...CallOption) (*api.Initialized, error)
+	// InitializeAndID calls Initialize and returns the parsed instance UUID.
+	InitializeAndID(ctx context.Context, opts ...CallOption) (uuid.UUID, error)
+	// CheckInitializedID calls CheckInitialized and returns the parsed
+	// instance UUID.
+	CheckInitializedID(ctx context.Context, opts ...CallOption) (uuid.UUID, error)
 	GetVni(ctx context.Context, vni uint32, vniType uint8, opts ...CallOption) (*api.Vni, error)
 	ResetVni(ctx context.Context, vni uint32, vniType uint8, opts ...CallOption) (*api.Vni, error)
+	// ResetAllVnis resets the given VNIs concurrently (see WithConcurrency)
+	// and reports the failed ones in a *BulkError, which is nil if all
+	// succeeded. VNIs not in use (NO_VNI) are not failures. dpservice cannot
+	// enumerate its VNIs, so they have to be given.
+	ResetAllVnis(ctx context.Context, vniType uint8, vnis []uint32, opts ...CallOption) *BulkError
 	GetVersion(ctx context.Context, version *api.Version, opts ...CallOption) (*api.Version, error)
+	// Capabilities probes the server once for optional operations and reports
+	// which of them it supports.
+	Capabilities(ctx context.Context, opts ...CallOption) (*Capabilities, error)
+	// Summary counts the interfaces, load balancers, NATs and, with
+	// WithSummaryVNIs, routes concurrently. Failed sections are reported in
+	// the summary; an error is only returned if no count could be gathered.
+	Summary(ctx context.Context, opts ...CallOption) (*ResourceSummary, error)
+	// WithDefaults scopes additional defaults to this client, see
+	// LoadBalancers.WithDefaults.
+	WithDefaults(opts ...CallOption) System
 }
 
-type systemClient struct{ legacy legacy.Client }
+type systemClient struct{ *core }
 
 func (c *systemClient) CheckInitialized(ctx context.Context, opts ...CallOption) (*api.Initialized, error) {
-	return c.legacy.CheckInitialized(ctx, toLegacyIgnored(opts...)...)
+	return invoke(ctx, c.core, DomainSystem, "CheckInitialized", opts, func(ctx context.Context, ignored ...[]uint32) (*api.Initialized, error) {
+		return c.legacy.CheckInitialized(ctx, ignored...)
+	})
 }
 func (c *systemClient) Initialize(ctx context.Context, opts ...CallOption) (*api.Initialized, error) {
-	return c.legacy.Initialize(ctx, toLegacyIgnored(opts...)...)
+	return invoke(ctx, c.core, DomainSystem, "Initialize", opts, func(ctx context.Context, ignored ...[]uint32) (*api.Initialized, error) {
+		return c.legacy.Initialize(ctx, ignored...)
+	})
 }
 func (c *systemClient) GetVni(ctx context.Context, vni uint32, vniType uint8, opts ...CallOption) (*api.Vni, error) {
-	return c.legacy.GetVni(ctx, vni, vniType, toLegacyIgnored(opts...)...)
+	return invoke(ctx, c.core, DomainSystem, "GetVni", withCallArgs(opts, vni, vniType), func(ctx context.Context, ignored ...[]uint32) (*api.Vni, error) {
+		return c.legacy.GetVni(ctx, vni, vniType, ignored...)
+	})
 }
 func (c *systemClient) ResetVni(ctx context.Context, vni uint32, vniType uint8, opts ...CallOption) (*api.Vni, error) {
-	return c.legacy.ResetVni(ctx, vni, vniType, toLegacyIgnored(opts...)...)
+	return invoke(ctx, c.core, DomainSystem, "ResetVni", withCallArgs(opts, vni, vniType), func(ctx context.Context, ignored ...[]uint32) (*api.Vni, error) {
+		return c.legacy.ResetVni(ctx, vni, vniType, ignored...)
+	})
 }
 func (c *systemClient) GetVersion(ctx context.Context, version *api.Version, opts ...CallOption) (*api.Version, error) {
-	return c.legacy.GetVersion(ctx, version, toLegacyIgnored(opts...)...)
+	return invoke(ctx, c.core, DomainSystem, "GetVersion", withCallArgs(opts, version), func(ctx context.Context, ignored ...[]uint32) (*api.Version, error) {
+		return c.legacy.GetVersion(ctx, version, ignored...)
+	})
 }
 
 //
@@ -341,19 +1001,45 @@ func (c *systemClient) GetVersion(ctx context.Context, version *api.Version, opt
 //
 
 type Capture interface {
+	// Start starts capturing packets as configured by capture, which may be
+	// built with NewCaptureStart. A request without config or sink node IP
+	// is rejected with an *InvalidArgumentError before any RPC is made.
 	Start(ctx context.Context, capture *api.CaptureStart, opts ...CallOption) (*api.CaptureStart, error)
 	Stop(ctx context.Context, opts ...CallOption) (*api.CaptureStop, error)
 	Status(ctx context.Context, opts ...CallOption) (*api.CaptureStatus, error)
+	// StopIfRunning stops the capture if Status reports one as running, e.g.
+	// to clean up captures orphaned by failed tests, and returns the status
+	// seen before stopping. dpservice does not report when a capture was
+	// started, so maxAge is ignored and any running capture is stopped.
+	StopIfRunning(ctx context.Context, maxAge time.Duration, opts ...CallOption) (*api.CaptureStatus, error)
+	// WaitForPackets would wait until the capture has seen n packets.
+	// dpservice only reports whether a capture is active, not how many
+	// packets it has seen, so it always fails with a *NotSupportedError
+	// wrapping ErrNoCapturePacketCount, without any RPC. Count the packets
+	// arriving at the sink instead, e.g. with package capturestats.
+	WaitForPackets(ctx context.Context, n uint64, poll time.Duration, opts ...CallOption) (*api.CaptureStatus, error)
+	// WithDefaults scopes additional defaults to this client, see
+	// LoadBalancers.WithDefaults.
+	WithDefaults(opts ...CallOption) Capture
 }
 
-type captureClient struct{ legacy legacy.Client }
+type captureClient struct{ *core }
 
 func (c *captureClient) Start(ctx context.Context, capture *api.CaptureStart, opts ...CallOption) (*api.CaptureStart, error) {
-	return c.legacy.CaptureStart(ctx, capture, toLegacyIgnored(opts...)...)
+	if err := checkCaptureStart(capture); err != nil {
+		return nil, err
+	}
+	return invoke(ctx, c.core, DomainCapture, "Start", withCallArgs(opts, capture), func(ctx context.Context, ignored ...[]uint32) (*api.CaptureStart, error) {
+		return c.legacy.CaptureStart(ctx, capture, ignored...)
+	})
 }
 func (c *captureClient) Stop(ctx context.Context, opts ...CallOption) (*api.CaptureStop, error) {
-	return c.legacy.CaptureStop(ctx, toLegacyIgnored(opts...)...)
+	return invoke(ctx, c.core, DomainCapture, "Stop", opts, func(ctx context.Context, ignored ...[]uint32) (*api.CaptureStop, error) {
+		return c.legacy.CaptureStop(ctx, ignored...)
+	})
 }
 func (c *captureClient) Status(ctx context.Context, opts ...CallOption) (*api.CaptureStatus, error) {
-	return c.legacy.CaptureStatus(ctx, toLegacyIgnored(opts...)...)
+	return invoke(ctx, c.core, DomainCapture, "Status", opts, func(ctx context.Context, ignored ...[]uint32) (*api.CaptureStatus, error) {
+		return c.legacy.CaptureStatus(ctx, ignored...)
+	})
 }
@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package clienttest provides assertion helpers for tests that run against a
+// clientv2.Client, typically integration tests talking to a live dpservice.
+//
+// Every helper calls the client with context.Background() and fails the test
+// via t.Fatalf with a message naming the resource and the mismatch.
+//
+// Spy records the calls code under test makes through a client, for tests
+// asserting which calls were made in which order:
+//
+//	spy := clienttest.NewSpy(clientv2.AsV2(fake))
+//	reconcile(ctx, spy)
+//	clienttest.AssertCalledInOrder(t, spy,
+//		clienttest.Call{Domain: clientv2.DomainInterfaces, Method: "Create"},
+//		clienttest.Call{Domain: clientv2.DomainVirtualIPs, Method: "Create"})
+package clienttest
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+	clientv2 "github.com/ironcore-dev/dpservice/go/dpservice-go/clientv2"
+)
+
+// AssertInterfaceExists fails the test unless interface id exists and returns it.
+func AssertInterfaceExists(t testing.TB, c clientv2.Client, id string) *api.Interface {
+	t.Helper()
+	iface, err := c.Interfaces().Get(context.Background(), id)
+	if err != nil {
+		t.Fatalf("expected interface %q to exist, got error: %v", id, err)
+	}
+	if iface.Status.Code != 0 {
+		t.Fatalf("expected interface %q to exist, got status %s", id, iface.Status.String())
+	}
+	return iface
+}
+
+// AssertVIP fails the test unless interface id has the virtual IP want.
+func AssertVIP(t testing.TB, c clientv2.Client, id string, want netip.Addr) *api.VirtualIP {
+	t.Helper()
+	vip, err := c.Interfaces().VIP().Get(context.Background(), id)
+	if err != nil {
+		t.Fatalf("expected interface %q to have VIP %s, got error: %v", id, want, err)
+	}
+	if vip.Spec.IP == nil {
+		t.Fatalf("expected interface %q to have VIP %s, got none", id, want)
+	}
+	if *vip.Spec.IP != want {
+		t.Fatalf("expected interface %q to have VIP %s, got %s", id, want, vip.Spec.IP)
+	}
+	return vip
+}
+
+// AssertNoFirewallRules fails the test if interface id has any firewall rules.
+func AssertNoFirewallRules(t testing.TB, c clientv2.Client, id string) {
+	t.Helper()
+	rules, err := c.Interfaces().Firewall().List(context.Background(), id)
+	if err != nil {
+		t.Fatalf("expected interface %q to have no firewall rules, got error: %v", id, err)
+	}
+	if n := len(rules.Items); n != 0 {
+		ids := make([]string, 0, n)
+		for _, r := range rules.Items {
+			ids = append(ids, r.Spec.RuleID)
+		}
+		t.Fatalf("expected interface %q to have no firewall rules, got %d: %v", id, n, ids)
+	}
+}
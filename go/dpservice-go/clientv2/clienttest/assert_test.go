@@ -0,0 +1,109 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clienttest
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+	legacy "github.com/ironcore-dev/dpservice/go/dpservice-go/client"
+	clientv2 "github.com/ironcore-dev/dpservice/go/dpservice-go/clientv2"
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/errors"
+)
+
+type fakeLegacy struct {
+	legacy.Client
+	vip   *netip.Addr
+	rules []api.FirewallRule
+}
+
+func (f *fakeLegacy) GetInterface(_ context.Context, id string, _ ...[]uint32) (*api.Interface, error) {
+	if id != "vm1" {
+		return &api.Interface{}, errors.NewStatusError(errors.NOT_FOUND, "not found")
+	}
+	return &api.Interface{InterfaceMeta: api.InterfaceMeta{ID: id}}, nil
+}
+
+func (f *fakeLegacy) GetVirtualIP(_ context.Context, id string, _ ...[]uint32) (*api.VirtualIP, error) {
+	return &api.VirtualIP{VirtualIPMeta: api.VirtualIPMeta{InterfaceID: id}, Spec: api.VirtualIPSpec{IP: f.vip}}, nil
+}
+
+func (f *fakeLegacy) ListFirewallRules(_ context.Context, id string, _ ...[]uint32) (*api.FirewallRuleList, error) {
+	return &api.FirewallRuleList{Items: f.rules}, nil
+}
+
+// recorder captures Fatalf without aborting the calling test.
+type recorder struct {
+	testing.TB
+	failed bool
+	msg    string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Fatalf(format string, args ...any) {
+	r.failed = true
+	r.msg = fmt.Sprintf(format, args...)
+	runtime.Goexit()
+}
+
+func run(fn func(t testing.TB)) *recorder {
+	r := &recorder{}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn(r)
+	}()
+	<-done
+	return r
+}
+
+func TestAssertInterfaceExists(t *testing.T) {
+	c := clientv2.AsV2(&fakeLegacy{})
+
+	if r := run(func(t testing.TB) { AssertInterfaceExists(t, c, "vm1") }); r.failed {
+		t.Fatalf("unexpected failure: %s", r.msg)
+	}
+	r := run(func(t testing.TB) { AssertInterfaceExists(t, c, "vm2") })
+	if !r.failed || !strings.Contains(r.msg, `"vm2"`) {
+		t.Fatalf("expected failure mentioning vm2, got %q", r.msg)
+	}
+}
+
+func TestAssertVIP(t *testing.T) {
+	ip := netip.MustParseAddr("10.0.0.1")
+	c := clientv2.AsV2(&fakeLegacy{vip: &ip})
+
+	if r := run(func(t testing.TB) { AssertVIP(t, c, "vm1", ip) }); r.failed {
+		t.Fatalf("unexpected failure: %s", r.msg)
+	}
+	r := run(func(t testing.TB) { AssertVIP(t, c, "vm1", netip.MustParseAddr("10.0.0.2")) })
+	if !r.failed || !strings.Contains(r.msg, "got 10.0.0.1") {
+		t.Fatalf("expected VIP mismatch failure, got %q", r.msg)
+	}
+
+	c = clientv2.AsV2(&fakeLegacy{})
+	r = run(func(t testing.TB) { AssertVIP(t, c, "vm1", ip) })
+	if !r.failed || !strings.Contains(r.msg, "got none") {
+		t.Fatalf("expected missing VIP failure, got %q", r.msg)
+	}
+}
+
+func TestAssertNoFirewallRules(t *testing.T) {
+	c := clientv2.AsV2(&fakeLegacy{})
+	if r := run(func(t testing.TB) { AssertNoFirewallRules(t, c, "vm1") }); r.failed {
+		t.Fatalf("unexpected failure: %s", r.msg)
+	}
+
+	c = clientv2.AsV2(&fakeLegacy{rules: []api.FirewallRule{{Spec: api.FirewallRuleSpec{RuleID: "fr1"}}}})
+	r := run(func(t testing.TB) { AssertNoFirewallRules(t, c, "vm1") })
+	if !r.failed || !strings.Contains(r.msg, "fr1") {
+		t.Fatalf("expected failure listing fr1, got %q", r.msg)
+	}
+}
@@ -0,0 +1,735 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clienttest
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+	clientv2 "github.com/ironcore-dev/dpservice/go/dpservice-go/clientv2"
+)
+
+// Call is a call recorded by a Spy.
+type Call struct {
+	Domain string
+	Method string
+	// Args holds the arguments of the call other than the context, the
+	// predicates of the WaitFor methods and the call options. Pointers are
+	// recorded as passed, so later changes to the pointed-to values show up.
+	Args []any
+}
+
+func (c Call) String() string {
+	args := make([]string, len(c.Args))
+	for i, arg := range c.Args {
+		args[i] = fmt.Sprintf("%v", arg)
+	}
+	return fmt.Sprintf("%s.%s(%s)", c.Domain, c.Method, strings.Join(args, ", "))
+}
+
+// Spy is a clientv2.Client that records the calls made through it, including
+// those of its sub-clients, before passing them on to the client it wraps.
+// It records the calls of the code under test only: calls that a method such
+// as Ensure makes internally go to the wrapped client directly. It is safe
+// for concurrent use.
+type Spy struct {
+	next clientv2.Client
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+var _ clientv2.Client = (*Spy)(nil)
+
+// NewSpy returns a Spy delegating to next, which can be a client of a fake
+// or of a real server.
+func NewSpy(next clientv2.Client) *Spy {
+	return &Spy{next: next}
+}
+
+func (s *Spy) record(domain, method string, args ...any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls = append(s.calls, Call{Domain: domain, Method: method, Args: args})
+}
+
+// Calls returns the recorded calls in the order they were made.
+func (s *Spy) Calls() []Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Call(nil), s.calls...)
+}
+
+// Reset forgets the recorded calls.
+func (s *Spy) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls = nil
+}
+
+// AssertCalledInOrder fails the test unless the calls recorded by s contain
+// want in this order. Other calls may come before, between and after them.
+// A want with nil Args matches any arguments; otherwise the arguments are
+// compared with reflect.DeepEqual.
+func AssertCalledInOrder(t testing.TB, s *Spy, want ...Call) {
+	t.Helper()
+	calls := s.Calls()
+	next := 0
+	for _, call := range calls {
+		if next < len(want) && matchCall(want[next], call) {
+			next++
+		}
+	}
+	if next == len(want) {
+		return
+	}
+	got := make([]string, len(calls))
+	for i, call := range calls {
+		got[i] = "\n\t" + call.String()
+	}
+	t.Fatalf("expected call %s after %d matching calls, got calls:%s", want[next], next, strings.Join(got, ""))
+}
+
+func matchCall(want, got Call) bool {
+	if want.Domain != got.Domain || want.Method != got.Method {
+		return false
+	}
+	return want.Args == nil || reflect.DeepEqual(want.Args, got.Args)
+}
+
+func (s *Spy) LoadBalancers() clientv2.LoadBalancers {
+	return &spyLoadBalancers{spy: s, next: s.next.LoadBalancers()}
+}
+
+func (s *Spy) Interfaces() clientv2.Interfaces {
+	return &spyInterfaces{spy: s, next: s.next.Interfaces()}
+}
+
+func (s *Spy) Routes() clientv2.Routes {
+	return &spyRoutes{spy: s, next: s.next.Routes()}
+}
+
+func (s *Spy) NATs() clientv2.NATs {
+	return &spyNATs{spy: s, next: s.next.NATs()}
+}
+
+func (s *Spy) Firewall() clientv2.Firewall {
+	return &spyFirewall{spy: s, next: s.next.Firewall()}
+}
+
+func (s *Spy) System() clientv2.System {
+	return &spySystem{spy: s, next: s.next.System()}
+}
+
+func (s *Spy) Capture() clientv2.Capture {
+	return &spyCapture{spy: s, next: s.next.Capture()}
+}
+
+// Close closes the wrapped client. It is not recorded.
+func (s *Spy) Close() error {
+	return s.next.Close()
+}
+
+type spyLoadBalancers struct {
+	spy  *Spy
+	next clientv2.LoadBalancers
+}
+
+func (s *spyLoadBalancers) Get(ctx context.Context, id string, opts ...clientv2.CallOption) (*api.LoadBalancer, error) {
+	s.spy.record(clientv2.DomainLoadBalancers, "Get", id)
+	return s.next.Get(ctx, id, opts...)
+}
+
+func (s *spyLoadBalancers) List(ctx context.Context, opts ...clientv2.CallOption) (*api.LoadBalancerList, error) {
+	s.spy.record(clientv2.DomainLoadBalancers, "List")
+	return s.next.List(ctx, opts...)
+}
+
+func (s *spyLoadBalancers) Create(ctx context.Context, lb *api.LoadBalancer, opts ...clientv2.CallOption) (*api.LoadBalancer, error) {
+	s.spy.record(clientv2.DomainLoadBalancers, "Create", lb)
+	return s.next.Create(ctx, lb, opts...)
+}
+
+func (s *spyLoadBalancers) Delete(ctx context.Context, id string, opts ...clientv2.CallOption) (*api.LoadBalancer, error) {
+	s.spy.record(clientv2.DomainLoadBalancers, "Delete", id)
+	return s.next.Delete(ctx, id, opts...)
+}
+
+func (s *spyLoadBalancers) Ensure(ctx context.Context, lb *api.LoadBalancer, opts ...clientv2.CallOption) (*api.LoadBalancer, bool, error) {
+	s.spy.record(clientv2.DomainLoadBalancers, "Ensure", lb)
+	return s.next.Ensure(ctx, lb, opts...)
+}
+
+func (s *spyLoadBalancers) CreateOrGet(ctx context.Context, lb *api.LoadBalancer, opts ...clientv2.CallOption) (*api.LoadBalancer, bool, error) {
+	s.spy.record(clientv2.DomainLoadBalancers, "CreateOrGet", lb)
+	return s.next.CreateOrGet(ctx, lb, opts...)
+}
+
+func (s *spyLoadBalancers) Describe(ctx context.Context, lbID, interfaceID string, opts ...clientv2.CallOption) (*clientv2.LoadBalancerDetail, error) {
+	s.spy.record(clientv2.DomainLoadBalancers, "Describe", lbID, interfaceID)
+	return s.next.Describe(ctx, lbID, interfaceID, opts...)
+}
+
+func (s *spyLoadBalancers) VerifyProgrammed(ctx context.Context, lbID, interfaceID string, wantTargets []netip.Addr, wantPrefixes []netip.Prefix, opts ...clientv2.CallOption) (*clientv2.LBVerification, error) {
+	s.spy.record(clientv2.DomainLoadBalancers, "VerifyProgrammed", lbID, interfaceID, wantTargets, wantPrefixes)
+	return s.next.VerifyProgrammed(ctx, lbID, interfaceID, wantTargets, wantPrefixes, opts...)
+}
+
+func (s *spyLoadBalancers) WaitFor(ctx context.Context, id string, pred func(*api.LoadBalancer) bool, poll time.Duration, opts ...clientv2.CallOption) (*api.LoadBalancer, error) {
+	s.spy.record(clientv2.DomainLoadBalancers, "WaitFor", id, poll)
+	return s.next.WaitFor(ctx, id, pred, poll, opts...)
+}
+
+func (s *spyLoadBalancers) SelectTarget(ctx context.Context, lbID string, flow clientv2.FlowTuple, opts ...clientv2.CallOption) (*api.LoadBalancerTarget, error) {
+	s.spy.record(clientv2.DomainLoadBalancers, "SelectTarget", lbID, flow)
+	return s.next.SelectTarget(ctx, lbID, flow, opts...)
+}
+
+func (s *spyLoadBalancers) ListWithInterfaces(ctx context.Context, opts ...clientv2.CallOption) ([]clientv2.LBWithInterface, error) {
+	s.spy.record(clientv2.DomainLoadBalancers, "ListWithInterfaces")
+	return s.next.ListWithInterfaces(ctx, opts...)
+}
+
+func (s *spyLoadBalancers) Prefixes() clientv2.LoadBalancerPrefixes {
+	return &spyLBPrefixes{spy: s.spy, next: s.next.Prefixes()}
+}
+
+func (s *spyLoadBalancers) Targets() clientv2.LoadBalancerTargets {
+	return &spyLBTargets{spy: s.spy, next: s.next.Targets()}
+}
+
+func (s *spyLoadBalancers) WithDefaults(opts ...clientv2.CallOption) clientv2.LoadBalancers {
+	return &spyLoadBalancers{spy: s.spy, next: s.next.WithDefaults(opts...)}
+}
+
+type spyLBPrefixes struct {
+	spy  *Spy
+	next clientv2.LoadBalancerPrefixes
+}
+
+func (s *spyLBPrefixes) List(ctx context.Context, interfaceID string, opts ...clientv2.CallOption) (*api.PrefixList, error) {
+	s.spy.record(clientv2.DomainLoadBalancerPrefixes, "List", interfaceID)
+	return s.next.List(ctx, interfaceID, opts...)
+}
+
+func (s *spyLBPrefixes) Get(ctx context.Context, interfaceID string, prefix netip.Prefix, opts ...clientv2.CallOption) (*api.LoadBalancerPrefix, error) {
+	s.spy.record(clientv2.DomainLoadBalancerPrefixes, "Get", interfaceID, prefix)
+	return s.next.Get(ctx, interfaceID, prefix, opts...)
+}
+
+func (s *spyLBPrefixes) Exists(ctx context.Context, interfaceID string, prefix netip.Prefix, opts ...clientv2.CallOption) (bool, error) {
+	s.spy.record(clientv2.DomainLoadBalancerPrefixes, "Exists", interfaceID, prefix)
+	return s.next.Exists(ctx, interfaceID, prefix, opts...)
+}
+
+func (s *spyLBPrefixes) Create(ctx context.Context, prefix *api.LoadBalancerPrefix, opts ...clientv2.CallOption) (*api.LoadBalancerPrefix, error) {
+	s.spy.record(clientv2.DomainLoadBalancerPrefixes, "Create", prefix)
+	return s.next.Create(ctx, prefix, opts...)
+}
+
+func (s *spyLBPrefixes) Delete(ctx context.Context, interfaceID string, prefix *netip.Prefix, opts ...clientv2.CallOption) (*api.LoadBalancerPrefix, error) {
+	s.spy.record(clientv2.DomainLoadBalancerPrefixes, "Delete", interfaceID, prefix)
+	return s.next.Delete(ctx, interfaceID, prefix, opts...)
+}
+
+func (s *spyLBPrefixes) WithDefaults(opts ...clientv2.CallOption) clientv2.LoadBalancerPrefixes {
+	return &spyLBPrefixes{spy: s.spy, next: s.next.WithDefaults(opts...)}
+}
+
+type spyLBTargets struct {
+	spy  *Spy
+	next clientv2.LoadBalancerTargets
+}
+
+func (s *spyLBTargets) List(ctx context.Context, loadBalancerID string, opts ...clientv2.CallOption) (*api.LoadBalancerTargetList, error) {
+	s.spy.record(clientv2.DomainLoadBalancerTargets, "List", loadBalancerID)
+	return s.next.List(ctx, loadBalancerID, opts...)
+}
+
+func (s *spyLBTargets) Get(ctx context.Context, lbID string, targetIP netip.Addr, opts ...clientv2.CallOption) (*api.LoadBalancerTarget, error) {
+	s.spy.record(clientv2.DomainLoadBalancerTargets, "Get", lbID, targetIP)
+	return s.next.Get(ctx, lbID, targetIP, opts...)
+}
+
+func (s *spyLBTargets) Create(ctx context.Context, target *api.LoadBalancerTarget, opts ...clientv2.CallOption) (*api.LoadBalancerTarget, error) {
+	s.spy.record(clientv2.DomainLoadBalancerTargets, "Create", target)
+	return s.next.Create(ctx, target, opts...)
+}
+
+func (s *spyLBTargets) Delete(ctx context.Context, lbID string, targetIP *netip.Addr, opts ...clientv2.CallOption) (*api.LoadBalancerTarget, error) {
+	s.spy.record(clientv2.DomainLoadBalancerTargets, "Delete", lbID, targetIP)
+	return s.next.Delete(ctx, lbID, targetIP, opts...)
+}
+
+func (s *spyLBTargets) SetWeight(ctx context.Context, lbID string, targetIP netip.Addr, weight uint32, opts ...clientv2.CallOption) (*api.LoadBalancerTarget, error) {
+	s.spy.record(clientv2.DomainLoadBalancerTargets, "SetWeight", lbID, targetIP, weight)
+	return s.next.SetWeight(ctx, lbID, targetIP, weight, opts...)
+}
+
+func (s *spyLBTargets) WithDefaults(opts ...clientv2.CallOption) clientv2.LoadBalancerTargets {
+	return &spyLBTargets{spy: s.spy, next: s.next.WithDefaults(opts...)}
+}
+
+type spyInterfaces struct {
+	spy  *Spy
+	next clientv2.Interfaces
+}
+
+func (s *spyInterfaces) Get(ctx context.Context, id string, opts ...clientv2.CallOption) (*api.Interface, error) {
+	s.spy.record(clientv2.DomainInterfaces, "Get", id)
+	return s.next.Get(ctx, id, opts...)
+}
+
+func (s *spyInterfaces) List(ctx context.Context, opts ...clientv2.CallOption) (*api.InterfaceList, error) {
+	s.spy.record(clientv2.DomainInterfaces, "List")
+	return s.next.List(ctx, opts...)
+}
+
+func (s *spyInterfaces) Create(ctx context.Context, iface *api.Interface, opts ...clientv2.CallOption) (*api.Interface, error) {
+	s.spy.record(clientv2.DomainInterfaces, "Create", iface)
+	return s.next.Create(ctx, iface, opts...)
+}
+
+func (s *spyInterfaces) Delete(ctx context.Context, id string, opts ...clientv2.CallOption) (*api.Interface, error) {
+	s.spy.record(clientv2.DomainInterfaces, "Delete", id)
+	return s.next.Delete(ctx, id, opts...)
+}
+
+func (s *spyInterfaces) Ensure(ctx context.Context, iface *api.Interface, opts ...clientv2.CallOption) (*api.Interface, bool, error) {
+	s.spy.record(clientv2.DomainInterfaces, "Ensure", iface)
+	return s.next.Ensure(ctx, iface, opts...)
+}
+
+func (s *spyInterfaces) CreateExclusive(ctx context.Context, iface *api.Interface, opts ...clientv2.CallOption) (*api.Interface, error) {
+	s.spy.record(clientv2.DomainInterfaces, "CreateExclusive", iface)
+	return s.next.CreateExclusive(ctx, iface, opts...)
+}
+
+func (s *spyInterfaces) CreateOrGet(ctx context.Context, iface *api.Interface, opts ...clientv2.CallOption) (*api.Interface, bool, error) {
+	s.spy.record(clientv2.DomainInterfaces, "CreateOrGet", iface)
+	return s.next.CreateOrGet(ctx, iface, opts...)
+}
+
+func (s *spyInterfaces) GetByDevice(ctx context.Context, device string, opts ...clientv2.CallOption) (*api.Interface, error) {
+	s.spy.record(clientv2.DomainInterfaces, "GetByDevice", device)
+	return s.next.GetByDevice(ctx, device, opts...)
+}
+
+func (s *spyInterfaces) GroupByVNI(ctx context.Context, opts ...clientv2.CallOption) (map[uint32]*api.InterfaceList, error) {
+	s.spy.record(clientv2.DomainInterfaces, "GroupByVNI")
+	return s.next.GroupByVNI(ctx, opts...)
+}
+
+func (s *spyInterfaces) DeleteCascade(ctx context.Context, interfaceID string, opts ...clientv2.CallOption) error {
+	s.spy.record(clientv2.DomainInterfaces, "DeleteCascade", interfaceID)
+	return s.next.DeleteCascade(ctx, interfaceID, opts...)
+}
+
+func (s *spyInterfaces) GetMany(ctx context.Context, ids []string, opts ...clientv2.CallOption) (map[string]*api.Interface, *clientv2.BulkError) {
+	s.spy.record(clientv2.DomainInterfaces, "GetMany", ids)
+	return s.next.GetMany(ctx, ids, opts...)
+}
+
+func (s *spyInterfaces) WaitFor(ctx context.Context, id string, pred func(*api.Interface) bool, poll time.Duration, opts ...clientv2.CallOption) (*api.Interface, error) {
+	s.spy.record(clientv2.DomainInterfaces, "WaitFor", id, poll)
+	return s.next.WaitFor(ctx, id, pred, poll, opts...)
+}
+
+func (s *spyInterfaces) WaitUntilGone(ctx context.Context, interfaceID string, poll time.Duration, opts ...clientv2.CallOption) error {
+	s.spy.record(clientv2.DomainInterfaces, "WaitUntilGone", interfaceID, poll)
+	return s.next.WaitUntilGone(ctx, interfaceID, poll, opts...)
+}
+
+func (s *spyInterfaces) WatchDiffs(ctx context.Context, poll time.Duration, opts ...clientv2.CallOption) (<-chan clientv2.InterfaceEvent, <-chan error) {
+	s.spy.record(clientv2.DomainInterfaces, "WatchDiffs", poll)
+	return s.next.WatchDiffs(ctx, poll, opts...)
+}
+
+func (s *spyInterfaces) VIP() clientv2.VirtualIPs {
+	return &spyVIPs{spy: s.spy, next: s.next.VIP()}
+}
+
+func (s *spyInterfaces) Prefixes() clientv2.InterfacePrefixes {
+	return &spyInterfacePrefixes{spy: s.spy, next: s.next.Prefixes()}
+}
+
+func (s *spyInterfaces) Firewall() clientv2.Firewall {
+	return &spyFirewall{spy: s.spy, next: s.next.Firewall()}
+}
+
+func (s *spyInterfaces) WithDefaults(opts ...clientv2.CallOption) clientv2.Interfaces {
+	return &spyInterfaces{spy: s.spy, next: s.next.WithDefaults(opts...)}
+}
+
+type spyVIPs struct {
+	spy  *Spy
+	next clientv2.VirtualIPs
+}
+
+func (s *spyVIPs) Get(ctx context.Context, interfaceID string, opts ...clientv2.CallOption) (*api.VirtualIP, error) {
+	s.spy.record(clientv2.DomainVirtualIPs, "Get", interfaceID)
+	return s.next.Get(ctx, interfaceID, opts...)
+}
+
+func (s *spyVIPs) Create(ctx context.Context, vip *api.VirtualIP, opts ...clientv2.CallOption) (*api.VirtualIP, error) {
+	s.spy.record(clientv2.DomainVirtualIPs, "Create", vip)
+	return s.next.Create(ctx, vip, opts...)
+}
+
+func (s *spyVIPs) Delete(ctx context.Context, interfaceID string, opts ...clientv2.CallOption) (*api.VirtualIP, error) {
+	s.spy.record(clientv2.DomainVirtualIPs, "Delete", interfaceID)
+	return s.next.Delete(ctx, interfaceID, opts...)
+}
+
+func (s *spyVIPs) Ensure(ctx context.Context, vip *api.VirtualIP, opts ...clientv2.CallOption) (*api.VirtualIP, bool, error) {
+	s.spy.record(clientv2.DomainVirtualIPs, "Ensure", vip)
+	return s.next.Ensure(ctx, vip, opts...)
+}
+
+func (s *spyVIPs) WaitFor(ctx context.Context, interfaceID string, pred func(*api.VirtualIP) bool, poll time.Duration, opts ...clientv2.CallOption) (*api.VirtualIP, error) {
+	s.spy.record(clientv2.DomainVirtualIPs, "WaitFor", interfaceID, poll)
+	return s.next.WaitFor(ctx, interfaceID, pred, poll, opts...)
+}
+
+func (s *spyVIPs) WithDefaults(opts ...clientv2.CallOption) clientv2.VirtualIPs {
+	return &spyVIPs{spy: s.spy, next: s.next.WithDefaults(opts...)}
+}
+
+type spyInterfacePrefixes struct {
+	spy  *Spy
+	next clientv2.InterfacePrefixes
+}
+
+func (s *spyInterfacePrefixes) List(ctx context.Context, interfaceID string, opts ...clientv2.CallOption) (*api.PrefixList, error) {
+	s.spy.record(clientv2.DomainInterfacePrefixes, "List", interfaceID)
+	return s.next.List(ctx, interfaceID, opts...)
+}
+
+func (s *spyInterfacePrefixes) ListAll(ctx context.Context, opts ...clientv2.CallOption) (*api.PrefixList, error) {
+	s.spy.record(clientv2.DomainInterfacePrefixes, "ListAll")
+	return s.next.ListAll(ctx, opts...)
+}
+
+func (s *spyInterfacePrefixes) Get(ctx context.Context, interfaceID string, prefix netip.Prefix, opts ...clientv2.CallOption) (*api.Prefix, error) {
+	s.spy.record(clientv2.DomainInterfacePrefixes, "Get", interfaceID, prefix)
+	return s.next.Get(ctx, interfaceID, prefix, opts...)
+}
+
+func (s *spyInterfacePrefixes) Exists(ctx context.Context, interfaceID string, prefix netip.Prefix, opts ...clientv2.CallOption) (bool, error) {
+	s.spy.record(clientv2.DomainInterfacePrefixes, "Exists", interfaceID, prefix)
+	return s.next.Exists(ctx, interfaceID, prefix, opts...)
+}
+
+func (s *spyInterfacePrefixes) Create(ctx context.Context, prefix *api.Prefix, opts ...clientv2.CallOption) (*api.Prefix, error) {
+	s.spy.record(clientv2.DomainInterfacePrefixes, "Create", prefix)
+	return s.next.Create(ctx, prefix, opts...)
+}
+
+func (s *spyInterfacePrefixes) Delete(ctx context.Context, interfaceID string, prefix *netip.Prefix, opts ...clientv2.CallOption) (*api.Prefix, error) {
+	s.spy.record(clientv2.DomainInterfacePrefixes, "Delete", interfaceID, prefix)
+	return s.next.Delete(ctx, interfaceID, prefix, opts...)
+}
+
+func (s *spyInterfacePrefixes) WithDefaults(opts ...clientv2.CallOption) clientv2.InterfacePrefixes {
+	return &spyInterfacePrefixes{spy: s.spy, next: s.next.WithDefaults(opts...)}
+}
+
+type spyRoutes struct {
+	spy  *Spy
+	next clientv2.Routes
+}
+
+func (s *spyRoutes) List(ctx context.Context, vni uint32, opts ...clientv2.CallOption) (*api.RouteList, error) {
+	s.spy.record(clientv2.DomainRoutes, "List", vni)
+	return s.next.List(ctx, vni, opts...)
+}
+
+func (s *spyRoutes) ListMany(ctx context.Context, vnis []uint32, opts ...clientv2.CallOption) (map[uint32]*api.RouteList, *clientv2.BulkError) {
+	s.spy.record(clientv2.DomainRoutes, "ListMany", vnis)
+	return s.next.ListMany(ctx, vnis, opts...)
+}
+
+func (s *spyRoutes) Get(ctx context.Context, vni uint32, prefix netip.Prefix, opts ...clientv2.CallOption) (*api.Route, error) {
+	s.spy.record(clientv2.DomainRoutes, "Get", vni, prefix)
+	return s.next.Get(ctx, vni, prefix, opts...)
+}
+
+func (s *spyRoutes) Exists(ctx context.Context, vni uint32, prefix netip.Prefix, opts ...clientv2.CallOption) (bool, error) {
+	s.spy.record(clientv2.DomainRoutes, "Exists", vni, prefix)
+	return s.next.Exists(ctx, vni, prefix, opts...)
+}
+
+func (s *spyRoutes) WaitForPrefix(ctx context.Context, vni uint32, prefix netip.Prefix, poll time.Duration, opts ...clientv2.CallOption) (*api.Route, error) {
+	s.spy.record(clientv2.DomainRoutes, "WaitForPrefix", vni, prefix, poll)
+	return s.next.WaitForPrefix(ctx, vni, prefix, poll, opts...)
+}
+
+func (s *spyRoutes) Create(ctx context.Context, route *api.Route, opts ...clientv2.CallOption) (*api.Route, error) {
+	s.spy.record(clientv2.DomainRoutes, "Create", route)
+	return s.next.Create(ctx, route, opts...)
+}
+
+func (s *spyRoutes) CreateVia(ctx context.Context, vni uint32, dest netip.Prefix, nextHopVNI uint32, nextHopIP netip.Addr, opts ...clientv2.CallOption) (*api.Route, error) {
+	s.spy.record(clientv2.DomainRoutes, "CreateVia", vni, dest, nextHopVNI, nextHopIP)
+	return s.next.CreateVia(ctx, vni, dest, nextHopVNI, nextHopIP, opts...)
+}
+
+func (s *spyRoutes) Delete(ctx context.Context, vni uint32, prefix *netip.Prefix, opts ...clientv2.CallOption) (*api.Route, error) {
+	s.spy.record(clientv2.DomainRoutes, "Delete", vni, prefix)
+	return s.next.Delete(ctx, vni, prefix, opts...)
+}
+
+func (s *spyRoutes) Ensure(ctx context.Context, route *api.Route, opts ...clientv2.CallOption) (*api.Route, bool, error) {
+	s.spy.record(clientv2.DomainRoutes, "Ensure", route)
+	return s.next.Ensure(ctx, route, opts...)
+}
+
+func (s *spyRoutes) Verify(ctx context.Context, vni uint32, desired []*api.Route, opts ...clientv2.CallOption) (*clientv2.RouteDiff, error) {
+	s.spy.record(clientv2.DomainRoutes, "Verify", vni, desired)
+	return s.next.Verify(ctx, vni, desired, opts...)
+}
+
+func (s *spyRoutes) WithDefaults(opts ...clientv2.CallOption) clientv2.Routes {
+	return &spyRoutes{spy: s.spy, next: s.next.WithDefaults(opts...)}
+}
+
+type spyNATs struct {
+	spy  *Spy
+	next clientv2.NATs
+}
+
+func (s *spyNATs) Get(ctx context.Context, interfaceID string, opts ...clientv2.CallOption) (*api.Nat, error) {
+	s.spy.record(clientv2.DomainNATs, "Get", interfaceID)
+	return s.next.Get(ctx, interfaceID, opts...)
+}
+
+func (s *spyNATs) Create(ctx context.Context, nat *api.Nat, opts ...clientv2.CallOption) (*api.Nat, error) {
+	s.spy.record(clientv2.DomainNATs, "Create", nat)
+	return s.next.Create(ctx, nat, opts...)
+}
+
+func (s *spyNATs) Delete(ctx context.Context, interfaceID string, opts ...clientv2.CallOption) (*api.Nat, error) {
+	s.spy.record(clientv2.DomainNATs, "Delete", interfaceID)
+	return s.next.Delete(ctx, interfaceID, opts...)
+}
+
+func (s *spyNATs) Ensure(ctx context.Context, nat *api.Nat, opts ...clientv2.CallOption) (*api.Nat, bool, error) {
+	s.spy.record(clientv2.DomainNATs, "Ensure", nat)
+	return s.next.Ensure(ctx, nat, opts...)
+}
+
+func (s *spyNATs) WaitFor(ctx context.Context, interfaceID string, pred func(*api.Nat) bool, poll time.Duration, opts ...clientv2.CallOption) (*api.Nat, error) {
+	s.spy.record(clientv2.DomainNATs, "WaitFor", interfaceID, poll)
+	return s.next.WaitFor(ctx, interfaceID, pred, poll, opts...)
+}
+
+func (s *spyNATs) CreateMany(ctx context.Context, nats []*api.Nat, opts ...clientv2.CallOption) (*api.NatList, *clientv2.BulkError) {
+	s.spy.record(clientv2.DomainNATs, "CreateMany", nats)
+	return s.next.CreateMany(ctx, nats, opts...)
+}
+
+func (s *spyNATs) ListByInterface(ctx context.Context, opts ...clientv2.CallOption) (map[string]*api.Nat, error) {
+	s.spy.record(clientv2.DomainNATs, "ListByInterface")
+	return s.next.ListByInterface(ctx, opts...)
+}
+
+func (s *spyNATs) ListAllIPs(ctx context.Context, opts ...clientv2.CallOption) ([]netip.Addr, error) {
+	s.spy.record(clientv2.DomainNATs, "ListAllIPs")
+	return s.next.ListAllIPs(ctx, opts...)
+}
+
+func (s *spyNATs) ListAny(ctx context.Context, natIP *netip.Addr, opts ...clientv2.CallOption) (*api.NatList, error) {
+	s.spy.record(clientv2.DomainNATs, "ListAny", natIP)
+	return s.next.ListAny(ctx, natIP, opts...)
+}
+
+func (s *spyNATs) ListLocal(ctx context.Context, natIP *netip.Addr, opts ...clientv2.CallOption) (*api.NatList, error) {
+	s.spy.record(clientv2.DomainNATs, "ListLocal", natIP)
+	return s.next.ListLocal(ctx, natIP, opts...)
+}
+
+func (s *spyNATs) ListNeighbors(ctx context.Context, natIP *netip.Addr, opts ...clientv2.CallOption) (*api.NatList, error) {
+	s.spy.record(clientv2.DomainNATs, "ListNeighbors", natIP)
+	return s.next.ListNeighbors(ctx, natIP, opts...)
+}
+
+func (s *spyNATs) CreateNeighbor(ctx context.Context, n *api.NeighborNat, opts ...clientv2.CallOption) (*api.NeighborNat, error) {
+	s.spy.record(clientv2.DomainNATs, "CreateNeighbor", n)
+	return s.next.CreateNeighbor(ctx, n, opts...)
+}
+
+func (s *spyNATs) DeleteNeighbor(ctx context.Context, n *api.NeighborNat, opts ...clientv2.CallOption) (*api.NeighborNat, error) {
+	s.spy.record(clientv2.DomainNATs, "DeleteNeighbor", n)
+	return s.next.DeleteNeighbor(ctx, n, opts...)
+}
+
+func (s *spyNATs) GetNeighborByRoute(ctx context.Context, natIP netip.Addr, underlay netip.Prefix, opts ...clientv2.CallOption) (*api.NeighborNat, error) {
+	s.spy.record(clientv2.DomainNATs, "GetNeighborByRoute", natIP, underlay)
+	return s.next.GetNeighborByRoute(ctx, natIP, underlay, opts...)
+}
+
+func (s *spyNATs) WithDefaults(opts ...clientv2.CallOption) clientv2.NATs {
+	return &spyNATs{spy: s.spy, next: s.next.WithDefaults(opts...)}
+}
+
+type spyFirewall struct {
+	spy  *Spy
+	next clientv2.Firewall
+}
+
+func (s *spyFirewall) List(ctx context.Context, interfaceID string, opts ...clientv2.CallOption) (*api.FirewallRuleList, error) {
+	s.spy.record(clientv2.DomainFirewall, "List", interfaceID)
+	return s.next.List(ctx, interfaceID, opts...)
+}
+
+func (s *spyFirewall) Get(ctx context.Context, interfaceID string, ruleID string, opts ...clientv2.CallOption) (*api.FirewallRule, error) {
+	s.spy.record(clientv2.DomainFirewall, "Get", interfaceID, ruleID)
+	return s.next.Get(ctx, interfaceID, ruleID, opts...)
+}
+
+func (s *spyFirewall) Create(ctx context.Context, rule *api.FirewallRule, opts ...clientv2.CallOption) (*api.FirewallRule, error) {
+	s.spy.record(clientv2.DomainFirewall, "Create", rule)
+	return s.next.Create(ctx, rule, opts...)
+}
+
+func (s *spyFirewall) Delete(ctx context.Context, interfaceID string, ruleID string, opts ...clientv2.CallOption) (*api.FirewallRule, error) {
+	s.spy.record(clientv2.DomainFirewall, "Delete", interfaceID, ruleID)
+	return s.next.Delete(ctx, interfaceID, ruleID, opts...)
+}
+
+func (s *spyFirewall) Ensure(ctx context.Context, rule *api.FirewallRule, opts ...clientv2.CallOption) (*api.FirewallRule, bool, error) {
+	s.spy.record(clientv2.DomainFirewall, "Ensure", rule)
+	return s.next.Ensure(ctx, rule, opts...)
+}
+
+func (s *spyFirewall) WaitFor(ctx context.Context, interfaceID, ruleID string, pred func(*api.FirewallRule) bool, poll time.Duration, opts ...clientv2.CallOption) (*api.FirewallRule, error) {
+	s.spy.record(clientv2.DomainFirewall, "WaitFor", interfaceID, ruleID, poll)
+	return s.next.WaitFor(ctx, interfaceID, ruleID, pred, poll, opts...)
+}
+
+func (s *spyFirewall) Duplicates(ctx context.Context, interfaceID string, opts ...clientv2.CallOption) ([]*api.FirewallRule, error) {
+	s.spy.record(clientv2.DomainFirewall, "Duplicates", interfaceID)
+	return s.next.Duplicates(ctx, interfaceID, opts...)
+}
+
+func (s *spyFirewall) Deduplicate(ctx context.Context, interfaceID string, opts ...clientv2.CallOption) ([]*api.FirewallRule, error) {
+	s.spy.record(clientv2.DomainFirewall, "Deduplicate", interfaceID)
+	return s.next.Deduplicate(ctx, interfaceID, opts...)
+}
+
+func (s *spyFirewall) FindShadowed(ctx context.Context, interfaceID string, opts ...clientv2.CallOption) ([]*api.FirewallRule, error) {
+	s.spy.record(clientv2.DomainFirewall, "FindShadowed", interfaceID)
+	return s.next.FindShadowed(ctx, interfaceID, opts...)
+}
+
+func (s *spyFirewall) CreateSet(ctx context.Context, interfaceID string, rules []*api.FirewallRule, opts ...clientv2.CallOption) (*api.FirewallRuleList, *clientv2.BulkError) {
+	s.spy.record(clientv2.DomainFirewall, "CreateSet", interfaceID, rules)
+	return s.next.CreateSet(ctx, interfaceID, rules, opts...)
+}
+
+func (s *spyFirewall) WithDefaults(opts ...clientv2.CallOption) clientv2.Firewall {
+	return &spyFirewall{spy: s.spy, next: s.next.WithDefaults(opts...)}
+}
+
+type spySystem struct {
+	spy  *Spy
+	next clientv2.System
+}
+
+func (s *spySystem) CheckInitialized(ctx context.Context, opts ...clientv2.CallOption) (*api.Initialized, error) {
+	s.spy.record(clientv2.DomainSystem, "CheckInitialized")
+	return s.next.CheckInitialized(ctx, opts...)
+}
+
+func (s *spySystem) Initialize(ctx context.Context, opts ...clientv2.CallOption) (*api.Initialized, error) {
+	s.spy.record(clientv2.DomainSystem, "Initialize")
+	return s.next.Initialize(ctx, opts...)
+}
+
+func (s *spySystem) InitializeAndID(ctx context.Context, opts ...clientv2.CallOption) (uuid.UUID, error) {
+	s.spy.record(clientv2.DomainSystem, "InitializeAndID")
+	return s.next.InitializeAndID(ctx, opts...)
+}
+
+func (s *spySystem) CheckInitializedID(ctx context.Context, opts ...clientv2.CallOption) (uuid.UUID, error) {
+	s.spy.record(clientv2.DomainSystem, "CheckInitializedID")
+	return s.next.CheckInitializedID(ctx, opts...)
+}
+
+func (s *spySystem) GetVni(ctx context.Context, vni uint32, vniType uint8, opts ...clientv2.CallOption) (*api.Vni, error) {
+	s.spy.record(clientv2.DomainSystem, "GetVni", vni, vniType)
+	return s.next.GetVni(ctx, vni, vniType, opts...)
+}
+
+func (s *spySystem) ResetVni(ctx context.Context, vni uint32, vniType uint8, opts ...clientv2.CallOption) (*api.Vni, error) {
+	s.spy.record(clientv2.DomainSystem, "ResetVni", vni, vniType)
+	return s.next.ResetVni(ctx, vni, vniType, opts...)
+}
+
+func (s *spySystem) ResetAllVnis(ctx context.Context, vniType uint8, vnis []uint32, opts ...clientv2.CallOption) *clientv2.BulkError {
+	s.spy.record(clientv2.DomainSystem, "ResetAllVnis", vniType, vnis)
+	return s.next.ResetAllVnis(ctx, vniType, vnis, opts...)
+}
+
+func (s *spySystem) GetVersion(ctx context.Context, version *api.Version, opts ...clientv2.CallOption) (*api.Version, error) {
+	s.spy.record(clientv2.DomainSystem, "GetVersion", version)
+	return s.next.GetVersion(ctx, version, opts...)
+}
+
+func (s *spySystem) Capabilities(ctx context.Context, opts ...clientv2.CallOption) (*clientv2.Capabilities, error) {
+	s.spy.record(clientv2.DomainSystem, "Capabilities")
+	return s.next.Capabilities(ctx, opts...)
+}
+
+func (s *spySystem) Summary(ctx context.Context, opts ...clientv2.CallOption) (*clientv2.ResourceSummary, error) {
+	s.spy.record(clientv2.DomainSystem, "Summary")
+	return s.next.Summary(ctx, opts...)
+}
+
+func (s *spySystem) WithDefaults(opts ...clientv2.CallOption) clientv2.System {
+	return &spySystem{spy: s.spy, next: s.next.WithDefaults(opts...)}
+}
+
+type spyCapture struct {
+	spy  *Spy
+	next clientv2.Capture
+}
+
+func (s *spyCapture) Start(ctx context.Context, capture *api.CaptureStart, opts ...clientv2.CallOption) (*api.CaptureStart, error) {
+	s.spy.record(clientv2.DomainCapture, "Start", capture)
+	return s.next.Start(ctx, capture, opts...)
+}
+
+func (s *spyCapture) Stop(ctx context.Context, opts ...clientv2.CallOption) (*api.CaptureStop, error) {
+	s.spy.record(clientv2.DomainCapture, "Stop")
+	return s.next.Stop(ctx, opts...)
+}
+
+func (s *spyCapture) Status(ctx context.Context, opts ...clientv2.CallOption) (*api.CaptureStatus, error) {
+	s.spy.record(clientv2.DomainCapture, "Status")
+	return s.next.Status(ctx, opts...)
+}
+
+func (s *spyCapture) StopIfRunning(ctx context.Context, maxAge time.Duration, opts ...clientv2.CallOption) (*api.CaptureStatus, error) {
+	s.spy.record(clientv2.DomainCapture, "StopIfRunning", maxAge)
+	return s.next.StopIfRunning(ctx, maxAge, opts...)
+}
+
+func (s *spyCapture) WaitForPackets(ctx context.Context, n uint64, poll time.Duration, opts ...clientv2.CallOption) (*api.CaptureStatus, error) {
+	s.spy.record(clientv2.DomainCapture, "WaitForPackets", n, poll)
+	return s.next.WaitForPackets(ctx, n, poll, opts...)
+}
+
+func (s *spyCapture) WithDefaults(opts ...clientv2.CallOption) clientv2.Capture {
+	return &spyCapture{spy: s.spy, next: s.next.WithDefaults(opts...)}
+}
@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clienttest
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	clientv2 "github.com/ironcore-dev/dpservice/go/dpservice-go/clientv2"
+)
+
+func TestSpy(t *testing.T) {
+	spy := NewSpy(clientv2.AsV2(&fakeLegacy{}))
+	ctx := context.Background()
+
+	if _, err := spy.Interfaces().Get(ctx, "vm1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := spy.Interfaces().WithDefaults().VIP().Get(ctx, "vm1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := spy.Interfaces().Firewall().List(ctx, "vm1"); err != nil {
+		t.Fatal(err)
+	}
+
+	calls := spy.Calls()
+	if len(calls) != 3 {
+		t.Fatalf("expected 3 calls, got %v", calls)
+	}
+	if got := calls[1].String(); got != "Interfaces.VIP.Get(vm1)" {
+		t.Errorf("unexpected call %s", got)
+	}
+
+	AssertCalledInOrder(t, spy,
+		Call{Domain: clientv2.DomainInterfaces, Method: "Get", Args: []any{"vm1"}},
+		Call{Domain: clientv2.DomainFirewall, Method: "List"})
+
+	r := run(func(t testing.TB) {
+		AssertCalledInOrder(t, spy,
+			Call{Domain: clientv2.DomainFirewall, Method: "List"},
+			Call{Domain: clientv2.DomainVirtualIPs, Method: "Get"})
+	})
+	if !r.failed || !strings.Contains(r.msg, "Interfaces.VIP.Get() after 1 matching calls") {
+		t.Fatalf("expected out-of-order failure, got %q", r.msg)
+	}
+	r = run(func(t testing.TB) {
+		AssertCalledInOrder(t, spy, Call{Domain: clientv2.DomainInterfaces, Method: "Get", Args: []any{"vm2"}})
+	})
+	if !r.failed {
+		t.Fatal("expected argument mismatch failure")
+	}
+
+	spy.Reset()
+	if len(spy.Calls()) != 0 {
+		t.Error("expected no calls after Reset")
+	}
+}
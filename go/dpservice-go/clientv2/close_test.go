@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+)
+
+func TestClose(t *testing.T) {
+	started := make(chan struct{})
+	fake := &fakeLegacy{
+		getInterface: func(ctx context.Context, id string) (*api.Interface, error) {
+			if id == "blocking" {
+				close(started)
+				<-ctx.Done()
+				return &api.Interface{}, ctx.Err()
+			}
+			return &api.Interface{}, nil
+		},
+	}
+	c := AsV2(fake)
+
+	inFlight := make(chan error)
+	go func() {
+		_, err := c.Interfaces().Get(context.Background(), "blocking")
+		inFlight <- err
+	}()
+	<-started
+
+	if err := c.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var closedErr *ClientClosedError
+	if err := <-inFlight; !errors.As(err, &closedErr) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the in-flight call to fail with ClientClosedError, got %v", err)
+	}
+
+	calls := len(fake.Calls())
+	_, err := c.Interfaces().Get(context.Background(), "vm1")
+	if !errors.As(err, &closedErr) || closedErr.Domain != DomainInterfaces || closedErr.Method != "Get" {
+		t.Fatalf("expected ClientClosedError for Interfaces.Get, got %v", err)
+	}
+	if len(fake.Calls()) != calls {
+		t.Fatalf("expected no call to reach the server after Close")
+	}
+	if err := c.Close(); err != nil {
+		t.Fatalf("expected a second Close to be a no-op, got %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"net/netip"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+	dperrors "github.com/ironcore-dev/dpservice/go/dpservice-go/errors"
+)
+
+type countingRecorder struct {
+	calls atomic.Int64
+}
+
+func (r *countingRecorder) ObserveCall(context.Context, []attribute.KeyValue, time.Duration, error) {
+	r.calls.Add(1)
+}
+
+// TestClientConcurrentUse hammers a single client from many goroutines across
+// domains. Run it with -race.
+func TestClientConcurrentUse(t *testing.T) {
+	var attempts atomic.Int64
+	fake := &fakeLegacy{
+		getInterface: func(_ context.Context, id string) (*api.Interface, error) {
+			// Every other attempt fails transiently to exercise retries.
+			if attempts.Add(1)%2 == 0 {
+				return &api.Interface{}, dperrors.NewStatusError(dperrors.OUT_OF_MEMORY, "oom")
+			}
+			return &api.Interface{InterfaceMeta: api.InterfaceMeta{ID: id}}, nil
+		},
+		listInterfaces: interfaceList("vm1", "vm2", "vm3"),
+		listRoutes: func(_ context.Context, vni uint32) (*api.RouteList, error) {
+			return &api.RouteList{Items: []api.Route{testRoute("10.0.0.0/24", vni, "fc00::1")}}, nil
+		},
+	}
+	recorder := &countingRecorder{}
+	logger, _ := bufferLogger()
+	c := AsV2(fake,
+		WithRetry(3, JitteredExponentialBackoff(time.Microsecond, time.Millisecond)),
+		WithIgnoredCodes(dperrors.OUT_OF_MEMORY),
+		WithMetrics(recorder),
+		WithLogger(logger),
+	)
+
+	const goroutines = 32
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			ctx := context.Background()
+			ifaces := c.Interfaces().WithDefaults(WithTimeout(time.Second))
+			for i := 0; i < 20; i++ {
+				_, _ = ifaces.Get(ctx, "vm1")
+				_, _ = c.Interfaces().List(ctx, WithAddressFamily(AddressFamilyIPv4))
+				_, _ = c.Interfaces().GetMany(ctx, []string{"vm1", "vm2"}, WithConcurrency(2))
+				_, _ = c.Routes().List(ctx, uint32(g), WithFields("prefix"))
+				_, _ = c.NATs().ListByInterface(ctx)
+				_, _ = c.LoadBalancers().Prefixes().Get(ctx, "vm1", netip.MustParsePrefix("10.0.0.0/32"))
+				_, _ = c.Firewall().List(ctx, "vm1", WithSortByPriority())
+				_, _ = c.System().Summary(ctx)
+				_ = Format(&api.Interface{InterfaceMeta: api.InterfaceMeta{ID: "vm1"}})
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	if recorder.calls.Load() == 0 {
+		t.Fatalf("expected calls to be observed")
+	}
+	if err := c.Close(); err != nil {
+		t.Fatalf("unexpected close error: %v", err)
+	}
+}
@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+)
+
+// ToCreateRequest returns a copy of a fetched resource that can be passed to
+// the Create method of its domain, e.g. to recreate it on another server.
+// The copy keeps the identifying metadata and the fields Create takes and
+// drops the status and the fields the server assigns: underlay routes,
+// except for neighbor NATs whose underlay route is an input, the virtual
+// function, NAT and virtual IP reported with an interface, and the VNI and
+// actual NAT IP of a NAT. Resources may be passed by value or pointer; the
+// result is always a pointer of the same type. The copy is shallow, so
+// pointed-to values such as addresses are shared with obj.
+func ToCreateRequest(obj any) (any, error) {
+	ptr, ok := resourcePointer(obj)
+	if !ok {
+		return nil, errors.New("cannot build a create request from nil")
+	}
+
+	switch r := ptr.(type) {
+	case *api.Interface:
+		spec := r.Spec
+		spec.UnderlayRoute, spec.VirtualFunction, spec.Nat, spec.VIP = nil, nil, nil, nil
+		return &api.Interface{TypeMeta: r.TypeMeta, InterfaceMeta: r.InterfaceMeta, Spec: spec}, nil
+	case *api.LoadBalancer:
+		spec := r.Spec
+		spec.UnderlayRoute = nil
+		spec.Lbports = slices.Clone(spec.Lbports)
+		return &api.LoadBalancer{TypeMeta: r.TypeMeta, LoadBalancerMeta: r.LoadBalancerMeta, Spec: spec}, nil
+	case *api.LoadBalancerTarget:
+		return &api.LoadBalancerTarget{TypeMeta: r.TypeMeta, LoadBalancerTargetMeta: r.LoadBalancerTargetMeta, Spec: r.Spec}, nil
+	case *api.LoadBalancerPrefix:
+		return &api.LoadBalancerPrefix{
+			TypeMeta:               r.TypeMeta,
+			LoadBalancerPrefixMeta: r.LoadBalancerPrefixMeta,
+			Spec:                   api.LoadBalancerPrefixSpec{Prefix: r.Spec.Prefix},
+		}, nil
+	case *api.Prefix:
+		return &api.Prefix{
+			TypeMeta:   r.TypeMeta,
+			PrefixMeta: api.PrefixMeta{InterfaceID: r.InterfaceID},
+			Spec:       api.PrefixSpec{Prefix: r.Spec.Prefix},
+		}, nil
+	case *api.VirtualIP:
+		return &api.VirtualIP{TypeMeta: r.TypeMeta, VirtualIPMeta: r.VirtualIPMeta, Spec: api.VirtualIPSpec{IP: r.Spec.IP}}, nil
+	case *api.Nat:
+		return &api.Nat{
+			TypeMeta: r.TypeMeta,
+			NatMeta:  r.NatMeta,
+			Spec:     api.NatSpec{NatIP: r.Spec.NatIP, MinPort: r.Spec.MinPort, MaxPort: r.Spec.MaxPort},
+		}, nil
+	case *api.NeighborNat:
+		return &api.NeighborNat{TypeMeta: r.TypeMeta, NeighborNatMeta: r.NeighborNatMeta, Spec: r.Spec}, nil
+	case *api.Route:
+		return &api.Route{TypeMeta: r.TypeMeta, RouteMeta: r.RouteMeta, Spec: r.Spec}, nil
+	case *api.FirewallRule:
+		return &api.FirewallRule{TypeMeta: r.TypeMeta, FirewallRuleMeta: r.FirewallRuleMeta, Spec: r.Spec}, nil
+	}
+	return nil, fmt.Errorf("cannot build a create request from %T", obj)
+}
+
+// toCreate is ToCreateRequest for the known resource types.
+func toCreate[T any](obj *T) *T {
+	req, err := ToCreateRequest(obj)
+	if err != nil {
+		panic(err)
+	}
+	return req.(*T)
+}
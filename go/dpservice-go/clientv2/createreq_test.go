@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+)
+
+func TestToCreateRequest(t *testing.T) {
+	underlay := netip.MustParseAddr("fc00::1")
+	ipv4 := netip.MustParseAddr("10.0.0.1")
+	iface := api.Interface{
+		InterfaceMeta: api.InterfaceMeta{ID: "vm1"},
+		Spec: api.InterfaceSpec{
+			VNI: 100, IPv4: &ipv4, UnderlayRoute: &underlay,
+			VirtualFunction: &api.VirtualFunction{Name: "vf0"},
+		},
+		Status: api.Status{Code: 1, Message: "stale"},
+	}
+
+	req, err := ToCreateRequest(iface)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	clean, ok := req.(*api.Interface)
+	if !ok || clean.ID != "vm1" || clean.Spec.VNI != 100 || clean.Spec.IPv4 != &ipv4 {
+		t.Fatalf("expected the identity and inputs to be kept, got %+v", req)
+	}
+	if clean.Spec.UnderlayRoute != nil || clean.Spec.VirtualFunction != nil || clean.Status != (api.Status{}) {
+		t.Fatalf("expected server-assigned fields to be dropped, got %+v", clean)
+	}
+	if iface.Spec.UnderlayRoute == nil {
+		t.Fatalf("expected the original to be left unchanged")
+	}
+
+	nat := &api.Nat{
+		NatMeta: api.NatMeta{InterfaceID: "vm1"},
+		Spec:    api.NatSpec{NatIP: &ipv4, MinPort: 100, MaxPort: 200, Vni: 100, UnderlayRoute: &underlay},
+	}
+	req, _ = ToCreateRequest(nat)
+	if got := req.(*api.Nat); got.Spec != (api.NatSpec{NatIP: &ipv4, MinPort: 100, MaxPort: 200}) || got.InterfaceID != "vm1" {
+		t.Fatalf("expected only the NAT inputs to be kept, got %+v", got)
+	}
+
+	neighbor := &api.NeighborNat{Spec: api.NeighborNatSpec{Vni: 100, UnderlayRoute: &underlay}}
+	req, _ = ToCreateRequest(neighbor)
+	if got := req.(*api.NeighborNat); got.Spec.UnderlayRoute != &underlay {
+		t.Fatalf("expected the underlay route of a neighbor NAT to be kept, got %+v", got)
+	}
+
+	if _, err := ToCreateRequest(&api.Version{}); err == nil {
+		t.Fatalf("expected an error for a type without Create")
+	}
+	if _, err := ToCreateRequest(nil); err == nil {
+		t.Fatalf("expected an error for nil")
+	}
+	var nilIface *api.Interface
+	if _, err := ToCreateRequest(nilIface); err == nil {
+		t.Fatalf("expected an error for a nil pointer")
+	}
+}
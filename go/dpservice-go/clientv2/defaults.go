@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+// withDefaults returns a core sharing the connection and closed state of c
+// whose defaults are those of c followed by opts.
+func (c *core) withDefaults(opts []CallOption) *core {
+	scoped := *c
+	scoped.defaults = append(c.defaults[:len(c.defaults):len(c.defaults)], opts...)
+	return &scoped
+}
+
+func (c *lbClient) WithDefaults(opts ...CallOption) LoadBalancers {
+	return &lbClient{core: c.withDefaults(opts)}
+}
+
+func (c *lbPrefixesClient) WithDefaults(opts ...CallOption) LoadBalancerPrefixes {
+	return &lbPrefixesClient{core: c.withDefaults(opts)}
+}
+
+func (c *lbTargetsClient) WithDefaults(opts ...CallOption) LoadBalancerTargets {
+	return &lbTargetsClient{core: c.withDefaults(opts)}
+}
+
+func (c *ifaceClient) WithDefaults(opts ...CallOption) Interfaces {
+	return &ifaceClient{core: c.withDefaults(opts)}
+}
+
+func (c *vipClient) WithDefaults(opts ...CallOption) VirtualIPs {
+	return &vipClient{core: c.withDefaults(opts)}
+}
+
+func (c *ifacePrefixesClient) WithDefaults(opts ...CallOption) InterfacePrefixes {
+	return &ifacePrefixesClient{core: c.withDefaults(opts)}
+}
+
+func (c *routeClient) WithDefaults(opts ...CallOption) Routes {
+	return &routeClient{core: c.withDefaults(opts)}
+}
+
+func (c *natClient) WithDefaults(opts ...CallOption) NATs {
+	return &natClient{core: c.withDefaults(opts)}
+}
+
+func (c *fwClient) WithDefaults(opts ...CallOption) Firewall {
+	return &fwClient{core: c.withDefaults(opts)}
+}
+
+func (c *systemClient) WithDefaults(opts ...CallOption) System {
+	return &systemClient{core: c.withDefaults(opts)}
+}
+
+func (c *captureClient) WithDefaults(opts ...CallOption) Capture {
+	return &captureClient{core: c.withDefaults(opts)}
+}
@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+	dperrors "github.com/ironcore-dev/dpservice/go/dpservice-go/errors"
+)
+
+func TestWithDefaults(t *testing.T) {
+	fake := &fakeLegacy{
+		getFirewallRule: func(context.Context, string, string) (*api.FirewallRule, error) {
+			return &api.FirewallRule{}, dperrors.NewStatusError(dperrors.NOT_FOUND, "not found")
+		},
+		getInterface: func(context.Context, string) (*api.Interface, error) {
+			return &api.Interface{}, dperrors.NewStatusError(dperrors.NOT_FOUND, "not found")
+		},
+	}
+	c := AsV2(fake)
+	fw := c.Firewall().WithDefaults(WithIgnoredCodes(dperrors.NOT_FOUND))
+
+	if _, err := fw.Get(context.Background(), "vm1", "r1"); err != nil {
+		t.Fatalf("expected the scoped default to ignore NOT_FOUND, got %v", err)
+	}
+	if _, err := c.Firewall().Get(context.Background(), "vm1", "r1"); !IsNotFound(err) {
+		t.Fatalf("expected the unscoped firewall client to fail, got %v", err)
+	}
+	if _, err := c.Interfaces().Get(context.Background(), "vm1"); !IsNotFound(err) {
+		t.Fatalf("expected other domains to be unaffected, got %v", err)
+	}
+
+	scoped := c.Interfaces().WithDefaults(WithIgnoredCodes(dperrors.NOT_FOUND))
+	if _, err := scoped.Firewall().Get(context.Background(), "vm1", "r1"); err != nil {
+		t.Fatalf("expected sub-clients to inherit scoped defaults, got %v", err)
+	}
+
+	_ = c.Close()
+	var closed *ClientClosedError
+	if _, err := fw.Get(context.Background(), "vm1", "r1"); !errors.As(err, &closed) {
+		t.Fatalf("expected scoped clients to share the closed state, got %v", err)
+	}
+}
@@ -10,6 +10,10 @@
 //
 // # Basic usage
 //
+// New builds a client from exactly one of WithProtoClient, WithLegacyClient
+// or WithAddress plus any CallOptions as defaults. NewFromProto, AsV2 and
+// NewFromAddress are shorthands for the single-source cases.
+//
 //	v2 := clientv2.NewFromProto(rpc)
 //
 //	// Load balancers
@@ -38,10 +42,117 @@
 //	// Capture
 //	_, _ = v2.Capture().Status(ctx)
 //
+// List methods always return a non-nil list whose Items slice is non-nil,
+// even when there are no results. If the server reports that it truncated a
+// list, the partial list comes with a *TruncatedError.
+//
+// # Call options and defaults
+//
+// Every method accepts CallOptions. Options given to New, NewFromProto or AsV2
+// become client defaults and are applied before the per-call options, for
+// example to install WithBefore and WithAfter hooks for all calls.
+//
+// WithRetry retries calls failing with transient gRPC errors. ConstantBackoff,
+// ExponentialBackoff and JitteredExponentialBackoff provide ready-made delays:
+//
+//	v2 := clientv2.AsV2(legacyClient, clientv2.WithRetry(5,
+//		clientv2.JitteredExponentialBackoff(100*time.Millisecond, 5*time.Second)))
+//
+// WithRetryBudget, given as a client default, caps the retries of all calls
+// to a share of the calls, so that a widespread outage does not turn into a
+// retry storm.
+//
+// WithRateLimiter throttles the requests sent to the server, and
+// WithDomainRateLimiter adds separate budgets per domain, for example to
+// throttle Capture harder than Interfaces.
+//
+// WithDefaults on a sub-client scopes additional defaults to that domain and
+// its sub-clients:
+//
+//	fw := v2.Firewall().WithDefaults(clientv2.WithIgnoredCodes(errors.NOT_FOUND))
+//
+// Retrying a Create whose first attempt was applied but timed out fails with
+// an already-exists error. WithIdempotencyKey makes such retries return the
+// existing resource instead.
+//
+// Reconcilers can use the Ensure methods instead, which create, replace or
+// keep a resource to match a desired one and report whether they changed
+// anything.
+//
+// WithLogger logs every call to a *slog.Logger. ContextWithLogger attaches a
+// request-scoped logger that takes precedence for calls made under that
+// context. Retries are logged one line each, or as a single summary per call
+// with WithRetryLogCoalesce(true).
+//
+// WithTracerProvider starts an OpenTelemetry span per call, and
+// WithSpanFilter skips spans for calls such as frequent health checks.
+//
+// WithEventChannel publishes an Event per call on a channel instead of
+// calling hooks. Sends never block; events are dropped, and counted, while
+// the channel is full.
+//
+// # Concurrency
+//
+// A Client, its sub-clients and clients derived with WithDefaults are safe
+// for concurrent use by multiple goroutines. Options are resolved into a
+// fresh value for every call, and the state shared between calls, such as
+// the closed state, JitterSource and the redactor of RegisterRedactor, is
+// synchronized. MetricsRecorder, RateLimiter, Transport and hook implementations are
+// called concurrently and must be safe for that themselves. Options writing
+// results to a caller-owned value, WithCaptureTrailers and
+// WithTruncateOversized, must not be shared between concurrent calls, so
+// pass them per call rather than as defaults. WithSingleFlight lets
+// concurrent Gets of the same resource share one RPC and one result object.
+// WithSerializeByKey runs calls mapped to the same key, e.g. mutations of
+// one interface, one at a time.
+//
+// # Bulk operations
+//
+// Bulk helpers such as NATs().CreateMany fan out the single-resource calls
+// with bounded parallelism (see WithConcurrency) and report per-item failures
+// in a *BulkError, which is nil when every item succeeded. WithChunkSize
+// splits very large batches into chunks that run one after the other.
+//
+//	list, bulkErr := v2.NATs().CreateMany(ctx, nats, clientv2.WithIgnoredCodes(343))
+//	if bulkErr != nil {
+//		for _, item := range bulkErr.Items {
+//			log.Printf("nat %d: %v", item.Index, item.Err)
+//		}
+//	}
+//	_ = list
+//
+// WithTimeout bounds a bulk operation as a whole. Each sub-call gets a fair
+// share of the remaining time unless WithPerItemTimeout sets a fixed budget.
+// RetryFailed re-runs only the failed items of a *BulkError.
+//
+// # Waiting for resources
+//
+// WaitFor polls Get until a predicate holds for the resource, for example
+// until the server has assigned an underlay route to a new interface:
+//
+//	iface, err := v2.Interfaces().WaitFor(ctx, "iface-1", func(iface *api.Interface) bool {
+//		return iface.Spec.UnderlayRoute != nil
+//	}, 100*time.Millisecond)
+//
+// It returns a *TimeoutError or *CanceledError when ctx ends first.
+//
+// # Migrating state between servers
+//
+// Migrate copies the resources of one dpservice to another in dependency
+// order, skipping resources that already exist, and reports the outcome of
+// every resource in a *MigrateReport. ExportAll streams the same resources
+// to an io.Writer as JSON lines for backups, and ImportAll recreates them
+// from such a stream. FindOrphans reports the sub-resources still held by
+// interface IDs that no longer exist, e.g. after a partial failure.
+//
 // Migration from legacy
 //
 //	// If you already have a legacy client, adapt it without changing call sites
 //	// that pass the legacy type around.
 //	v2 := clientv2.AsV2(legacyClient)
 //	_, _ = v2.Routes().List(ctx, 42)
+//
+//	// AsLegacy goes the other way for code still taking a legacy client; its
+//	// calls run through v2 and keep the defaults of v2.
+//	var old client.Client = clientv2.AsLegacy(v2)
 package clientv2
@@ -0,0 +1,167 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"slices"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+)
+
+// ensure makes the resource fetched by get match desired, comparing them with
+// same. A missing resource is created; a differing one is deleted and created
+// again, as dpservice has no update RPC. changed reports whether the server
+// state was modified, even if the call failed afterwards.
+func ensure[T any](ctx context.Context, desired *T, same func(desired, current *T) (bool, error), get, del, create func(context.Context) (*T, error)) (obj *T, changed bool, err error) {
+	current, err := get(ctx)
+	found, err := exists(any(current).(api.Object), err)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if found {
+		equal, err := same(desired, current)
+		if err != nil {
+			return nil, false, err
+		}
+		if equal {
+			return current, false, nil
+		}
+		if _, err := del(ctx); err != nil && !IsNotFound(err) {
+			return nil, false, err
+		}
+	}
+
+	created, err := create(ctx)
+	if err != nil {
+		return nil, found, err
+	}
+	return created, true, nil
+}
+
+// sameFingerprint reports whether desired and current have the same
+// Fingerprint.
+func sameFingerprint[T any](desired, current *T) (bool, error) {
+	want, err := Fingerprint(desired)
+	if err != nil {
+		return false, err
+	}
+	have, err := Fingerprint(current)
+	if err != nil {
+		return false, err
+	}
+	return want == have, nil
+}
+
+// sameInterface compares an interface by the fields Get returns. Get never
+// returns the PXE settings and always fills in the device, so PXE is ignored
+// and a desired interface without device matches any device.
+func sameInterface(desired, current *api.Interface) (bool, error) {
+	want, have := *desired, *current
+	want.Spec.PXE, have.Spec.PXE = nil, nil
+	if want.Spec.Device == "" {
+		want.Spec.Device = have.Spec.Device
+	}
+	return sameFingerprint(&want, &have)
+}
+
+// createOrGet calls create and reports true, or, if the resource already
+// exists, fetches it with get and reports false. An already-exists code
+// ignored with WithIgnoredCodes counts as existing too. The already-exists
+// error is returned if the resource cannot be fetched.
+func createOrGet[T any](ctx context.Context, create, get func(context.Context) (*T, error)) (*T, bool, error) {
+	created, err := create(ctx)
+	switch {
+	case IsAlreadyExists(err):
+	case err != nil:
+		return nil, false, err
+	case !slices.Contains(alreadyExistsCodes, any(created).(api.Object).GetStatus().Code):
+		return created, true, nil
+	}
+	existing, getErr := get(ctx)
+	if found, _ := exists(any(existing).(api.Object), getErr); !found {
+		return nil, false, err
+	}
+	return existing, false, nil
+}
+
+func (c *ifaceClient) CreateOrGet(ctx context.Context, iface *api.Interface, opts ...CallOption) (*api.Interface, bool, error) {
+	return createOrGet(ctx, func(ctx context.Context) (*api.Interface, error) {
+		return c.Create(ctx, iface, opts...)
+	}, func(ctx context.Context) (*api.Interface, error) {
+		return c.Get(ctx, iface.ID, opts...)
+	})
+}
+
+func (c *lbClient) CreateOrGet(ctx context.Context, lb *api.LoadBalancer, opts ...CallOption) (*api.LoadBalancer, bool, error) {
+	return createOrGet(ctx, func(ctx context.Context) (*api.LoadBalancer, error) {
+		return c.Create(ctx, lb, opts...)
+	}, func(ctx context.Context) (*api.LoadBalancer, error) {
+		return c.Get(ctx, lb.ID, opts...)
+	})
+}
+
+func (c *ifaceClient) Ensure(ctx context.Context, iface *api.Interface, opts ...CallOption) (*api.Interface, bool, error) {
+	return ensure(ctx, iface, sameInterface, func(ctx context.Context) (*api.Interface, error) {
+		return c.Get(ctx, iface.ID, opts...)
+	}, func(ctx context.Context) (*api.Interface, error) {
+		return c.Delete(ctx, iface.ID, opts...)
+	}, func(ctx context.Context) (*api.Interface, error) {
+		return c.Create(ctx, iface, opts...)
+	})
+}
+
+func (c *lbClient) Ensure(ctx context.Context, lb *api.LoadBalancer, opts ...CallOption) (*api.LoadBalancer, bool, error) {
+	return ensure(ctx, lb, sameFingerprint[api.LoadBalancer], func(ctx context.Context) (*api.LoadBalancer, error) {
+		return c.Get(ctx, lb.ID, opts...)
+	}, func(ctx context.Context) (*api.LoadBalancer, error) {
+		return c.Delete(ctx, lb.ID, opts...)
+	}, func(ctx context.Context) (*api.LoadBalancer, error) {
+		return c.Create(ctx, lb, opts...)
+	})
+}
+
+func (c *routeClient) Ensure(ctx context.Context, route *api.Route, opts ...CallOption) (*api.Route, bool, error) {
+	if err := checkPrefix(DomainRoutes, "Ensure", "prefix", route.Spec.Prefix); err != nil {
+		return nil, false, err
+	}
+	return ensure(ctx, route, sameFingerprint[api.Route], func(ctx context.Context) (*api.Route, error) {
+		return c.Get(ctx, route.VNI, *route.Spec.Prefix, opts...)
+	}, func(ctx context.Context) (*api.Route, error) {
+		return c.Delete(ctx, route.VNI, route.Spec.Prefix, opts...)
+	}, func(ctx context.Context) (*api.Route, error) {
+		return c.Create(ctx, route, opts...)
+	})
+}
+
+func (c *natClient) Ensure(ctx context.Context, nat *api.Nat, opts ...CallOption) (*api.Nat, bool, error) {
+	return ensure(ctx, nat, sameFingerprint[api.Nat], func(ctx context.Context) (*api.Nat, error) {
+		return c.Get(ctx, nat.InterfaceID, opts...)
+	}, func(ctx context.Context) (*api.Nat, error) {
+		return c.Delete(ctx, nat.InterfaceID, opts...)
+	}, func(ctx context.Context) (*api.Nat, error) {
+		return c.Create(ctx, nat, opts...)
+	})
+}
+
+func (c *fwClient) Ensure(ctx context.Context, rule *api.FirewallRule, opts ...CallOption) (*api.FirewallRule, bool, error) {
+	return ensure(ctx, rule, sameFingerprint[api.FirewallRule], func(ctx context.Context) (*api.FirewallRule, error) {
+		return c.Get(ctx, rule.InterfaceID, rule.Spec.RuleID, opts...)
+	}, func(ctx context.Context) (*api.FirewallRule, error) {
+		return c.Delete(ctx, rule.InterfaceID, rule.Spec.RuleID, opts...)
+	}, func(ctx context.Context) (*api.FirewallRule, error) {
+		return c.Create(ctx, rule, opts...)
+	})
+}
+
+func (c *vipClient) Ensure(ctx context.Context, vip *api.VirtualIP, opts ...CallOption) (*api.VirtualIP, bool, error) {
+	return ensure(ctx, vip, sameFingerprint[api.VirtualIP], func(ctx context.Context) (*api.VirtualIP, error) {
+		return c.Get(ctx, vip.InterfaceID, opts...)
+	}, func(ctx context.Context) (*api.VirtualIP, error) {
+		return c.Delete(ctx, vip.InterfaceID, opts...)
+	}, func(ctx context.Context) (*api.VirtualIP, error) {
+		return c.Create(ctx, vip, opts...)
+	})
+}
@@ -0,0 +1,175 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"reflect"
+	"testing"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+	dperrors "github.com/ironcore-dev/dpservice/go/dpservice-go/errors"
+	dpdkproto "github.com/ironcore-dev/dpservice/go/dpservice-go/proto"
+)
+
+// fakeVIPStore backs the virtual IP calls of a fakeLegacy with a map.
+func fakeVIPStore(fake *fakeLegacy) {
+	vips := map[string]*api.VirtualIP{}
+	fake.getVirtualIP = func(_ context.Context, id string) (*api.VirtualIP, error) {
+		if vip, ok := vips[id]; ok {
+			return vip, nil
+		}
+		return &api.VirtualIP{}, dperrors.NewStatusError(dperrors.NOT_FOUND, "not found")
+	}
+	fake.createVirtualIP = func(_ context.Context, vip *api.VirtualIP) (*api.VirtualIP, error) {
+		stored := *vip
+		stored.Spec.UnderlayRoute = &netip.Addr{}
+		vips[vip.InterfaceID] = &stored
+		return &stored, nil
+	}
+	fake.deleteVirtualIP = func(_ context.Context, id string) (*api.VirtualIP, error) {
+		delete(vips, id)
+		return &api.VirtualIP{}, nil
+	}
+}
+
+func TestVirtualIPsEnsure(t *testing.T) {
+	fake := &fakeLegacy{}
+	fakeVIPStore(fake)
+	vips := AsV2(fake).Interfaces().VIP()
+	ip := netip.MustParseAddr("45.86.6.6")
+	desired := &api.VirtualIP{VirtualIPMeta: api.VirtualIPMeta{InterfaceID: "vm1"}, Spec: api.VirtualIPSpec{IP: &ip}}
+
+	steps := []struct {
+		name    string
+		ip      string
+		changed bool
+		calls   []string
+	}{
+		{"create missing", "45.86.6.6", true, []string{"GetVirtualIP", "CreateVirtualIP"}},
+		{"keep equal", "45.86.6.6", false, []string{"GetVirtualIP"}},
+		{"replace differing", "45.86.6.7", true, []string{"GetVirtualIP", "DeleteVirtualIP", "CreateVirtualIP"}},
+	}
+	for _, step := range steps {
+		ip := netip.MustParseAddr(step.ip)
+		desired.Spec.IP = &ip
+		before := len(fake.Calls())
+		vip, changed, err := vips.Ensure(context.Background(), desired)
+		if err != nil || changed != step.changed || *vip.Spec.IP != ip {
+			t.Fatalf("%s: expected changed=%v and IP %s, got %v, %v, %+v", step.name, step.changed, ip, err, changed, vip)
+		}
+		if calls := fake.Calls()[before:]; !reflect.DeepEqual(calls, step.calls) {
+			t.Fatalf("%s: expected calls %v, got %v", step.name, step.calls, calls)
+		}
+	}
+}
+
+func TestInterfacesEnsureUnchanged(t *testing.T) {
+	fake := &fakeLegacy{
+		// getInterface returns what the legacy client returns for a real
+		// server: a filled in device and empty metering, and no PXE.
+		getInterface: func(_ context.Context, id string) (*api.Interface, error) {
+			return api.ProtoInterfaceToInterface(&dpdkproto.Interface{
+				Id:             []byte(id),
+				Vni:            100,
+				PrimaryIpv4:    []byte("10.0.0.1"),
+				PrimaryIpv6:    []byte("::"),
+				UnderlayRoute:  []byte("fc00::1"),
+				PciName:        "0000:3b:00.2",
+				MeteringParams: &dpdkproto.MeteringParams{},
+			})
+		},
+	}
+	ipv4 := netip.MustParseAddr("10.0.0.1")
+	desired := &api.Interface{
+		InterfaceMeta: api.InterfaceMeta{ID: "vm1"},
+		Spec:          api.InterfaceSpec{VNI: 100, IPv4: &ipv4, PXE: &api.PXE{Server: "10.0.0.254", FileName: "boot.ipxe"}},
+	}
+	iface, changed, err := AsV2(fake).Interfaces().Ensure(context.Background(), desired)
+	if err != nil || changed || iface.Spec.Device != "0000:3b:00.2" {
+		t.Fatalf("expected the interface to be kept, got %v, %v, %+v", err, changed, iface)
+	}
+	if calls := fake.Calls(); !reflect.DeepEqual(calls, []string{"GetInterface"}) {
+		t.Fatalf("expected only a Get, got %v", calls)
+	}
+
+	desired.Spec.Device = "0000:3b:00.3"
+	fake.deleteInterface = func(context.Context, string) (*api.Interface, error) { return &api.Interface{}, nil }
+	fake.createInterface = func(_ context.Context, iface *api.Interface) (*api.Interface, error) { return iface, nil }
+	if _, changed, err := AsV2(fake).Interfaces().Ensure(context.Background(), desired); err != nil || !changed {
+		t.Fatalf("expected a different device to replace the interface, got %v, %v", err, changed)
+	}
+}
+
+func TestEnsureFailure(t *testing.T) {
+	fake := &fakeLegacy{
+		getNat: func(context.Context, string) (*api.Nat, error) {
+			return &api.Nat{}, dperrors.NewStatusError(dperrors.SNAT_NO_DATA, "no nat")
+		},
+		createNat: func(context.Context, *api.Nat) (*api.Nat, error) {
+			return &api.Nat{}, dperrors.NewStatusError(dperrors.OUT_OF_MEMORY, "oom")
+		},
+		getFirewallRule: func(context.Context, string, string) (*api.FirewallRule, error) {
+			return &api.FirewallRule{}, dperrors.NewStatusError(dperrors.OUT_OF_MEMORY, "oom")
+		},
+	}
+	c := AsV2(fake)
+
+	if _, changed, err := c.NATs().Ensure(context.Background(), &api.Nat{NatMeta: api.NatMeta{InterfaceID: "vm1"}}); err == nil || changed {
+		t.Fatalf("expected a failed create of a missing NAT to change nothing, got %v, %v", err, changed)
+	}
+	rule := &api.FirewallRule{FirewallRuleMeta: api.FirewallRuleMeta{InterfaceID: "vm1"}, Spec: api.FirewallRuleSpec{RuleID: "fr1"}}
+	if _, changed, err := c.Firewall().Ensure(context.Background(), rule); err == nil || changed || len(fake.Calls()) != 3 {
+		t.Fatalf("expected a failed lookup to end Ensure, got %v, %v, calls %v", err, changed, fake.Calls())
+	}
+	var invalid *InvalidArgumentError
+	if _, _, err := c.Routes().Ensure(context.Background(), &api.Route{}); !errors.As(err, &invalid) {
+		t.Fatalf("expected an invalid argument error for a route without prefix, got %v", err)
+	}
+}
+
+func TestInterfacesCreateOrGet(t *testing.T) {
+	ifaces := map[string]*api.Interface{}
+	fake := &fakeLegacy{
+		createInterface: func(_ context.Context, iface *api.Interface) (*api.Interface, error) {
+			if _, ok := ifaces[iface.ID]; ok {
+				return &api.Interface{Status: api.Status{Code: dperrors.ALREADY_EXISTS}}, dperrors.NewStatusError(dperrors.ALREADY_EXISTS, "exists")
+			}
+			ifaces[iface.ID] = iface
+			return iface, nil
+		},
+		getInterface: func(_ context.Context, id string) (*api.Interface, error) {
+			if iface, ok := ifaces[id]; ok {
+				return iface, nil
+			}
+			return &api.Interface{}, dperrors.NewStatusError(dperrors.NOT_FOUND, "not found")
+		},
+	}
+	c := AsV2(fake).Interfaces()
+	iface := &api.Interface{InterfaceMeta: api.InterfaceMeta{ID: "vm1"}, Spec: api.InterfaceSpec{VNI: 100}}
+
+	got, created, err := c.CreateOrGet(context.Background(), iface)
+	if err != nil || !created || got.Spec.VNI != 100 {
+		t.Fatalf("expected the interface to be created, got %+v, %v, %v", got, created, err)
+	}
+	other := &api.Interface{InterfaceMeta: api.InterfaceMeta{ID: "vm1"}, Spec: api.InterfaceSpec{VNI: 200}}
+	got, created, err = c.CreateOrGet(context.Background(), other)
+	if err != nil || created || got.Spec.VNI != 100 {
+		t.Fatalf("expected the existing interface, got %+v, %v, %v", got, created, err)
+	}
+	got, created, err = c.CreateOrGet(context.Background(), other, WithIgnoreAlreadyExists())
+	if err != nil || created || got.Spec.VNI != 100 {
+		t.Fatalf("expected an ignored already-exists to count as existing, got %+v, %v, %v", got, created, err)
+	}
+
+	delete(ifaces, "vm1")
+	fake.createInterface = func(context.Context, *api.Interface) (*api.Interface, error) {
+		return &api.Interface{}, dperrors.NewStatusError(dperrors.ALREADY_EXISTS, "exists")
+	}
+	if _, _, err := c.CreateOrGet(context.Background(), iface); !IsAlreadyExists(err) {
+		t.Fatalf("expected the already-exists error for a vanished interface, got %v", err)
+	}
+}
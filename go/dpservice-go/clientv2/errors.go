@@ -0,0 +1,151 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	dperrors "github.com/ironcore-dev/dpservice/go/dpservice-go/errors"
+)
+
+// notFoundCodes are the dpservice status codes reporting that the addressed
+// resource, or the interface owning it, does not exist.
+var notFoundCodes = []uint32{
+	dperrors.NOT_FOUND,
+	dperrors.NO_VM,
+	dperrors.ROUTE_NOT_FOUND,
+	dperrors.SNAT_NO_DATA,
+	dperrors.DNAT_NO_DATA,
+	dperrors.NO_BACKIP,
+	dperrors.NO_LB,
+}
+
+// IsNotFound reports whether err is a dpservice status error signalling that
+// the resource does not exist.
+func IsNotFound(err error) bool {
+	return dperrors.IsStatusErrorCode(err, notFoundCodes...)
+}
+
+// alreadyExistsCodes are the dpservice status codes reporting that the
+// resource to be created already exists.
+var alreadyExistsCodes = []uint32{
+	dperrors.ALREADY_EXISTS,
+	dperrors.ROUTE_EXISTS,
+	dperrors.DNAT_EXISTS,
+	dperrors.SNAT_EXISTS,
+}
+
+// IsAlreadyExists reports whether err is a dpservice status error signalling
+// that the resource to be created already exists.
+func IsAlreadyExists(err error) bool {
+	return dperrors.IsStatusErrorCode(err, alreadyExistsCodes...)
+}
+
+// NotSupportedError is returned when the server does not implement the RPC
+// behind a v2 method, typically because it runs an older dpservice version.
+type NotSupportedError struct {
+	Domain string
+	Method string
+	// Err is the underlying error, usually a gRPC Unimplemented status.
+	Err error
+}
+
+func (e *NotSupportedError) Error() string {
+	return fmt.Sprintf("%s.%s is not supported by the server", e.Domain, e.Method)
+}
+
+func (e *NotSupportedError) Unwrap() error {
+	return e.Err
+}
+
+// ClientClosedError is returned by calls made after, or interrupted by, Close.
+type ClientClosedError struct {
+	Domain string
+	Method string
+	// Err is the error of an interrupted call, if any.
+	Err error
+}
+
+func (e *ClientClosedError) Error() string {
+	return fmt.Sprintf("%s.%s: client is closed", e.Domain, e.Method)
+}
+
+func (e *ClientClosedError) Unwrap() error {
+	return e.Err
+}
+
+// InvalidArgumentError is returned before any RPC is made when an argument
+// of a domain.method call is invalid, e.g. a zero netip.Prefix.
+type InvalidArgumentError struct {
+	Domain   string
+	Method   string
+	Argument string
+	Reason   string
+}
+
+func (e *InvalidArgumentError) Error() string {
+	return fmt.Sprintf("%s.%s: invalid argument %s: %s", e.Domain, e.Method, e.Argument, e.Reason)
+}
+
+// checkID rejects an empty ID argument. dpservice does not treat an empty
+// ID as a wildcard, but the errors it returns for one are misleading.
+func checkID(domain, method, argument, id string) error {
+	if id == "" {
+		return &InvalidArgumentError{Domain: domain, Method: method, Argument: argument, Reason: "ID is empty"}
+	}
+	return nil
+}
+
+// checkPrefix rejects a nil or invalid prefix argument.
+func checkPrefix(domain, method, argument string, prefix *netip.Prefix) error {
+	switch {
+	case prefix == nil:
+		return &InvalidArgumentError{Domain: domain, Method: method, Argument: argument, Reason: "prefix is nil"}
+	case !prefix.IsValid():
+		return &InvalidArgumentError{Domain: domain, Method: method, Argument: argument, Reason: "prefix is not valid"}
+	}
+	return nil
+}
+
+// checkAddr rejects a nil or invalid address argument.
+func checkAddr(domain, method, argument string, addr *netip.Addr) error {
+	switch {
+	case addr == nil:
+		return &InvalidArgumentError{Domain: domain, Method: method, Argument: argument, Reason: "address is nil"}
+	case !addr.IsValid():
+		return &InvalidArgumentError{Domain: domain, Method: method, Argument: argument, Reason: "address is not valid"}
+	}
+	return nil
+}
+
+// IsUnimplemented reports whether err indicates that the server does not
+// support the called operation, either as a NotSupportedError or as a raw
+// gRPC Unimplemented status.
+func IsUnimplemented(err error) bool {
+	if err == nil {
+		return false
+	}
+	var notSupported *NotSupportedError
+	if errors.As(err, &notSupported) {
+		return true
+	}
+	return status.Code(err) == codes.Unimplemented
+}
+
+// wrapError converts transport errors of a domain.method call into the v2
+// error types. Errors that need no conversion are returned unchanged.
+func wrapError(domain, method string, err error) error {
+	if err == nil {
+		return nil
+	}
+	if status.Code(err) == codes.Unimplemented {
+		return &NotSupportedError{Domain: domain, Method: method, Err: err}
+	}
+	return err
+}
@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+)
+
+func TestUnimplementedIsNotSupported(t *testing.T) {
+	fake := &fakeLegacy{
+		captureStatus: func(context.Context) (*api.CaptureStatus, error) {
+			return &api.CaptureStatus{}, status.Error(codes.Unimplemented, "unknown method CaptureStatus")
+		},
+	}
+
+	_, err := AsV2(fake).Capture().Status(context.Background())
+	var notSupported *NotSupportedError
+	if !errors.As(err, &notSupported) {
+		t.Fatalf("expected NotSupportedError, got %T: %v", err, err)
+	}
+	if notSupported.Domain != DomainCapture || notSupported.Method != "Status" {
+		t.Fatalf("unexpected method in error: %s.%s", notSupported.Domain, notSupported.Method)
+	}
+	if !IsUnimplemented(err) || status.Code(err) != codes.Unimplemented {
+		t.Fatalf("expected error to be classified as unimplemented, got %v", err)
+	}
+}
+
+func TestIsUnimplemented(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{errors.New("boom"), false},
+		{status.Error(codes.Unavailable, "down"), false},
+		{status.Error(codes.Unimplemented, "nope"), true},
+		{fmt.Errorf("wrapped: %w", status.Error(codes.Unimplemented, "nope")), true},
+		{&NotSupportedError{Domain: DomainSystem, Method: "GetVni"}, true},
+	} {
+		if got := IsUnimplemented(tc.err); got != tc.want {
+			t.Errorf("IsUnimplemented(%v) = %v, want %v", tc.err, got, tc.want)
+		}
+	}
+}
+
+func TestDeleteRejectsInvalidNetip(t *testing.T) {
+	fake := &fakeLegacy{}
+	c := AsV2(fake)
+	ctx := context.Background()
+	var zeroPrefix netip.Prefix
+	var zeroAddr netip.Addr
+
+	calls := map[string]func() error{
+		"LoadBalancers.Prefixes zero": func() error {
+			_, err := c.LoadBalancers().Prefixes().Delete(ctx, "vm1", &zeroPrefix)
+			return err
+		},
+		"LoadBalancers.Targets zero": func() error {
+			_, err := c.LoadBalancers().Targets().Delete(ctx, "lb1", &zeroAddr)
+			return err
+		},
+		"LoadBalancers.Targets nil": func() error {
+			_, err := c.LoadBalancers().Targets().Delete(ctx, "lb1", nil)
+			return err
+		},
+		"Interfaces.Prefixes zero": func() error {
+			_, err := c.Interfaces().Prefixes().Delete(ctx, "vm1", &zeroPrefix)
+			return err
+		},
+		"Routes zero": func() error {
+			_, err := c.Routes().Delete(ctx, 1, &zeroPrefix)
+			return err
+		},
+		"Routes nil": func() error {
+			_, err := c.Routes().Delete(ctx, 1, nil)
+			return err
+		},
+	}
+	for name, call := range calls {
+		var invalid *InvalidArgumentError
+		if err := call(); !errors.As(err, &invalid) {
+			t.Errorf("%s: expected an InvalidArgumentError, got %v", name, err)
+		}
+	}
+	if len(fake.Calls()) != 0 {
+		t.Fatalf("expected no RPC for invalid arguments, got %v", fake.Calls())
+	}
+
+	prefix := netip.MustParsePrefix("10.0.0.0/24")
+	if _, err := c.Routes().Delete(ctx, 1, &prefix); err != nil {
+		t.Fatalf("expected a valid prefix to pass, got %v", err)
+	}
+}
+
+func TestEmptyIDRejected(t *testing.T) {
+	fake := &fakeLegacy{}
+	c := AsV2(fake)
+	ctx := context.Background()
+	prefix := netip.MustParsePrefix("10.0.0.0/24")
+	addr := netip.MustParseAddr("10.0.0.1")
+
+	calls := map[string]func() error{
+		"LoadBalancers.Get":             func() error { _, err := c.LoadBalancers().Get(ctx, ""); return err },
+		"LoadBalancers.Delete":          func() error { _, err := c.LoadBalancers().Delete(ctx, ""); return err },
+		"LoadBalancers.Describe":        func() error { _, err := c.LoadBalancers().Describe(ctx, "", "vm1"); return err },
+		"LoadBalancers.Prefixes.List":   func() error { _, err := c.LoadBalancers().Prefixes().List(ctx, ""); return err },
+		"LoadBalancers.Prefixes.Delete": func() error { _, err := c.LoadBalancers().Prefixes().Delete(ctx, "", &prefix); return err },
+		"LoadBalancers.Targets.Get":     func() error { _, err := c.LoadBalancers().Targets().Get(ctx, "", addr); return err },
+		"LoadBalancers.Targets.Delete":  func() error { _, err := c.LoadBalancers().Targets().Delete(ctx, "", &addr); return err },
+		"Interfaces.Get":                func() error { _, err := c.Interfaces().Get(ctx, ""); return err },
+		"Interfaces.Delete":             func() error { _, err := c.Interfaces().Delete(ctx, ""); return err },
+		"Interfaces.DeleteCascade":      func() error { return c.Interfaces().DeleteCascade(ctx, "") },
+		"Interfaces.VIP.Get":            func() error { _, err := c.Interfaces().VIP().Get(ctx, ""); return err },
+		"Interfaces.Prefixes.Get":       func() error { _, err := c.Interfaces().Prefixes().Get(ctx, "", prefix); return err },
+		"Interfaces.Prefixes.Delete":    func() error { _, err := c.Interfaces().Prefixes().Delete(ctx, "", &prefix); return err },
+		"NATs.Get":                      func() error { _, err := c.NATs().Get(ctx, ""); return err },
+		"NATs.Delete":                   func() error { _, err := c.NATs().Delete(ctx, ""); return err },
+		"Firewall.List":                 func() error { _, err := c.Firewall().List(ctx, ""); return err },
+		"Firewall.Get":                  func() error { _, err := c.Firewall().Get(ctx, "vm1", ""); return err },
+		"Firewall.Delete":               func() error { _, err := c.Firewall().Delete(ctx, "", "fr1"); return err },
+		"Firewall.Duplicates":           func() error { _, err := c.Firewall().Duplicates(ctx, ""); return err },
+	}
+	for name, call := range calls {
+		var invalid *InvalidArgumentError
+		if err := call(); !errors.As(err, &invalid) {
+			t.Errorf("%s: expected an InvalidArgumentError, got %v", name, err)
+		} else if !strings.HasSuffix(invalid.Argument, "ID") && invalid.Argument != "id" {
+			t.Errorf("%s: unexpected argument %q", name, invalid.Argument)
+		}
+	}
+	if len(fake.Calls()) != 0 {
+		t.Fatalf("expected no RPC for empty IDs, got %v", fake.Calls())
+	}
+}
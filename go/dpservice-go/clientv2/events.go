@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Event describes a completed call, see WithEventChannel.
+type Event struct {
+	Domain string
+	Method string
+	// Start is the time the call was issued and Duration how long it took,
+	// including retries.
+	Start    time.Time
+	Duration time.Duration
+	// Err is the error returned to the caller, nil on success.
+	Err error
+	// Dropped is the number of events dropped on the channel so far because
+	// it was full.
+	Dropped uint64
+}
+
+// WithEventChannel sends an Event to ch after every call, as an alternative
+// to WithAfter hooks for consumers that forward calls to an event bus.
+//
+// Sends never block: if ch is full, the event is dropped and counted, and
+// the count is reported in the Dropped field of the next event delivered.
+// Size the buffer of ch for bursts and drain it promptly, as a slow consumer
+// loses events rather than slowing down calls. The count is kept per
+// WithEventChannel option, so pass the same option, e.g. as a client
+// default, for a consistent count. ch must not be closed while calls using
+// the option are in flight.
+func WithEventChannel(ch chan<- Event) CallOption {
+	sink := &eventSink{ch: ch}
+	return func(o *callOptions) {
+		o.events = sink
+	}
+}
+
+type eventSink struct {
+	ch      chan<- Event
+	dropped atomic.Uint64
+}
+
+func (s *eventSink) send(ev Event) {
+	ev.Dropped = s.dropped.Load()
+	select {
+	case s.ch <- ev:
+	default:
+		s.dropped.Add(1)
+	}
+}
@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+	dperrors "github.com/ironcore-dev/dpservice/go/dpservice-go/errors"
+)
+
+func TestWithEventChannel(t *testing.T) {
+	fake := &fakeLegacy{
+		getInterface: func(context.Context, string) (*api.Interface, error) {
+			return &api.Interface{}, dperrors.NewStatusError(dperrors.NOT_FOUND, "not found")
+		},
+	}
+	events := make(chan Event, 2)
+	c := AsV2(fake, WithEventChannel(events))
+	ctx := context.Background()
+
+	_, _ = c.Interfaces().List(ctx)
+	_, _ = c.Interfaces().Get(ctx, "vm1")
+	// The channel is full, so this event is dropped without blocking.
+	_, _ = c.Routes().List(ctx, 100)
+
+	ev := <-events
+	if ev.Domain != DomainInterfaces || ev.Method != "List" || ev.Err != nil || ev.Start.IsZero() {
+		t.Fatalf("expected a successful Interfaces.List event, got %+v", ev)
+	}
+	ev = <-events
+	if ev.Method != "Get" || !IsNotFound(ev.Err) {
+		t.Fatalf("expected a failed Interfaces.Get event, got %+v", ev)
+	}
+
+	_, _ = c.NATs().Get(ctx, "vm1")
+	ev = <-events
+	if ev.Domain != DomainNATs || ev.Dropped != 1 {
+		t.Fatalf("expected the NATs.Get event to report one drop, got %+v", ev)
+	}
+}
@@ -5,7 +5,15 @@ package clientv2_test
 
 import (
 	"context"
+	"log"
+	"log/slog"
 	"net/netip"
+	"time"
+
+	"golang.org/x/sync/semaphore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 
 	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
 	clientv2 "github.com/ironcore-dev/dpservice/go/dpservice-go/clientv2"
@@ -19,7 +27,24 @@ func ExampleNewFromProto() {
 	v2 := clientv2.NewFromProto(rpc)
 	_ = v2
 	_ = ctx
-	// Output:
+}
+
+func ExampleWithBefore() {
+	var rpc dpdkproto.DPDKironcoreClient
+	ctx := context.TODO()
+
+	// Hooks passed to the constructor apply to every call of the client.
+	v2 := clientv2.NewFromProto(rpc,
+		clientv2.WithBefore(func(domain, method string, ctx context.Context) context.Context {
+			return metadata.AppendToOutgoingContext(ctx, "x-request-id", "42")
+		}),
+		clientv2.WithAfter(func(domain, method string, err error) {
+			if err != nil {
+				log.Printf("%s.%s failed: %v", domain, method, err)
+			}
+		}),
+	)
+	_, _ = v2.Interfaces().List(ctx)
 }
 
 func ExampleClient_LoadBalancers() {
@@ -36,6 +61,7 @@ func ExampleClient_LoadBalancers() {
 		LoadBalancerMeta: api.LoadBalancerMeta{ID: "lb-1"},
 	})
 	_, _ = v2.LoadBalancers().Delete(ctx, "lb-1")
+	_, _ = v2.LoadBalancers().Describe(ctx, "lb-1", "iface-1")
 
 	// LB sub-resources: prefixes
 	_, _ = v2.LoadBalancers().Prefixes().List(ctx, "iface-1", clientv2.WithIgnoredCodes(1001))
@@ -47,12 +73,12 @@ func ExampleClient_LoadBalancers() {
 
 	// LB sub-resources: targets
 	_, _ = v2.LoadBalancers().Targets().List(ctx, "lb-1")
+	_, _ = v2.LoadBalancers().Targets().Get(ctx, "lb-1", netip.MustParseAddr("fc00::1"))
 	_, _ = v2.LoadBalancers().Targets().Create(ctx, &api.LoadBalancerTarget{
 		LoadBalancerTargetMeta: api.LoadBalancerTargetMeta{LoadbalancerID: "lb-1"},
 	})
 	ip := netip.Addr{}
 	_, _ = v2.LoadBalancers().Targets().Delete(ctx, "lb-1", &ip)
-	// Output:
 }
 
 func ExampleClient_Interfaces() {
@@ -62,9 +88,19 @@ func ExampleClient_Interfaces() {
 	v2 := clientv2.NewFromProto(rpc)
 
 	_, _ = v2.Interfaces().Get(ctx, "iface-1")
+	_, _ = v2.Interfaces().Get(ctx, "iface-1", clientv2.WithFields("vni", "primary_ipv4"))
+	_, _ = v2.Interfaces().GetMany(ctx, []string{"iface-1", "iface-2"}, clientv2.WithIncludeMissing())
 	_, _ = v2.Interfaces().List(ctx)
+	_, _ = v2.Interfaces().List(ctx, clientv2.WithResponseSizeLimit(10000))
 	_, _ = v2.Interfaces().Create(ctx, &api.Interface{InterfaceMeta: api.InterfaceMeta{ID: "iface-1"}})
 	_, _ = v2.Interfaces().Delete(ctx, "iface-1")
+	_ = v2.Interfaces().DeleteCascade(ctx, "iface-1")
+
+	// Deferred cleanup that must run even if ctx is already cancelled.
+	defer func() {
+		_ = v2.Interfaces().DeleteCascade(ctx, "iface-1",
+			clientv2.WithDetachedContext(), clientv2.WithTimeout(5*time.Second))
+	}()
 
 	// VIP
 	_, _ = v2.Interfaces().VIP().Get(ctx, "iface-1")
@@ -82,8 +118,8 @@ func ExampleClient_Interfaces() {
 	_, _ = v2.Interfaces().Firewall().List(ctx, "iface-1")
 	_, _ = v2.Interfaces().Firewall().Create(ctx, &api.FirewallRule{FirewallRuleMeta: api.FirewallRuleMeta{InterfaceID: "iface-1"}})
 	_, _ = v2.Interfaces().Firewall().Get(ctx, "iface-1", "rule-1")
+	_, _ = v2.Interfaces().Firewall().List(ctx, "iface-1", clientv2.WithSortByPriority())
 	_, _ = v2.Interfaces().Firewall().Delete(ctx, "iface-1", "rule-1")
-	// Output:
 }
 
 func ExampleClient_Routes() {
@@ -96,7 +132,14 @@ func ExampleClient_Routes() {
 	var ip netip.Addr
 	p := netip.PrefixFrom(ip, 24)
 	_, _ = v2.Routes().Delete(ctx, 42, &p)
-	// Output:
+
+	// Log calls of this reconcile iteration with its correlation ID.
+	ctx = clientv2.ContextWithLogger(ctx, slog.Default().With("reconcile", "r-1"))
+
+	// Drift detection without changing anything.
+	if diff, err := v2.Routes().Verify(ctx, 42, []*api.Route{}); err == nil && !diff.InSync() {
+		log.Printf("routes drifted: %d missing, %d extra", len(diff.Missing), len(diff.Extra))
+	}
 }
 
 func ExampleClient_NATs() {
@@ -107,6 +150,20 @@ func ExampleClient_NATs() {
 	_, _ = v2.NATs().Get(ctx, "iface-1")
 	_, _ = v2.NATs().Create(ctx, &api.Nat{NatMeta: api.NatMeta{InterfaceID: "iface-1"}})
 	_, _ = v2.NATs().Delete(ctx, "iface-1")
+	_, _ = v2.NATs().CreateMany(ctx, []*api.Nat{
+		{NatMeta: api.NatMeta{InterfaceID: "iface-1"}},
+		{NatMeta: api.NatMeta{InterfaceID: "iface-2"}},
+	}, clientv2.WithIgnoredCodes(343), clientv2.WithConcurrency(4))
+
+	// Draw bulk concurrency from a budget shared with other subsystems.
+	budget := semaphore.NewWeighted(16)
+	_, _ = v2.NATs().CreateMany(ctx, []*api.Nat{}, clientv2.WithSemaphore(budget, 1))
+
+	// NAT audit: every interface with its NAT configuration, or nil.
+	_, _ = v2.NATs().ListByInterface(ctx, clientv2.WithConcurrency(16))
+
+	// IPAM reconciliation: NAT IPs currently allocated.
+	_, _ = v2.NATs().ListAllIPs(ctx)
 
 	var natIP netip.Addr
 	_, _ = v2.NATs().ListAny(ctx, &natIP)
@@ -114,7 +171,6 @@ func ExampleClient_NATs() {
 	_, _ = v2.NATs().ListNeighbors(ctx, &natIP)
 	_, _ = v2.NATs().CreateNeighbor(ctx, &api.NeighborNat{})
 	_, _ = v2.NATs().DeleteNeighbor(ctx, &api.NeighborNat{})
-	// Output:
 }
 
 func ExampleClient_System() {
@@ -127,7 +183,10 @@ func ExampleClient_System() {
 	_, _ = v2.System().GetVni(ctx, 42, 1)
 	_, _ = v2.System().ResetVni(ctx, 42, 1)
 	_, _ = v2.System().GetVersion(ctx, &api.Version{})
-	// Output:
+
+	if caps, err := v2.System().Capabilities(ctx); err == nil && !caps.Supports(clientv2.DomainCapture, "Start") {
+		_ = caps // skip capture-based features on this server
+	}
 }
 
 func ExampleClient_Capture() {
@@ -135,8 +194,68 @@ func ExampleClient_Capture() {
 	ctx := context.TODO()
 
 	v2 := clientv2.NewFromProto(rpc)
-	_, _ = v2.Capture().Start(ctx, &api.CaptureStart{})
+	sink := clientv2.UDPSink(netip.MustParseAddrPort("[fc00:2::64:0:1]:30100")).WithSourcePort(30000)
+	capture, err := clientv2.NewCaptureStart(sink, clientv2.PFCapture(0), clientv2.VFCapture("vm1"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	_, _ = v2.Capture().Start(ctx, capture)
 	_, _ = v2.Capture().Status(ctx)
 	_, _ = v2.Capture().Stop(ctx)
-	// Output:
+}
+
+func ExampleWithCaptureTrailers() {
+	var rpc dpdkproto.DPDKironcoreClient
+	ctx := context.TODO()
+
+	v2 := clientv2.NewFromProto(rpc)
+	var trailer metadata.MD
+	if _, err := v2.Interfaces().Get(ctx, "vm1", clientv2.WithCaptureTrailers(&trailer)); err != nil {
+		log.Printf("get failed: %v (trailers %v)", err, trailer)
+	}
+}
+
+func ExampleNew() {
+	logger := slog.Default()
+
+	v2, err := clientv2.New(
+		clientv2.WithAddress("127.0.0.1:1337", grpc.WithTransportCredentials(insecure.NewCredentials())),
+		clientv2.WithLogger(logger),
+		clientv2.WithRetry(3, clientv2.ExponentialBackoff(100*time.Millisecond, time.Second)),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer v2.Close()
+
+	_, _ = v2.Interfaces().List(context.TODO())
+}
+
+func ExampleNewFromAddress() {
+	ctx := context.TODO()
+
+	v2, err := clientv2.NewFromAddress(ctx, "127.0.0.1:1337", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatal(err)
+	}
+	// Calls racing with Close fail with a *clientv2.ClientClosedError.
+	defer v2.Close()
+
+	_, _ = v2.Interfaces().List(ctx)
+}
+
+func ExampleMigrate() {
+	var primary, standby dpdkproto.DPDKironcoreClient
+	ctx := context.TODO()
+
+	report, err := clientv2.Migrate(ctx, clientv2.NewFromProto(primary), clientv2.NewFromProto(standby), clientv2.MigrateOptions{
+		CallOptions: []clientv2.CallOption{clientv2.WithTimeout(5 * time.Second)},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("copied %d resources", len(report.Copied()))
+	if err := report.Err(); err != nil {
+		log.Print(err)
+	}
 }
@@ -0,0 +1,219 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+)
+
+// ExportOptions configures ExportAll.
+type ExportOptions struct {
+	// CallOptions are applied to every call.
+	CallOptions []CallOption
+	// RouteVNIs lists additional VNIs whose routes are exported. The VNIs of
+	// all interfaces and load balancers are always included.
+	RouteVNIs []uint32
+}
+
+// ExportRecord is a line of the stream written by ExportAll.
+type ExportRecord struct {
+	// Kind is the kind of Object, e.g. api.InterfaceKind.
+	Kind   string          `json:"kind"`
+	Object json.RawMessage `json:"object"`
+}
+
+// firewallRuleJSON is the form of a firewall rule in an ExportRecord. The
+// protocol filter is a protobuf oneof, which encoding/json can neither
+// encode stably nor decode, so it is written with protojson using the field
+// names of dpdk.proto, e.g. {"tcp":{"dst_port_lower":80}}.
+type firewallRuleJSON struct {
+	api.TypeMeta
+	api.FirewallRuleMeta `json:"metadata"`
+	Spec                 firewallRuleSpecJSON `json:"spec"`
+	Status               api.Status           `json:"status"`
+}
+
+type firewallRuleSpecJSON struct {
+	api.FirewallRuleSpec
+	// ProtocolFilter shadows the field of the embedded spec.
+	ProtocolFilter json.RawMessage `json:"protocol_filter,omitempty"`
+}
+
+func toFirewallRuleJSON(rule *api.FirewallRule) (*firewallRuleJSON, error) {
+	out := &firewallRuleJSON{TypeMeta: rule.TypeMeta, FirewallRuleMeta: rule.FirewallRuleMeta, Status: rule.Status}
+	out.Spec.FirewallRuleSpec = rule.Spec
+	out.Spec.FirewallRuleSpec.ProtocolFilter = nil
+	if rule.Spec.ProtocolFilter != nil {
+		raw, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(rule.Spec.ProtocolFilter)
+		if err != nil {
+			return nil, fmt.Errorf("marshal protocol filter of rule %s: %w", rule.Spec.RuleID, err)
+		}
+		out.Spec.ProtocolFilter = raw
+	}
+	return out, nil
+}
+
+// exporter writes ExportRecords to a stream.
+type exporter struct {
+	ctx context.Context
+	enc *json.Encoder
+	w   io.Writer
+}
+
+func (e *exporter) write(kind string, obj any) error {
+	if err := e.ctx.Err(); err != nil {
+		return err
+	}
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		return fmt.Errorf("marshal %s: %w", kind, err)
+	}
+	if err := e.enc.Encode(ExportRecord{Kind: kind, Object: raw}); err != nil {
+		return fmt.Errorf("write %s: %w", kind, err)
+	}
+	if f, ok := e.w.(interface{ Flush() error }); ok {
+		if err := f.Flush(); err != nil {
+			return fmt.Errorf("flush %s: %w", kind, err)
+		}
+	}
+	return nil
+}
+
+// ExportAll writes the resources of c to w as JSON lines, one ExportRecord
+// per resource, in the dependency order used by Migrate: load balancers with
+// their targets, interfaces with their prefixes, loadbalancer prefixes,
+// virtual IP, NAT and firewall rules, then the routes of all involved VNIs.
+//
+// Resources are written as they are read, so only the list being walked is
+// held in memory. If w has a Flush() error method, such as *bufio.Writer, it
+// is called after every record. ExportAll stops at the first error and
+// returns ctx.Err() once ctx is done; the records written until then remain
+// valid JSON lines.
+func ExportAll(ctx context.Context, c Client, w io.Writer, opts ExportOptions) error {
+	o := opts.CallOptions
+	e := &exporter{ctx: ctx, enc: json.NewEncoder(w), w: w}
+	vnis := slices.Clone(opts.RouteVNIs)
+
+	lbs, err := c.LoadBalancers().List(ctx, o...)
+	if err != nil {
+		return fmt.Errorf("list loadbalancers: %w", err)
+	}
+	for _, lb := range lbs.Items {
+		vnis = append(vnis, lb.Spec.VNI)
+		if err := e.write(api.LoadBalancerKind, &lb); err != nil {
+			return err
+		}
+		targets, err := c.LoadBalancers().Targets().List(ctx, lb.ID, o...)
+		if err != nil {
+			return fmt.Errorf("list targets of loadbalancer %s: %w", lb.ID, err)
+		}
+		for _, target := range targets.Items {
+			target.LoadbalancerID = lb.ID
+			if err := e.write(api.LoadBalancerTargetKind, &target); err != nil {
+				return err
+			}
+		}
+	}
+
+	ifaces, err := c.Interfaces().List(ctx, o...)
+	if err != nil {
+		return fmt.Errorf("list interfaces: %w", err)
+	}
+	for _, iface := range ifaces.Items {
+		vnis = append(vnis, iface.Spec.VNI)
+		if err := e.write(api.InterfaceKind, &iface); err != nil {
+			return err
+		}
+		if err := exportInterfaceDependents(ctx, c, e, iface.ID, o); err != nil {
+			return err
+		}
+	}
+
+	slices.Sort(vnis)
+	for _, vni := range slices.Compact(vnis) {
+		routes, err := c.Routes().List(ctx, vni, o...)
+		if err != nil {
+			return fmt.Errorf("list routes of vni %d: %w", vni, err)
+		}
+		for _, route := range routes.Items {
+			route.VNI = vni
+			if err := e.write(api.RouteKind, &route); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func exportInterfaceDependents(ctx context.Context, c Client, e *exporter, ifaceID string, o []CallOption) error {
+	prefixes, err := c.Interfaces().Prefixes().List(ctx, ifaceID, o...)
+	if err != nil {
+		return fmt.Errorf("list prefixes of interface %s: %w", ifaceID, err)
+	}
+	for _, prefix := range prefixes.Items {
+		prefix.InterfaceID = ifaceID
+		if err := e.write(api.PrefixKind, &prefix); err != nil {
+			return err
+		}
+	}
+
+	lbPrefixes, err := c.LoadBalancers().Prefixes().List(ctx, ifaceID, o...)
+	if err != nil {
+		return fmt.Errorf("list loadbalancer prefixes of interface %s: %w", ifaceID, err)
+	}
+	for _, prefix := range lbPrefixes.Items {
+		if err := e.write(api.LoadBalancerPrefixKind, &api.LoadBalancerPrefix{
+			LoadBalancerPrefixMeta: api.LoadBalancerPrefixMeta{InterfaceID: ifaceID},
+			Spec:                   api.LoadBalancerPrefixSpec{Prefix: prefix.Spec.Prefix, UnderlayRoute: prefix.Spec.UnderlayRoute},
+		}); err != nil {
+			return err
+		}
+	}
+
+	vip, err := c.Interfaces().VIP().Get(ctx, ifaceID, o...)
+	switch {
+	case err == nil:
+		vip.InterfaceID = ifaceID
+		if err := e.write(api.VirtualIPKind, vip); err != nil {
+			return err
+		}
+	case !IsNotFound(err):
+		return fmt.Errorf("get virtual ip of interface %s: %w", ifaceID, err)
+	}
+
+	nat, err := c.NATs().Get(ctx, ifaceID, o...)
+	switch {
+	case err == nil:
+		nat.InterfaceID = ifaceID
+		if err := e.write(api.NatKind, nat); err != nil {
+			return err
+		}
+	case !IsNotFound(err):
+		return fmt.Errorf("get nat of interface %s: %w", ifaceID, err)
+	}
+
+	rules, err := c.Firewall().List(ctx, ifaceID, o...)
+	if err != nil {
+		return fmt.Errorf("list firewall rules of interface %s: %w", ifaceID, err)
+	}
+	for _, rule := range rules.Items {
+		rule.InterfaceID = ifaceID
+		rec, err := toFirewallRuleJSON(&rule)
+		if err != nil {
+			return err
+		}
+		if err := e.write(api.FirewallRuleKind, rec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/netip"
+	"slices"
+	"testing"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+	dperrors "github.com/ironcore-dev/dpservice/go/dpservice-go/errors"
+	dpdkproto "github.com/ironcore-dev/dpservice/go/dpservice-go/proto"
+)
+
+// exportSource returns a fake with one load balancer and one interface with
+// dependents.
+func exportSource() *fakeLegacy {
+	target := netip.MustParseAddr("fc00::1")
+	vip := netip.MustParseAddr("45.86.6.6")
+	return &fakeLegacy{
+		listLoadBalancers: func(context.Context) (*api.LoadBalancerList, error) {
+			return &api.LoadBalancerList{Items: []api.LoadBalancer{
+				{LoadBalancerMeta: api.LoadBalancerMeta{ID: "lb1"}, Spec: api.LoadBalancerSpec{VNI: 200}},
+			}}, nil
+		},
+		listLoadBalancerTargets: func(context.Context, string) (*api.LoadBalancerTargetList, error) {
+			return &api.LoadBalancerTargetList{Items: []api.LoadBalancerTarget{{Spec: api.LoadBalancerTargetSpec{TargetIP: &target}}}}, nil
+		},
+		listInterfaces: func(context.Context) (*api.InterfaceList, error) {
+			return &api.InterfaceList{Items: []api.Interface{
+				{InterfaceMeta: api.InterfaceMeta{ID: "vm1"}, Spec: api.InterfaceSpec{VNI: 100}},
+			}}, nil
+		},
+		getVirtualIP: func(_ context.Context, id string) (*api.VirtualIP, error) {
+			return &api.VirtualIP{Spec: api.VirtualIPSpec{IP: &vip}}, nil
+		},
+		getNat: func(context.Context, string) (*api.Nat, error) {
+			return &api.Nat{}, dperrors.NewStatusError(dperrors.SNAT_NO_DATA, "no nat")
+		},
+		listFirewallRules: func(context.Context, string) (*api.FirewallRuleList, error) {
+			return &api.FirewallRuleList{Items: []api.FirewallRule{{Spec: api.FirewallRuleSpec{
+				RuleID:         "r1",
+				ProtocolFilter: &dpdkproto.ProtocolFilter{Filter: &dpdkproto.ProtocolFilter_Tcp{Tcp: &dpdkproto.TcpFilter{DstPortLower: 80, DstPortUpper: 80}}},
+			}}}}, nil
+		},
+		listRoutes: func(_ context.Context, vni uint32) (*api.RouteList, error) {
+			if vni != 100 {
+				return &api.RouteList{}, nil
+			}
+			return &api.RouteList{Items: []api.Route{testRoute("10.0.0.0/24", vni, "fc00::1")}}, nil
+		},
+	}
+}
+
+func TestExportAll(t *testing.T) {
+	var buf bytes.Buffer
+	w := bufio.NewWriterSize(&buf, 1<<16)
+	if err := ExportAll(context.Background(), AsV2(exportSource()), w, ExportOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if w.Buffered() != 0 {
+		t.Fatalf("expected the writer to be flushed")
+	}
+
+	var kinds []string
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var rec ExportRecord
+		if err := dec.Decode(&rec); err != nil {
+			t.Fatalf("decode record: %v", err)
+		}
+		kinds = append(kinds, rec.Kind)
+		if rec.Kind == api.VirtualIPKind {
+			var vip api.VirtualIP
+			if err := json.Unmarshal(rec.Object, &vip); err != nil || vip.InterfaceID != "vm1" {
+				t.Fatalf("expected the virtual ip of vm1, got %+v, %v", vip, err)
+			}
+		}
+		if rec.Kind == api.FirewallRuleKind {
+			var rule struct {
+				Spec struct {
+					ProtocolFilter json.RawMessage `json:"protocol_filter"`
+				} `json:"spec"`
+			}
+			if err := json.Unmarshal(rec.Object, &rule); err != nil {
+				t.Fatalf("decode firewall rule: %v", err)
+			}
+			if want := `{"tcp":{"dst_port_lower":80,"dst_port_upper":80}}`; string(rule.Spec.ProtocolFilter) != want {
+				t.Fatalf("expected protocol filter %s, got %s", want, rule.Spec.ProtocolFilter)
+			}
+		}
+	}
+	want := []string{
+		api.LoadBalancerKind, api.LoadBalancerTargetKind,
+		api.InterfaceKind, api.VirtualIPKind, api.FirewallRuleKind,
+		api.RouteKind,
+	}
+	if !slices.Equal(kinds, want) {
+		t.Fatalf("expected records %v, got %v", want, kinds)
+	}
+}
+
+func TestExportAllCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	src := exportSource()
+	src.listInterfaces = func(context.Context) (*api.InterfaceList, error) {
+		cancel()
+		return &api.InterfaceList{Items: []api.Interface{{InterfaceMeta: api.InterfaceMeta{ID: "vm1"}}}}, nil
+	}
+
+	var buf bytes.Buffer
+	if err := ExportAll(ctx, AsV2(src), &buf, ExportOptions{}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if lines := bytes.Count(buf.Bytes(), []byte("\n")); lines != 2 {
+		t.Fatalf("expected the records written before cancellation, got %d lines", lines)
+	}
+}
@@ -0,0 +1,477 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"net/netip"
+	"sync"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+	legacy "github.com/ironcore-dev/dpservice/go/dpservice-go/client"
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/errors"
+)
+
+var _ legacy.Client = &fakeLegacy{}
+
+// fakeLegacy is a legacy.Client whose methods are backed by optional function
+// fields. Methods without a backing function succeed with an empty result.
+// Every call is recorded by method name.
+type fakeLegacy struct {
+	getLoadBalancer          func(ctx context.Context, id string) (*api.LoadBalancer, error)
+	listLoadBalancers        func(ctx context.Context) (*api.LoadBalancerList, error)
+	createLoadBalancer       func(ctx context.Context, lb *api.LoadBalancer) (*api.LoadBalancer, error)
+	deleteLoadBalancer       func(ctx context.Context, id string) (*api.LoadBalancer, error)
+	listLoadBalancerPrefixes func(ctx context.Context, interfaceID string) (*api.PrefixList, error)
+	createLoadBalancerPrefix func(ctx context.Context, prefix *api.LoadBalancerPrefix) (*api.LoadBalancerPrefix, error)
+	deleteLoadBalancerPrefix func(ctx context.Context, interfaceID string, prefix *netip.Prefix) (*api.LoadBalancerPrefix, error)
+	listLoadBalancerTargets  func(ctx context.Context, loadbalancerID string) (*api.LoadBalancerTargetList, error)
+	createLoadBalancerTarget func(ctx context.Context, lbtarget *api.LoadBalancerTarget) (*api.LoadBalancerTarget, error)
+	deleteLoadBalancerTarget func(ctx context.Context, id string, targetIP *netip.Addr) (*api.LoadBalancerTarget, error)
+	getInterface             func(ctx context.Context, id string) (*api.Interface, error)
+	listInterfaces           func(ctx context.Context) (*api.InterfaceList, error)
+	createInterface          func(ctx context.Context, iface *api.Interface) (*api.Interface, error)
+	deleteInterface          func(ctx context.Context, id string) (*api.Interface, error)
+	getVirtualIP             func(ctx context.Context, interfaceID string) (*api.VirtualIP, error)
+	createVirtualIP          func(ctx context.Context, virtualIP *api.VirtualIP) (*api.VirtualIP, error)
+	deleteVirtualIP          func(ctx context.Context, interfaceID string) (*api.VirtualIP, error)
+	listPrefixes             func(ctx context.Context, interfaceID string) (*api.PrefixList, error)
+	createPrefix             func(ctx context.Context, prefix *api.Prefix) (*api.Prefix, error)
+	deletePrefix             func(ctx context.Context, interfaceID string, prefix *netip.Prefix) (*api.Prefix, error)
+	listRoutes               func(ctx context.Context, vni uint32) (*api.RouteList, error)
+	createRoute              func(ctx context.Context, route *api.Route) (*api.Route, error)
+	deleteRoute              func(ctx context.Context, vni uint32, prefix *netip.Prefix) (*api.Route, error)
+	getNat                   func(ctx context.Context, interfaceID string) (*api.Nat, error)
+	createNat                func(ctx context.Context, nat *api.Nat) (*api.Nat, error)
+	deleteNat                func(ctx context.Context, interfaceID string) (*api.Nat, error)
+	listLocalNats            func(ctx context.Context, natIP *netip.Addr) (*api.NatList, error)
+	createNeighborNat        func(ctx context.Context, nat *api.NeighborNat) (*api.NeighborNat, error)
+	listNats                 func(ctx context.Context, natIP *netip.Addr, natType string) (*api.NatList, error)
+	deleteNeighborNat        func(ctx context.Context, neigbhorNat *api.NeighborNat) (*api.NeighborNat, error)
+	listNeighborNats         func(ctx context.Context, natIP *netip.Addr) (*api.NatList, error)
+	listFirewallRules        func(ctx context.Context, interfaceID string) (*api.FirewallRuleList, error)
+	createFirewallRule       func(ctx context.Context, fwRule *api.FirewallRule) (*api.FirewallRule, error)
+	getFirewallRule          func(ctx context.Context, interfaceID string, ruleID string) (*api.FirewallRule, error)
+	deleteFirewallRule       func(ctx context.Context, interfaceID string, ruleID string) (*api.FirewallRule, error)
+	checkInitialized         func(ctx context.Context) (*api.Initialized, error)
+	initialize               func(ctx context.Context) (*api.Initialized, error)
+	getVni                   func(ctx context.Context, vni uint32, vniType uint8) (*api.Vni, error)
+	resetVni                 func(ctx context.Context, vni uint32, vniType uint8) (*api.Vni, error)
+	getVersion               func(ctx context.Context, version *api.Version) (*api.Version, error)
+	captureStart             func(ctx context.Context, capture *api.CaptureStart) (*api.CaptureStart, error)
+	captureStop              func(ctx context.Context) (*api.CaptureStop, error)
+	captureStatus            func(ctx context.Context) (*api.CaptureStatus, error)
+
+	mu    sync.Mutex
+	calls []string
+}
+
+func (f *fakeLegacy) record(method string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, method)
+}
+
+// Calls returns the names of the legacy methods called so far.
+func (f *fakeLegacy) Calls() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.calls...)
+}
+
+// filterIgnored mimics the legacy client by dropping status errors whose code
+// was requested to be ignored.
+func filterIgnored(err error, ignoredErrors [][]uint32) error {
+	if len(ignoredErrors) == 0 {
+		return err
+	}
+	return errors.IgnoreStatusErrorCode(err, ignoredErrors[0]...)
+}
+
+func (f *fakeLegacy) GetLoadBalancer(ctx context.Context, id string, ignoredErrors ...[]uint32) (*api.LoadBalancer, error) {
+	f.record("GetLoadBalancer")
+	if f.getLoadBalancer == nil {
+		return &api.LoadBalancer{}, nil
+	}
+	res, err := f.getLoadBalancer(ctx, id)
+	return res, filterIgnored(err, ignoredErrors)
+}
+
+func (f *fakeLegacy) ListLoadBalancers(ctx context.Context, ignoredErrors ...[]uint32) (*api.LoadBalancerList, error) {
+	f.record("ListLoadBalancers")
+	if f.listLoadBalancers == nil {
+		return &api.LoadBalancerList{}, nil
+	}
+	res, err := f.listLoadBalancers(ctx)
+	return res, filterIgnored(err, ignoredErrors)
+}
+
+func (f *fakeLegacy) CreateLoadBalancer(ctx context.Context, lb *api.LoadBalancer, ignoredErrors ...[]uint32) (*api.LoadBalancer, error) {
+	f.record("CreateLoadBalancer")
+	if f.createLoadBalancer == nil {
+		return &api.LoadBalancer{}, nil
+	}
+	res, err := f.createLoadBalancer(ctx, lb)
+	return res, filterIgnored(err, ignoredErrors)
+}
+
+func (f *fakeLegacy) DeleteLoadBalancer(ctx context.Context, id string, ignoredErrors ...[]uint32) (*api.LoadBalancer, error) {
+	f.record("DeleteLoadBalancer")
+	if f.deleteLoadBalancer == nil {
+		return &api.LoadBalancer{}, nil
+	}
+	res, err := f.deleteLoadBalancer(ctx, id)
+	return res, filterIgnored(err, ignoredErrors)
+}
+
+func (f *fakeLegacy) ListLoadBalancerPrefixes(ctx context.Context, interfaceID string, ignoredErrors ...[]uint32) (*api.PrefixList, error) {
+	f.record("ListLoadBalancerPrefixes")
+	if f.listLoadBalancerPrefixes == nil {
+		return &api.PrefixList{}, nil
+	}
+	res, err := f.listLoadBalancerPrefixes(ctx, interfaceID)
+	return res, filterIgnored(err, ignoredErrors)
+}
+
+func (f *fakeLegacy) CreateLoadBalancerPrefix(ctx context.Context, prefix *api.LoadBalancerPrefix, ignoredErrors ...[]uint32) (*api.LoadBalancerPrefix, error) {
+	f.record("CreateLoadBalancerPrefix")
+	if f.createLoadBalancerPrefix == nil {
+		return &api.LoadBalancerPrefix{}, nil
+	}
+	res, err := f.createLoadBalancerPrefix(ctx, prefix)
+	return res, filterIgnored(err, ignoredErrors)
+}
+
+func (f *fakeLegacy) DeleteLoadBalancerPrefix(ctx context.Context, interfaceID string, prefix *netip.Prefix, ignoredErrors ...[]uint32) (*api.LoadBalancerPrefix, error) {
+	f.record("DeleteLoadBalancerPrefix")
+	if f.deleteLoadBalancerPrefix == nil {
+		return &api.LoadBalancerPrefix{}, nil
+	}
+	res, err := f.deleteLoadBalancerPrefix(ctx, interfaceID, prefix)
+	return res, filterIgnored(err, ignoredErrors)
+}
+
+func (f *fakeLegacy) ListLoadBalancerTargets(ctx context.Context, loadbalancerID string, ignoredErrors ...[]uint32) (*api.LoadBalancerTargetList, error) {
+	f.record("ListLoadBalancerTargets")
+	if f.listLoadBalancerTargets == nil {
+		return &api.LoadBalancerTargetList{}, nil
+	}
+	res, err := f.listLoadBalancerTargets(ctx, loadbalancerID)
+	return res, filterIgnored(err, ignoredErrors)
+}
+
+func (f *fakeLegacy) CreateLoadBalancerTarget(ctx context.Context, lbtarget *api.LoadBalancerTarget, ignoredErrors ...[]uint32) (*api.LoadBalancerTarget, error) {
+	f.record("CreateLoadBalancerTarget")
+	if f.createLoadBalancerTarget == nil {
+		return &api.LoadBalancerTarget{}, nil
+	}
+	res, err := f.createLoadBalancerTarget(ctx, lbtarget)
+	return res, filterIgnored(err, ignoredErrors)
+}
+
+func (f *fakeLegacy) DeleteLoadBalancerTarget(ctx context.Context, id string, targetIP *netip.Addr, ignoredErrors ...[]uint32) (*api.LoadBalancerTarget, error) {
+	f.record("DeleteLoadBalancerTarget")
+	if f.deleteLoadBalancerTarget == nil {
+		return &api.LoadBalancerTarget{}, nil
+	}
+	res, err := f.deleteLoadBalancerTarget(ctx, id, targetIP)
+	return res, filterIgnored(err, ignoredErrors)
+}
+
+func (f *fakeLegacy) GetInterface(ctx context.Context, id string, ignoredErrors ...[]uint32) (*api.Interface, error) {
+	f.record("GetInterface")
+	if f.getInterface == nil {
+		return &api.Interface{}, nil
+	}
+	res, err := f.getInterface(ctx, id)
+	return res, filterIgnored(err, ignoredErrors)
+}
+
+func (f *fakeLegacy) ListInterfaces(ctx context.Context, ignoredErrors ...[]uint32) (*api.InterfaceList, error) {
+	f.record("ListInterfaces")
+	if f.listInterfaces == nil {
+		return &api.InterfaceList{}, nil
+	}
+	res, err := f.listInterfaces(ctx)
+	return res, filterIgnored(err, ignoredErrors)
+}
+
+func (f *fakeLegacy) CreateInterface(ctx context.Context, iface *api.Interface, ignoredErrors ...[]uint32) (*api.Interface, error) {
+	f.record("CreateInterface")
+	if f.createInterface == nil {
+		return &api.Interface{}, nil
+	}
+	res, err := f.createInterface(ctx, iface)
+	return res, filterIgnored(err, ignoredErrors)
+}
+
+func (f *fakeLegacy) DeleteInterface(ctx context.Context, id string, ignoredErrors ...[]uint32) (*api.Interface, error) {
+	f.record("DeleteInterface")
+	if f.deleteInterface == nil {
+		return &api.Interface{}, nil
+	}
+	res, err := f.deleteInterface(ctx, id)
+	return res, filterIgnored(err, ignoredErrors)
+}
+
+func (f *fakeLegacy) GetVirtualIP(ctx context.Context, interfaceID string, ignoredErrors ...[]uint32) (*api.VirtualIP, error) {
+	f.record("GetVirtualIP")
+	if f.getVirtualIP == nil {
+		return &api.VirtualIP{}, nil
+	}
+	res, err := f.getVirtualIP(ctx, interfaceID)
+	return res, filterIgnored(err, ignoredErrors)
+}
+
+func (f *fakeLegacy) CreateVirtualIP(ctx context.Context, virtualIP *api.VirtualIP, ignoredErrors ...[]uint32) (*api.VirtualIP, error) {
+	f.record("CreateVirtualIP")
+	if f.createVirtualIP == nil {
+		return &api.VirtualIP{}, nil
+	}
+	res, err := f.createVirtualIP(ctx, virtualIP)
+	return res, filterIgnored(err, ignoredErrors)
+}
+
+func (f *fakeLegacy) DeleteVirtualIP(ctx context.Context, interfaceID string, ignoredErrors ...[]uint32) (*api.VirtualIP, error) {
+	f.record("DeleteVirtualIP")
+	if f.deleteVirtualIP == nil {
+		return &api.VirtualIP{}, nil
+	}
+	res, err := f.deleteVirtualIP(ctx, interfaceID)
+	return res, filterIgnored(err, ignoredErrors)
+}
+
+func (f *fakeLegacy) ListPrefixes(ctx context.Context, interfaceID string, ignoredErrors ...[]uint32) (*api.PrefixList, error) {
+	f.record("ListPrefixes")
+	if f.listPrefixes == nil {
+		return &api.PrefixList{}, nil
+	}
+	res, err := f.listPrefixes(ctx, interfaceID)
+	return res, filterIgnored(err, ignoredErrors)
+}
+
+func (f *fakeLegacy) CreatePrefix(ctx context.Context, prefix *api.Prefix, ignoredErrors ...[]uint32) (*api.Prefix, error) {
+	f.record("CreatePrefix")
+	if f.createPrefix == nil {
+		return &api.Prefix{}, nil
+	}
+	res, err := f.createPrefix(ctx, prefix)
+	return res, filterIgnored(err, ignoredErrors)
+}
+
+func (f *fakeLegacy) DeletePrefix(ctx context.Context, interfaceID string, prefix *netip.Prefix, ignoredErrors ...[]uint32) (*api.Prefix, error) {
+	f.record("DeletePrefix")
+	if f.deletePrefix == nil {
+		return &api.Prefix{}, nil
+	}
+	res, err := f.deletePrefix(ctx, interfaceID, prefix)
+	return res, filterIgnored(err, ignoredErrors)
+}
+
+func (f *fakeLegacy) ListRoutes(ctx context.Context, vni uint32, ignoredErrors ...[]uint32) (*api.RouteList, error) {
+	f.record("ListRoutes")
+	if f.listRoutes == nil {
+		return &api.RouteList{}, nil
+	}
+	res, err := f.listRoutes(ctx, vni)
+	return res, filterIgnored(err, ignoredErrors)
+}
+
+func (f *fakeLegacy) CreateRoute(ctx context.Context, route *api.Route, ignoredErrors ...[]uint32) (*api.Route, error) {
+	f.record("CreateRoute")
+	if f.createRoute == nil {
+		return &api.Route{}, nil
+	}
+	res, err := f.createRoute(ctx, route)
+	return res, filterIgnored(err, ignoredErrors)
+}
+
+func (f *fakeLegacy) DeleteRoute(ctx context.Context, vni uint32, prefix *netip.Prefix, ignoredErrors ...[]uint32) (*api.Route, error) {
+	f.record("DeleteRoute")
+	if f.deleteRoute == nil {
+		return &api.Route{}, nil
+	}
+	res, err := f.deleteRoute(ctx, vni, prefix)
+	return res, filterIgnored(err, ignoredErrors)
+}
+
+func (f *fakeLegacy) GetNat(ctx context.Context, interfaceID string, ignoredErrors ...[]uint32) (*api.Nat, error) {
+	f.record("GetNat")
+	if f.getNat == nil {
+		return &api.Nat{}, nil
+	}
+	res, err := f.getNat(ctx, interfaceID)
+	return res, filterIgnored(err, ignoredErrors)
+}
+
+func (f *fakeLegacy) CreateNat(ctx context.Context, nat *api.Nat, ignoredErrors ...[]uint32) (*api.Nat, error) {
+	f.record("CreateNat")
+	if f.createNat == nil {
+		return &api.Nat{}, nil
+	}
+	res, err := f.createNat(ctx, nat)
+	return res, filterIgnored(err, ignoredErrors)
+}
+
+func (f *fakeLegacy) DeleteNat(ctx context.Context, interfaceID string, ignoredErrors ...[]uint32) (*api.Nat, error) {
+	f.record("DeleteNat")
+	if f.deleteNat == nil {
+		return &api.Nat{}, nil
+	}
+	res, err := f.deleteNat(ctx, interfaceID)
+	return res, filterIgnored(err, ignoredErrors)
+}
+
+func (f *fakeLegacy) ListLocalNats(ctx context.Context, natIP *netip.Addr, ignoredErrors ...[]uint32) (*api.NatList, error) {
+	f.record("ListLocalNats")
+	if f.listLocalNats == nil {
+		return &api.NatList{}, nil
+	}
+	res, err := f.listLocalNats(ctx, natIP)
+	return res, filterIgnored(err, ignoredErrors)
+}
+
+func (f *fakeLegacy) CreateNeighborNat(ctx context.Context, nat *api.NeighborNat, ignoredErrors ...[]uint32) (*api.NeighborNat, error) {
+	f.record("CreateNeighborNat")
+	if f.createNeighborNat == nil {
+		return &api.NeighborNat{}, nil
+	}
+	res, err := f.createNeighborNat(ctx, nat)
+	return res, filterIgnored(err, ignoredErrors)
+}
+
+func (f *fakeLegacy) ListNats(ctx context.Context, natIP *netip.Addr, natType string, ignoredErrors ...[]uint32) (*api.NatList, error) {
+	f.record("ListNats")
+	if f.listNats == nil {
+		return &api.NatList{}, nil
+	}
+	res, err := f.listNats(ctx, natIP, natType)
+	return res, filterIgnored(err, ignoredErrors)
+}
+
+func (f *fakeLegacy) DeleteNeighborNat(ctx context.Context, neigbhorNat *api.NeighborNat, ignoredErrors ...[]uint32) (*api.NeighborNat, error) {
+	f.record("DeleteNeighborNat")
+	if f.deleteNeighborNat == nil {
+		return &api.NeighborNat{}, nil
+	}
+	res, err := f.deleteNeighborNat(ctx, neigbhorNat)
+	return res, filterIgnored(err, ignoredErrors)
+}
+
+func (f *fakeLegacy) ListNeighborNats(ctx context.Context, natIP *netip.Addr, ignoredErrors ...[]uint32) (*api.NatList, error) {
+	f.record("ListNeighborNats")
+	if f.listNeighborNats == nil {
+		return &api.NatList{}, nil
+	}
+	res, err := f.listNeighborNats(ctx, natIP)
+	return res, filterIgnored(err, ignoredErrors)
+}
+
+func (f *fakeLegacy) ListFirewallRules(ctx context.Context, interfaceID string, ignoredErrors ...[]uint32) (*api.FirewallRuleList, error) {
+	f.record("ListFirewallRules")
+	if f.listFirewallRules == nil {
+		return &api.FirewallRuleList{}, nil
+	}
+	res, err := f.listFirewallRules(ctx, interfaceID)
+	return res, filterIgnored(err, ignoredErrors)
+}
+
+func (f *fakeLegacy) CreateFirewallRule(ctx context.Context, fwRule *api.FirewallRule, ignoredErrors ...[]uint32) (*api.FirewallRule, error) {
+	f.record("CreateFirewallRule")
+	if f.createFirewallRule == nil {
+		return &api.FirewallRule{}, nil
+	}
+	res, err := f.createFirewallRule(ctx, fwRule)
+	return res, filterIgnored(err, ignoredErrors)
+}
+
+func (f *fakeLegacy) GetFirewallRule(ctx context.Context, interfaceID string, ruleID string, ignoredErrors ...[]uint32) (*api.FirewallRule, error) {
+	f.record("GetFirewallRule")
+	if f.getFirewallRule == nil {
+		return &api.FirewallRule{}, nil
+	}
+	res, err := f.getFirewallRule(ctx, interfaceID, ruleID)
+	return res, filterIgnored(err, ignoredErrors)
+}
+
+func (f *fakeLegacy) DeleteFirewallRule(ctx context.Context, interfaceID string, ruleID string, ignoredErrors ...[]uint32) (*api.FirewallRule, error) {
+	f.record("DeleteFirewallRule")
+	if f.deleteFirewallRule == nil {
+		return &api.FirewallRule{}, nil
+	}
+	res, err := f.deleteFirewallRule(ctx, interfaceID, ruleID)
+	return res, filterIgnored(err, ignoredErrors)
+}
+
+func (f *fakeLegacy) CheckInitialized(ctx context.Context, ignoredErrors ...[]uint32) (*api.Initialized, error) {
+	f.record("CheckInitialized")
+	if f.checkInitialized == nil {
+		return &api.Initialized{}, nil
+	}
+	res, err := f.checkInitialized(ctx)
+	return res, filterIgnored(err, ignoredErrors)
+}
+
+func (f *fakeLegacy) Initialize(ctx context.Context, ignoredErrors ...[]uint32) (*api.Initialized, error) {
+	f.record("Initialize")
+	if f.initialize == nil {
+		return &api.Initialized{}, nil
+	}
+	res, err := f.initialize(ctx)
+	return res, filterIgnored(err, ignoredErrors)
+}
+
+func (f *fakeLegacy) GetVni(ctx context.Context, vni uint32, vniType uint8, ignoredErrors ...[]uint32) (*api.Vni, error) {
+	f.record("GetVni")
+	if f.getVni == nil {
+		return &api.Vni{}, nil
+	}
+	res, err := f.getVni(ctx, vni, vniType)
+	return res, filterIgnored(err, ignoredErrors)
+}
+
+func (f *fakeLegacy) ResetVni(ctx context.Context, vni uint32, vniType uint8, ignoredErrors ...[]uint32) (*api.Vni, error) {
+	f.record("ResetVni")
+	if f.resetVni == nil {
+		return &api.Vni{}, nil
+	}
+	res, err := f.resetVni(ctx, vni, vniType)
+	return res, filterIgnored(err, ignoredErrors)
+}
+
+func (f *fakeLegacy) GetVersion(ctx context.Context, version *api.Version, ignoredErrors ...[]uint32) (*api.Version, error) {
+	f.record("GetVersion")
+	if f.getVersion == nil {
+		return &api.Version{}, nil
+	}
+	res, err := f.getVersion(ctx, version)
+	return res, filterIgnored(err, ignoredErrors)
+}
+
+func (f *fakeLegacy) CaptureStart(ctx context.Context, capture *api.CaptureStart, ignoredErrors ...[]uint32) (*api.CaptureStart, error) {
+	f.record("CaptureStart")
+	if f.captureStart == nil {
+		return &api.CaptureStart{}, nil
+	}
+	res, err := f.captureStart(ctx, capture)
+	return res, filterIgnored(err, ignoredErrors)
+}
+
+func (f *fakeLegacy) CaptureStop(ctx context.Context, ignoredErrors ...[]uint32) (*api.CaptureStop, error) {
+	f.record("CaptureStop")
+	if f.captureStop == nil {
+		return &api.CaptureStop{}, nil
+	}
+	res, err := f.captureStop(ctx)
+	return res, filterIgnored(err, ignoredErrors)
+}
+
+func (f *fakeLegacy) CaptureStatus(ctx context.Context, ignoredErrors ...[]uint32) (*api.CaptureStatus, error) {
+	f.record("CaptureStatus")
+	if f.captureStatus == nil {
+		return &api.CaptureStatus{}, nil
+	}
+	res, err := f.captureStatus(ctx)
+	return res, filterIgnored(err, ignoredErrors)
+}
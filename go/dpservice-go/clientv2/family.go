@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"net/netip"
+	"slices"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+)
+
+// AddressFamily selects IPv4 or IPv6 entries in WithAddressFamily.
+type AddressFamily int
+
+const (
+	// AddressFamilyAny keeps entries of both families.
+	AddressFamilyAny AddressFamily = iota
+	AddressFamilyIPv4
+	AddressFamilyIPv6
+)
+
+// Matches reports whether addr belongs to the family. IPv4-mapped IPv6
+// addresses count as IPv4. AddressFamilyAny matches every valid address.
+func (f AddressFamily) Matches(addr netip.Addr) bool {
+	if !addr.IsValid() {
+		return false
+	}
+	switch f {
+	case AddressFamilyIPv4:
+		return addr.Unmap().Is4()
+	case AddressFamilyIPv6:
+		return !addr.Unmap().Is4()
+	}
+	return true
+}
+
+// WithAddressFamily makes List methods of routes, prefixes, NATs and
+// interfaces return only entries of the given family: routes and prefixes by
+// their prefix, NATs by their NAT IP and interfaces that have a primary
+// address of the family. dpservice cannot filter by family, so the full list
+// is transferred and filtered client-side, unless WithRequireServerSide
+// rejects the call.
+func WithAddressFamily(family AddressFamily) CallOption {
+	return func(o *callOptions) {
+		o.family = family
+	}
+}
+
+// filtersFamily reports whether filterFamily filters values of the type of v,
+// which may be a nil pointer.
+func filtersFamily(v any) bool {
+	switch v.(type) {
+	case *api.RouteList, *api.PrefixList, *api.NatList, *api.InterfaceList:
+		return true
+	}
+	return false
+}
+
+// filterFamily removes the items of a list that do not belong to family.
+// Values of other types are left unchanged.
+func filterFamily(v any, family AddressFamily) {
+	switch l := v.(type) {
+	case *api.RouteList:
+		l.Items = slices.DeleteFunc(l.Items, func(r api.Route) bool {
+			return r.Spec.Prefix == nil || !family.Matches(r.Spec.Prefix.Addr())
+		})
+	case *api.PrefixList:
+		l.Items = slices.DeleteFunc(l.Items, func(p api.Prefix) bool {
+			return !family.Matches(p.Spec.Prefix.Addr())
+		})
+	case *api.NatList:
+		l.Items = slices.DeleteFunc(l.Items, func(n api.Nat) bool {
+			return n.Spec.NatIP == nil || !family.Matches(*n.Spec.NatIP)
+		})
+	case *api.InterfaceList:
+		l.Items = slices.DeleteFunc(l.Items, func(i api.Interface) bool {
+			return !(i.Spec.IPv4 != nil && family.Matches(*i.Spec.IPv4)) &&
+				!(i.Spec.IPv6 != nil && family.Matches(*i.Spec.IPv6))
+		})
+	}
+}
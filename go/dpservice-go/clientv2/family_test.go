@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+)
+
+func TestAddressFamilyMatches(t *testing.T) {
+	tests := []struct {
+		family AddressFamily
+		addr   string
+		want   bool
+	}{
+		{AddressFamilyIPv4, "10.0.0.1", true},
+		{AddressFamilyIPv4, "::ffff:10.0.0.1", true},
+		{AddressFamilyIPv4, "fc00::1", false},
+		{AddressFamilyIPv6, "fc00::1", true},
+		{AddressFamilyIPv6, "10.0.0.1", false},
+		{AddressFamilyAny, "10.0.0.1", true},
+		{AddressFamilyAny, "fc00::1", true},
+	}
+	for _, tt := range tests {
+		if got := tt.family.Matches(netip.MustParseAddr(tt.addr)); got != tt.want {
+			t.Errorf("expected %v for family %d and %s, got %v", tt.want, tt.family, tt.addr, got)
+		}
+	}
+	if AddressFamilyAny.Matches(netip.Addr{}) {
+		t.Error("expected the zero address to match no family")
+	}
+}
+
+func TestWithAddressFamily(t *testing.T) {
+	fake := &fakeLegacy{
+		listRoutes: func(context.Context, uint32) (*api.RouteList, error) {
+			return &api.RouteList{Items: []api.Route{
+				testRoute("10.0.0.0/24", 1, "fc00::1"),
+				testRoute("fd00::/64", 1, "fc00::1"),
+			}}, nil
+		},
+		listInterfaces: func(context.Context) (*api.InterfaceList, error) {
+			v4, v6 := netip.MustParseAddr("10.0.0.1"), netip.MustParseAddr("fd00::1")
+			return &api.InterfaceList{Items: []api.Interface{
+				{InterfaceMeta: api.InterfaceMeta{ID: "dual"}, Spec: api.InterfaceSpec{IPv4: &v4, IPv6: &v6}},
+				{InterfaceMeta: api.InterfaceMeta{ID: "v6"}, Spec: api.InterfaceSpec{IPv6: &v6}},
+			}}, nil
+		},
+	}
+	c := AsV2(fake)
+
+	routes, err := c.Routes().List(context.Background(), 1, WithAddressFamily(AddressFamilyIPv6))
+	if err != nil || len(routes.Items) != 1 || routes.Items[0].Spec.Prefix.String() != "fd00::/64" {
+		t.Fatalf("expected only the IPv6 route, got %v, %v", routes, err)
+	}
+
+	ifaces, err := c.Interfaces().List(context.Background(), WithAddressFamily(AddressFamilyIPv4))
+	if err != nil || len(ifaces.Items) != 1 || ifaces.Items[0].ID != "dual" {
+		t.Fatalf("expected only the dual-stack interface, got %v, %v", ifaces, err)
+	}
+
+	ifaces, err = c.Interfaces().List(context.Background())
+	if err != nil || len(ifaces.Items) != 2 {
+		t.Fatalf("expected no filtering by default, got %v, %v", ifaces, err)
+	}
+}
@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package faultinject provides a clientv2.Transport that injects latency,
+// errors and corrupted results, for chaos and performance tests of code
+// using a clientv2.Client without a real server.
+//
+//	injector := &faultinject.FaultInjector{
+//		Latency:   50 * time.Millisecond,
+//		ErrorRate: 0.1,
+//	}
+//	v2 := clientv2.AsV2(legacyClient, clientv2.WithTransport(injector.Transport()))
+package faultinject
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	clientv2 "github.com/ironcore-dev/dpservice/go/dpservice-go/clientv2"
+)
+
+// FaultInjector configures the faults of its Transport. The zero value
+// passes calls through unchanged. A FaultInjector must not be modified while
+// its Transport is in use.
+type FaultInjector struct {
+	// Match selects the calls to inject faults into. Nil matches all calls.
+	Match func(domain, method string) bool
+	// Latency delays every matched call before it is sent. The delay ends
+	// early if the context of the call is done.
+	Latency time.Duration
+	// ErrorRate is the probability in [0, 1] of failing a matched call with
+	// Err instead of sending it.
+	ErrorRate float64
+	// Err is the injected error. It defaults to a gRPC Unavailable status.
+	Err error
+	// Corrupt, if set, is called with the result of every successful matched
+	// call and may modify it in place.
+	Corrupt func(domain, method string, res any)
+	// Rand is the source for ErrorRate. It defaults to a randomly seeded
+	// source; set it for reproducible tests.
+	Rand *rand.Rand
+
+	mu sync.Mutex
+}
+
+// Transport returns the clientv2.Transport injecting the configured faults.
+func (f *FaultInjector) Transport() clientv2.Transport {
+	return func(ctx context.Context, domain, method string, next clientv2.Invoker) (any, error) {
+		if f.Match != nil && !f.Match(domain, method) {
+			return next(ctx)
+		}
+
+		if f.Latency > 0 {
+			timer := time.NewTimer(f.Latency)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, ctx.Err()
+			case <-timer.C:
+			}
+		}
+
+		if f.ErrorRate > 0 && f.float64() < f.ErrorRate {
+			if f.Err != nil {
+				return nil, f.Err
+			}
+			return nil, status.Errorf(codes.Unavailable, "faultinject: %s.%s dropped", domain, method)
+		}
+
+		res, err := next(ctx)
+		if err == nil && f.Corrupt != nil {
+			f.Corrupt(domain, method, res)
+		}
+		return res, err
+	}
+}
+
+func (f *FaultInjector) float64() float64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Rand == nil {
+		f.Rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return f.Rand.Float64()
+}
@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package faultinject_test
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+	legacy "github.com/ironcore-dev/dpservice/go/dpservice-go/client"
+	clientv2 "github.com/ironcore-dev/dpservice/go/dpservice-go/clientv2"
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/clientv2/faultinject"
+)
+
+// versionClient is a legacy client that only answers GetVersion.
+type versionClient struct {
+	legacy.Client
+	calls int
+}
+
+func (c *versionClient) GetVersion(_ context.Context, v *api.Version, _ ...[]uint32) (*api.Version, error) {
+	c.calls++
+	return &api.Version{Spec: api.VersionSpec{ServiceVersion: "1.0"}}, nil
+}
+
+func TestFaultInjector(t *testing.T) {
+	server := &versionClient{}
+	injector := &faultinject.FaultInjector{
+		Latency:   20 * time.Millisecond,
+		ErrorRate: 0.5,
+		Rand:      rand.New(rand.NewSource(1)),
+		Corrupt: func(_, _ string, res any) {
+			res.(*api.Version).Spec.ServiceVersion = "corrupted"
+		},
+	}
+	c := clientv2.AsV2(server, clientv2.WithTransport(injector.Transport()))
+
+	start := time.Now()
+	var failed, corrupted int
+	for i := 0; i < 20; i++ {
+		v, err := c.System().GetVersion(context.Background(), &api.Version{})
+		switch {
+		case status.Code(err) == codes.Unavailable:
+			failed++
+		case err == nil && v.Spec.ServiceVersion == "corrupted":
+			corrupted++
+		default:
+			t.Fatalf("unexpected result %+v, %v", v, err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 20*20*time.Millisecond {
+		t.Fatalf("expected every call to be delayed, took %v", elapsed)
+	}
+	if failed == 0 || corrupted == 0 || server.calls != corrupted {
+		t.Fatalf("expected a mix of dropped and corrupted calls, got %d dropped, %d corrupted, %d sent", failed, corrupted, server.calls)
+	}
+}
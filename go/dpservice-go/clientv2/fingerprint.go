@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/netip"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+)
+
+// Fingerprint returns a content hash of a resource for change detection: the
+// hex SHA-256 of the JSON encoding of its ToCreateRequest form, so status and
+// server-assigned fields do not contribute. Semantically equal resources
+// hash equally: the kind is derived from the type, the ports of a load
+// balancer are sorted and the direction and action of a firewall rule are
+// reduced to the spellings the server returns, e.g. "allow" to "Accept".
+// Optional fields that are set to their zero value, such as an empty
+// MeteringParams or an unspecified address, hash like unset ones, and the
+// protocol filter of a firewall rule is encoded with protojson as in
+// ExportAll.
+func Fingerprint(obj any) (string, error) {
+	req, err := ToCreateRequest(obj)
+	if err != nil {
+		return "", err
+	}
+	// ToCreateRequest returns a fresh copy that may be normalized in place.
+	switch r := req.(type) {
+	case *api.Interface:
+		r.Spec.IPv4, r.Spec.IPv6 = optionalAddr(r.Spec.IPv4), optionalAddr(r.Spec.IPv6)
+		if r.Spec.PXE != nil && *r.Spec.PXE == (api.PXE{}) {
+			r.Spec.PXE = nil
+		}
+		if r.Spec.Metering != nil && *r.Spec.Metering == (api.MeteringParams{}) {
+			r.Spec.Metering = nil
+		}
+	case *api.LoadBalancer:
+		r.Spec.LbVipIP = optionalAddr(r.Spec.LbVipIP)
+		slices.SortFunc(r.Spec.Lbports, func(a, b api.LBPort) int {
+			if c := cmp.Compare(a.Protocol, b.Protocol); c != 0 {
+				return c
+			}
+			return cmp.Compare(a.Port, b.Port)
+		})
+	case *api.FirewallRule:
+		r.Spec.TrafficDirection = canonicalSpelling(r.Spec.TrafficDirection, map[string]string{
+			"ingress": "Ingress", "0": "Ingress", "egress": "Egress", "1": "Egress",
+		})
+		r.Spec.FirewallAction = canonicalSpelling(r.Spec.FirewallAction, map[string]string{
+			"accept": "Accept", "allow": "Accept", "1": "Accept", "drop": "Drop", "deny": "Drop", "0": "Drop",
+		})
+		if r.Spec.ProtocolFilter != nil && r.Spec.ProtocolFilter.Filter == nil {
+			r.Spec.ProtocolFilter = nil
+		}
+	}
+	reflect.ValueOf(req).Elem().FieldByName("TypeMeta").Set(reflect.ValueOf(api.TypeMeta{
+		Kind: reflect.TypeOf(req).Elem().Name(),
+	}))
+	if r, ok := req.(*api.FirewallRule); ok {
+		// json.Marshal compacts the protojson output of the filter, whose
+		// whitespace is not stable.
+		if req, err = toFirewallRuleJSON(r); err != nil {
+			return "", err
+		}
+	}
+
+	b, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// optionalAddr returns nil for a nil, invalid or unspecified address, which
+// the server reports for an address that was not set.
+func optionalAddr(addr *netip.Addr) *netip.Addr {
+	if addr == nil || !addr.IsValid() || addr.IsUnspecified() {
+		return nil
+	}
+	return addr
+}
+
+// canonicalSpelling returns the canonical form of a case-insensitive value,
+// or value itself if it is unknown.
+func canonicalSpelling(value string, canonical map[string]string) string {
+	if c, ok := canonical[strings.ToLower(value)]; ok {
+		return c
+	}
+	return value
+}
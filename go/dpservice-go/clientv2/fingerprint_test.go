@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+	dpdkproto "github.com/ironcore-dev/dpservice/go/dpservice-go/proto"
+)
+
+func TestFingerprint(t *testing.T) {
+	mustFingerprint := func(obj any) string {
+		t.Helper()
+		fp, err := Fingerprint(obj)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return fp
+	}
+
+	ipv4 := netip.MustParseAddr("10.0.0.1")
+	underlay := netip.MustParseAddr("fc00::1")
+	fetched := api.Interface{
+		TypeMeta:      api.TypeMeta{Kind: api.InterfaceKind},
+		InterfaceMeta: api.InterfaceMeta{ID: "vm1"},
+		Spec:          api.InterfaceSpec{VNI: 100, IPv4: &ipv4, UnderlayRoute: &underlay},
+		Status:        api.Status{Code: 0, Message: "ok"},
+	}
+	desired := &api.Interface{InterfaceMeta: api.InterfaceMeta{ID: "vm1"}, Spec: api.InterfaceSpec{VNI: 100, IPv4: &ipv4}}
+	if mustFingerprint(fetched) != mustFingerprint(desired) {
+		t.Fatalf("expected volatile fields not to change the fingerprint")
+	}
+	desired.Spec.VNI = 200
+	if mustFingerprint(fetched) == mustFingerprint(desired) {
+		t.Fatalf("expected a different VNI to change the fingerprint")
+	}
+
+	lb := func(ports ...api.LBPort) *api.LoadBalancer {
+		return &api.LoadBalancer{LoadBalancerMeta: api.LoadBalancerMeta{ID: "lb1"}, Spec: api.LoadBalancerSpec{Lbports: ports}}
+	}
+	http, https := api.LBPort{Protocol: 6, Port: 80}, api.LBPort{Protocol: 6, Port: 443}
+	original := lb(https, http)
+	if mustFingerprint(original) != mustFingerprint(lb(http, https)) {
+		t.Fatalf("expected the port order not to change the fingerprint")
+	}
+	if original.Spec.Lbports[0] != https {
+		t.Fatalf("expected the ports of the argument to be left unsorted")
+	}
+
+	prefix := netip.MustParsePrefix("10.0.0.0/24")
+	rule := func(direction, action string) *api.FirewallRule {
+		return &api.FirewallRule{Spec: api.FirewallRuleSpec{
+			RuleID: "r1", TrafficDirection: direction, FirewallAction: action, SourcePrefix: &prefix,
+			ProtocolFilter: &dpdkproto.ProtocolFilter{Filter: &dpdkproto.ProtocolFilter_Tcp{Tcp: &dpdkproto.TcpFilter{DstPortLower: 80}}},
+		}}
+	}
+	if mustFingerprint(rule("ingress", "allow")) != mustFingerprint(rule("Ingress", "Accept")) {
+		t.Fatalf("expected equivalent spellings to fingerprint equally")
+	}
+	if mustFingerprint(rule("Ingress", "Accept")) == mustFingerprint(rule("Ingress", "Drop")) {
+		t.Fatalf("expected a different action to change the fingerprint")
+	}
+
+	unset := &api.Interface{InterfaceMeta: api.InterfaceMeta{ID: "vm1"}, Spec: api.InterfaceSpec{VNI: 100, IPv4: &ipv4}}
+	zero := *unset
+	unspecified := netip.IPv6Unspecified()
+	zero.Spec.IPv6, zero.Spec.Metering, zero.Spec.PXE = &unspecified, &api.MeteringParams{}, &api.PXE{}
+	if mustFingerprint(unset) != mustFingerprint(&zero) {
+		t.Fatalf("expected zero optional fields to fingerprint like unset ones")
+	}
+	zero.Spec.Metering = &api.MeteringParams{TotalRate: 100}
+	if mustFingerprint(unset) == mustFingerprint(&zero) {
+		t.Fatalf("expected a metering rate to change the fingerprint")
+	}
+
+	tcp := rule("Ingress", "Accept")
+	if mustFingerprint(tcp) != mustFingerprint(rule("Ingress", "Accept")) {
+		t.Fatalf("expected equal tcp filters to fingerprint equally")
+	}
+	other := rule("Ingress", "Accept")
+	other.Spec.ProtocolFilter.GetTcp().DstPortLower = 443
+	if mustFingerprint(tcp) == mustFingerprint(other) {
+		t.Fatalf("expected a different tcp port to change the fingerprint")
+	}
+	other.Spec.ProtocolFilter = &dpdkproto.ProtocolFilter{Filter: &dpdkproto.ProtocolFilter_Udp{Udp: &dpdkproto.UdpFilter{DstPortLower: 80}}}
+	if mustFingerprint(tcp) == mustFingerprint(other) {
+		t.Fatalf("expected a udp filter to fingerprint differently from a tcp one")
+	}
+	other.Spec.ProtocolFilter = &dpdkproto.ProtocolFilter{}
+	unfiltered := rule("Ingress", "Accept")
+	unfiltered.Spec.ProtocolFilter = nil
+	if mustFingerprint(unfiltered) != mustFingerprint(other) {
+		t.Fatalf("expected an empty filter to fingerprint like no filter")
+	}
+
+	if _, err := Fingerprint(&api.Version{}); err == nil {
+		t.Fatalf("expected an error for a type without Create")
+	}
+}
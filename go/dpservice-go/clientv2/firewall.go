@@ -0,0 +1,292 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"slices"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+	dpdkproto "github.com/ironcore-dev/dpservice/go/dpservice-go/proto"
+)
+
+// WithSortByPriority makes Firewall().List return the rules sorted by the
+// priority field: ascending priority value, with the rule ID breaking ties.
+// This is not the order in which dpservice evaluates them: the priority is
+// documented as having no effect yet (see dpdk.proto), and the server tries
+// rules in the order they were created.
+func WithSortByPriority() CallOption {
+	return func(o *callOptions) {
+		o.sortByPriority = true
+	}
+}
+
+// SortFirewallRules sorts rules by the priority field, see WithSortByPriority.
+func SortFirewallRules(rules []api.FirewallRule) {
+	slices.SortStableFunc(rules, func(a, b api.FirewallRule) int {
+		if c := cmp.Compare(a.Spec.Priority, b.Spec.Priority); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Spec.RuleID, b.Spec.RuleID)
+	})
+}
+
+func (c *fwClient) Duplicates(ctx context.Context, interfaceID string, opts ...CallOption) ([]*api.FirewallRule, error) {
+	if err := checkID(DomainFirewall, "Duplicates", "interfaceID", interfaceID); err != nil {
+		return nil, err
+	}
+	rules, err := c.listInServerOrder(ctx, interfaceID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var kept, duplicates []*api.FirewallRule
+	for i := range rules.Items {
+		rule := &rules.Items[i]
+		if slices.ContainsFunc(kept, func(k *api.FirewallRule) bool { return sameFirewallMatch(&k.Spec, &rule.Spec) }) {
+			duplicates = append(duplicates, rule)
+		} else {
+			kept = append(kept, rule)
+		}
+	}
+	return duplicates, nil
+}
+
+func (c *fwClient) Deduplicate(ctx context.Context, interfaceID string, opts ...CallOption) ([]*api.FirewallRule, error) {
+	duplicates, err := c.Duplicates(ctx, interfaceID, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []*api.FirewallRule
+	var errs []error
+	for _, rule := range duplicates {
+		if _, err := c.Delete(ctx, interfaceID, rule.Spec.RuleID, opts...); err != nil && !IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("delete firewall rule %s: %w", rule.Spec.RuleID, err))
+			continue
+		}
+		removed = append(removed, rule)
+	}
+	return removed, errors.Join(errs...)
+}
+
+func (c *fwClient) FindShadowed(ctx context.Context, interfaceID string, opts ...CallOption) ([]*api.FirewallRule, error) {
+	if err := checkID(DomainFirewall, "FindShadowed", "interfaceID", interfaceID); err != nil {
+		return nil, err
+	}
+	rules, err := c.listInServerOrder(ctx, interfaceID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var shadowed []*api.FirewallRule
+	for i := range rules.Items {
+		rule := &rules.Items[i]
+		for j := 0; j < i; j++ {
+			if firewallMatchCovers(&rules.Items[j].Spec, &rule.Spec) {
+				shadowed = append(shadowed, rule)
+				break
+			}
+		}
+	}
+	return shadowed, nil
+}
+
+// listInServerOrder lists the rules of an interface in the order they were
+// created, which is the order dpservice tries them in, even if opts contain
+// WithSortByPriority.
+func (c *fwClient) listInServerOrder(ctx context.Context, interfaceID string, opts []CallOption) (*api.FirewallRuleList, error) {
+	return c.List(ctx, interfaceID, append(slices.Clone(opts), func(o *callOptions) { o.sortByPriority = false })...)
+}
+
+// firewallMatchCovers reports whether rule a matches all traffic that rule b
+// matches, regardless of their actions. A nil prefix or protocol filter
+// matches anything.
+func firewallMatchCovers(a, b *api.FirewallRuleSpec) bool {
+	return a.TrafficDirection == b.TrafficDirection &&
+		prefixCovers(a.SourcePrefix, b.SourcePrefix) &&
+		prefixCovers(a.DestinationPrefix, b.DestinationPrefix) &&
+		protocolCovers(a.ProtocolFilter, b.ProtocolFilter)
+}
+
+func prefixCovers(a, b *netip.Prefix) bool {
+	switch {
+	case a == nil:
+		return true
+	case b == nil:
+		return false
+	}
+	return a.Addr().Is4() == b.Addr().Is4() && a.Bits() <= b.Bits() && a.Contains(b.Addr())
+}
+
+func protocolCovers(a, b *dpdkproto.ProtocolFilter) bool {
+	switch {
+	case a == nil || a.Filter == nil:
+		return true
+	case b == nil || b.Filter == nil:
+		return false
+	}
+	switch af := a.Filter.(type) {
+	case *dpdkproto.ProtocolFilter_Icmp:
+		bf, ok := b.Filter.(*dpdkproto.ProtocolFilter_Icmp)
+		if !ok {
+			return false
+		}
+		switch {
+		case af.Icmp == nil:
+			return true
+		case bf.Icmp == nil:
+			return false
+		}
+		return icmpValueCovers(af.Icmp.IcmpType, bf.Icmp.IcmpType) && icmpValueCovers(af.Icmp.IcmpCode, bf.Icmp.IcmpCode)
+	case *dpdkproto.ProtocolFilter_Tcp:
+		bf, ok := b.Filter.(*dpdkproto.ProtocolFilter_Tcp)
+		if !ok {
+			return false
+		}
+		switch {
+		case af.Tcp == nil:
+			return true
+		case bf.Tcp == nil:
+			return false
+		}
+		return portRangeCovers(af.Tcp.SrcPortLower, af.Tcp.SrcPortUpper, bf.Tcp.SrcPortLower, bf.Tcp.SrcPortUpper) &&
+			portRangeCovers(af.Tcp.DstPortLower, af.Tcp.DstPortUpper, bf.Tcp.DstPortLower, bf.Tcp.DstPortUpper)
+	case *dpdkproto.ProtocolFilter_Udp:
+		bf, ok := b.Filter.(*dpdkproto.ProtocolFilter_Udp)
+		if !ok {
+			return false
+		}
+		switch {
+		case af.Udp == nil:
+			return true
+		case bf.Udp == nil:
+			return false
+		}
+		return portRangeCovers(af.Udp.SrcPortLower, af.Udp.SrcPortUpper, bf.Udp.SrcPortLower, bf.Udp.SrcPortUpper) &&
+			portRangeCovers(af.Udp.DstPortLower, af.Udp.DstPortUpper, bf.Udp.DstPortLower, bf.Udp.DstPortUpper)
+	}
+	return false
+}
+
+// icmpValueCovers compares ICMP types or codes, where -1 matches all.
+func icmpValueCovers(a, b int32) bool {
+	return a == -1 || a == b
+}
+
+// portRangeCovers compares port ranges, where a lower bound of -1 matches
+// all ports and an upper bound below the lower one matches the lower port.
+func portRangeCovers(aLower, aUpper, bLower, bUpper int32) bool {
+	if aLower == -1 {
+		return true
+	}
+	if bLower == -1 {
+		return false
+	}
+	return aLower <= bLower && max(bLower, bUpper) <= max(aLower, aUpper)
+}
+
+// WithRollbackOnError makes Firewall().CreateSet delete the rules it created
+// when any rule of the set fails, so that the interface is left with either
+// the whole set or none of it. The rollback is not atomic: the created rules
+// are in effect until they are deleted, and rules whose deletion fails stay.
+func WithRollbackOnError() CallOption {
+	return func(o *callOptions) {
+		o.rollbackOnError = true
+	}
+}
+
+func (c *fwClient) CreateSet(ctx context.Context, interfaceID string, rules []*api.FirewallRule, opts ...CallOption) (*api.FirewallRuleList, *BulkError) {
+	o := c.callOptions(opts)
+	ctx, cancel := o.bulkContext(ctx)
+	defer cancel()
+
+	list := &api.FirewallRuleList{TypeMeta: api.TypeMeta{Kind: api.FirewallRuleListKind}, Items: make([]api.FirewallRule, 0, len(rules))}
+	if err := checkID(DomainFirewall, "CreateSet", "interfaceID", interfaceID); err != nil {
+		return list, failAll(len(rules), err)
+	}
+	if err := contextDone(ctx, DomainFirewall+".CreateSet"); err != nil {
+		return list, failAll(len(rules), err)
+	}
+
+	itemOpts := bulkItemOptions(opts)
+	created := make([]*api.FirewallRule, len(rules))
+	bulkErr := runBulk(ctx, len(rules), o, func(ctx context.Context, i int) error {
+		if rules[i] == nil {
+			return &InvalidArgumentError{Domain: DomainFirewall, Method: "CreateSet", Argument: "rules", Reason: fmt.Sprintf("rule %d is nil", i)}
+		}
+		rule := *rules[i]
+		rule.InterfaceID = interfaceID
+		res, err := c.Create(ctx, &rule, itemOpts...)
+		if err != nil {
+			return err
+		}
+		created[i] = res
+		return nil
+	})
+
+	if bulkErr != nil && o.rollbackOnError {
+		bulkErr = c.rollbackSet(ctx, interfaceID, rules, created, bulkErr, itemOpts)
+	}
+	for _, rule := range created {
+		if rule != nil {
+			list.Items = append(list.Items, *rule)
+		}
+	}
+	return list, bulkErr
+}
+
+// rollbackSet deletes the created rules of a failed CreateSet, clearing them
+// from created. Failed deletions are added to bulkErr at the index of the
+// rule. The rollback outlives the deadline of the batch, which may be what
+// failed it.
+func (c *fwClient) rollbackSet(ctx context.Context, interfaceID string, rules, created []*api.FirewallRule, bulkErr *BulkError, opts []CallOption) *BulkError {
+	ctx = context.WithoutCancel(ctx)
+	for i, rule := range created {
+		if rule == nil {
+			continue
+		}
+		ruleID := rule.Spec.RuleID
+		if ruleID == "" {
+			ruleID = rules[i].Spec.RuleID
+		}
+		if _, err := c.Delete(ctx, interfaceID, ruleID, opts...); err != nil && !IsNotFound(err) {
+			bulkErr.Items = append(bulkErr.Items, BulkItemError{Index: i, Err: fmt.Errorf("roll back firewall rule %s: %w", ruleID, err)})
+			continue
+		}
+		created[i] = nil
+	}
+	slices.SortStableFunc(bulkErr.Items, func(a, b BulkItemError) int { return cmp.Compare(a.Index, b.Index) })
+	return bulkErr
+}
+
+// sameFirewallMatch reports whether two rules match the same traffic with
+// the same action, regardless of their ID and priority.
+func sameFirewallMatch(a, b *api.FirewallRuleSpec) bool {
+	return a.TrafficDirection == b.TrafficDirection &&
+		a.FirewallAction == b.FirewallAction &&
+		samePrefix(a.SourcePrefix, b.SourcePrefix) &&
+		samePrefix(a.DestinationPrefix, b.DestinationPrefix) &&
+		proto.Equal(a.ProtocolFilter, b.ProtocolFilter)
+}
+
+func samePrefix(a, b *netip.Prefix) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func (c *fwClient) WaitFor(ctx context.Context, interfaceID, ruleID string, pred func(*api.FirewallRule) bool, poll time.Duration, opts ...CallOption) (*api.FirewallRule, error) {
+	return waitFor(ctx, poll, "firewall rule "+ruleID+" of interface "+interfaceID, func(ctx context.Context) (*api.FirewallRule, error) {
+		return c.Get(ctx, interfaceID, ruleID, opts...)
+	}, pred)
+}
@@ -0,0 +1,260 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+	dpdkproto "github.com/ironcore-dev/dpservice/go/dpservice-go/proto"
+)
+
+func TestFirewallListSortByPriority(t *testing.T) {
+	rule := func(id string, priority uint32) api.FirewallRule {
+		return api.FirewallRule{Spec: api.FirewallRuleSpec{RuleID: id, Priority: priority}}
+	}
+	fake := &fakeLegacy{
+		listFirewallRules: func(context.Context, string) (*api.FirewallRuleList, error) {
+			return &api.FirewallRuleList{Items: []api.FirewallRule{
+				rule("c", 1000), rule("b", 100), rule("a", 1000), rule("d", 10),
+			}}, nil
+		},
+	}
+	fw := AsV2(fake).Firewall()
+
+	rules, err := fw.List(context.Background(), "vm1")
+	if err != nil || rules.Items[0].Spec.RuleID != "c" {
+		t.Fatalf("expected server order without the option, got %+v, %v", rules, err)
+	}
+
+	rules, err = fw.List(context.Background(), "vm1", WithSortByPriority())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var ids string
+	for _, r := range rules.Items {
+		ids += r.Spec.RuleID
+	}
+	if ids != "dbac" {
+		t.Fatalf("expected evaluation order dbac, got %s", ids)
+	}
+}
+
+func TestFirewallDeduplicate(t *testing.T) {
+	src := netip.MustParsePrefix("10.0.0.0/24")
+	tcp := func(port int32) *dpdkproto.ProtocolFilter {
+		return &dpdkproto.ProtocolFilter{Filter: &dpdkproto.ProtocolFilter_Tcp{Tcp: &dpdkproto.TcpFilter{DstPortLower: port, DstPortUpper: port}}}
+	}
+	rule := func(id string, priority uint32, filter *dpdkproto.ProtocolFilter) api.FirewallRule {
+		return api.FirewallRule{Spec: api.FirewallRuleSpec{
+			RuleID: id, Priority: priority, TrafficDirection: "Ingress", FirewallAction: "Accept",
+			SourcePrefix: &src, ProtocolFilter: filter,
+		}}
+	}
+	fake := &fakeLegacy{
+		listFirewallRules: func(context.Context, string) (*api.FirewallRuleList, error) {
+			return &api.FirewallRuleList{Items: []api.FirewallRule{
+				rule("http-b", 1000, tcp(80)),
+				rule("http-a", 100, tcp(80)),
+				rule("https", 1000, tcp(443)),
+				rule("http-c", 1000, tcp(80)),
+			}}, nil
+		},
+	}
+	fw := AsV2(fake).Firewall()
+
+	duplicates, err := fw.Duplicates(context.Background(), "vm1", WithSortByPriority())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(duplicates) != 2 || duplicates[0].Spec.RuleID != "http-a" || duplicates[1].Spec.RuleID != "http-c" {
+		t.Fatalf("expected http-a and http-c to be duplicates of the earlier http-b, got %+v", duplicates)
+	}
+	if slices.Contains(fake.Calls(), "DeleteFirewallRule") {
+		t.Fatalf("expected Duplicates not to delete anything")
+	}
+
+	var deleted []string
+	fake.deleteFirewallRule = func(_ context.Context, _ string, ruleID string) (*api.FirewallRule, error) {
+		deleted = append(deleted, ruleID)
+		return &api.FirewallRule{}, nil
+	}
+	removed, err := fw.Deduplicate(context.Background(), "vm1")
+	if err != nil || len(removed) != 2 || !slices.Equal(deleted, []string{"http-a", "http-c"}) {
+		t.Fatalf("expected http-a and http-c to be deleted, got %v, %v", deleted, err)
+	}
+}
+
+func TestValidateFirewallRule(t *testing.T) {
+	v4 := netip.MustParsePrefix("10.0.0.0/24")
+	v6 := netip.MustParsePrefix("fd00::/64")
+	valid := func() *api.FirewallRule {
+		return &api.FirewallRule{Spec: api.FirewallRuleSpec{
+			RuleID: "rule1", TrafficDirection: "Ingress", FirewallAction: "Accept", Priority: 1000,
+			SourcePrefix: &v4, DestinationPrefix: &v4,
+			ProtocolFilter: &dpdkproto.ProtocolFilter{Filter: &dpdkproto.ProtocolFilter_Tcp{Tcp: &dpdkproto.TcpFilter{
+				SrcPortLower: -1, DstPortLower: 80, DstPortUpper: 443,
+			}}},
+		}}
+	}
+	if err := ValidateFirewallRule(valid()); err != nil {
+		t.Fatalf("expected valid rule, got %v", err)
+	}
+
+	rule := valid()
+	rule.Spec.RuleID = ""
+	rule.Spec.TrafficDirection = "sideways"
+	rule.Spec.Priority = 70000
+	rule.Spec.DestinationPrefix = &v6
+	rule.Spec.ProtocolFilter = &dpdkproto.ProtocolFilter{Filter: &dpdkproto.ProtocolFilter_Udp{Udp: &dpdkproto.UdpFilter{
+		SrcPortLower: -1, SrcPortUpper: 53, DstPortLower: 443, DstPortUpper: 80,
+	}}}
+	err := ValidateFirewallRule(rule)
+	var ruleErr *FirewallRuleError
+	if !errors.As(err, &ruleErr) || len(ruleErr.Problems) != 6 {
+		t.Fatalf("expected six problems, got %v", err)
+	}
+
+	rule = valid()
+	rule.Spec.ProtocolFilter = &dpdkproto.ProtocolFilter{Filter: &dpdkproto.ProtocolFilter_Icmp{Icmp: &dpdkproto.IcmpFilter{IcmpType: 256, IcmpCode: -1}}}
+	if err := ValidateFirewallRule(rule); !errors.As(err, &ruleErr) || len(ruleErr.Problems) != 1 {
+		t.Fatalf("expected the ICMP type to be rejected, got %v", err)
+	}
+}
+
+func TestFirewallCreateWithValidate(t *testing.T) {
+	fake := &fakeLegacy{}
+	fw := AsV2(fake).Firewall()
+	rule := &api.FirewallRule{Spec: api.FirewallRuleSpec{RuleID: "rule1"}}
+
+	var ruleErr *FirewallRuleError
+	if _, err := fw.Create(context.Background(), rule, WithValidate()); !errors.As(err, &ruleErr) {
+		t.Fatalf("expected FirewallRuleError, got %v", err)
+	}
+	if len(fake.Calls()) != 0 {
+		t.Fatalf("expected no RPC, got %v", fake.Calls())
+	}
+	if _, err := fw.Create(context.Background(), rule); err != nil {
+		t.Fatalf("expected the rule to be sent without the option, got %v", err)
+	}
+}
+
+func TestFirewallFindShadowed(t *testing.T) {
+	prefix := func(s string) *netip.Prefix {
+		p := netip.MustParsePrefix(s)
+		return &p
+	}
+	tcp := func(lower, upper int32) *dpdkproto.ProtocolFilter {
+		return &dpdkproto.ProtocolFilter{Filter: &dpdkproto.ProtocolFilter_Tcp{Tcp: &dpdkproto.TcpFilter{SrcPortLower: -1, DstPortLower: lower, DstPortUpper: upper}}}
+	}
+	udp := &dpdkproto.ProtocolFilter{Filter: &dpdkproto.ProtocolFilter_Udp{Udp: &dpdkproto.UdpFilter{SrcPortLower: -1, DstPortLower: 53, DstPortUpper: 53}}}
+	icmp := func(typ, code int32) *dpdkproto.ProtocolFilter {
+		return &dpdkproto.ProtocolFilter{Filter: &dpdkproto.ProtocolFilter_Icmp{Icmp: &dpdkproto.IcmpFilter{IcmpType: typ, IcmpCode: code}}}
+	}
+	rule := func(id string, priority uint32, direction string, src *netip.Prefix, filter *dpdkproto.ProtocolFilter) api.FirewallRule {
+		return api.FirewallRule{Spec: api.FirewallRuleSpec{
+			RuleID: id, Priority: priority, TrafficDirection: direction, FirewallAction: "Accept",
+			SourcePrefix: src, ProtocolFilter: filter,
+		}}
+	}
+	fake := &fakeLegacy{
+		listFirewallRules: func(context.Context, string) (*api.FirewallRuleList, error) {
+			return &api.FirewallRuleList{Items: []api.FirewallRule{
+				rule("ssh-office", 100, "Ingress", prefix("10.0.0.0/24"), tcp(22, 22)),
+				rule("ssh-all", 10, "Ingress", nil, tcp(22, 22)),
+				rule("ssh-same-priority", 10, "Ingress", prefix("10.0.0.0/24"), tcp(22, 22)),
+				rule("web", 100, "Ingress", prefix("10.0.0.0/8"), tcp(80, 443)),
+				rule("https", 200, "Ingress", prefix("10.1.0.0/16"), tcp(443, 443)),
+				rule("https-public", 200, "Ingress", prefix("0.0.0.0/0"), tcp(443, 443)),
+				rule("dns", 200, "Ingress", prefix("10.1.0.0/16"), udp),
+				rule("ssh-egress", 300, "Egress", nil, tcp(22, 22)),
+				rule("tcp-lan", 20, "Ingress", prefix("192.168.0.0/16"), tcp(-1, 0)),
+				rule("proxy-lan", 400, "Ingress", prefix("192.168.1.0/24"), tcp(8080, 8080)),
+				rule("ssh-v6", 500, "Ingress", prefix("::/0"), tcp(22, 22)),
+				rule("icmp-all", 30, "Ingress", nil, icmp(-1, -1)),
+				rule("icmp-echo", 40, "Ingress", nil, icmp(8, 0)),
+			}}, nil
+		},
+	}
+
+	// Rules are compared in server order: ssh-office is created before the
+	// broader ssh-all and is not shadowed despite its higher priority value.
+	shadowed, err := AsV2(fake).Firewall().FindShadowed(context.Background(), "vm1", WithSortByPriority())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var ids []string
+	for _, rule := range shadowed {
+		ids = append(ids, rule.Spec.RuleID)
+	}
+	if want := []string{"ssh-same-priority", "https", "proxy-lan", "ssh-v6", "icmp-echo"}; !slices.Equal(ids, want) {
+		t.Fatalf("expected shadowed rules %v, got %v", want, ids)
+	}
+}
+
+func TestFirewallCreateSet(t *testing.T) {
+	var mu sync.Mutex
+	var deleted []string
+	fake := &fakeLegacy{
+		createFirewallRule: func(_ context.Context, rule *api.FirewallRule) (*api.FirewallRule, error) {
+			if rule.InterfaceID != "vm1" {
+				t.Errorf("expected interface vm1, got %q", rule.InterfaceID)
+			}
+			if rule.Spec.RuleID == "bad" {
+				return &api.FirewallRule{}, errors.New("rejected")
+			}
+			return rule, nil
+		},
+		deleteFirewallRule: func(_ context.Context, _ string, ruleID string) (*api.FirewallRule, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			deleted = append(deleted, ruleID)
+			return &api.FirewallRule{}, nil
+		},
+	}
+	fw := AsV2(fake).Firewall()
+	rule := func(id string) *api.FirewallRule {
+		return &api.FirewallRule{Spec: api.FirewallRuleSpec{RuleID: id}}
+	}
+
+	list, bulkErr := fw.CreateSet(context.Background(), "vm1", []*api.FirewallRule{rule("a"), rule("b")})
+	if bulkErr != nil || len(list.Items) != 2 {
+		t.Fatalf("expected both rules, got %v, %v", list, bulkErr)
+	}
+
+	rules := []*api.FirewallRule{rule("a"), rule("bad"), rule("c"), nil}
+	list, bulkErr = fw.CreateSet(context.Background(), "vm1", rules)
+	if bulkErr == nil || !slices.Equal(bulkErr.Failed(), []int{1, 3}) || len(list.Items) != 2 {
+		t.Fatalf("expected a and c with failures at 1 and 3, got %v, %v", list, bulkErr)
+	}
+	if len(deleted) != 0 {
+		t.Fatalf("expected no rollback without the option, got %v", deleted)
+	}
+	if rules[0].InterfaceID != "" {
+		t.Fatalf("expected the input rules to stay unmodified")
+	}
+
+	list, bulkErr = fw.CreateSet(context.Background(), "vm1", rules, WithRollbackOnError())
+	if bulkErr == nil || len(list.Items) != 0 {
+		t.Fatalf("expected everything to be rolled back, got %v, %v", list, bulkErr)
+	}
+	slices.Sort(deleted)
+	if !slices.Equal(deleted, []string{"a", "c"}) {
+		t.Fatalf("expected a and c to be rolled back, got %v", deleted)
+	}
+
+	deleted = nil
+	fake.deleteFirewallRule = func(context.Context, string, string) (*api.FirewallRule, error) {
+		return &api.FirewallRule{}, errors.New("unavailable")
+	}
+	list, bulkErr = fw.CreateSet(context.Background(), "vm1", rules[:3], WithRollbackOnError())
+	if len(list.Items) != 2 || !slices.Equal(bulkErr.Failed(), []int{0, 1, 2}) {
+		t.Fatalf("expected failed rollbacks to be reported, got %v, %v", list, bulkErr)
+	}
+}
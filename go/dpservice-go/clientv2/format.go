@@ -0,0 +1,185 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+	dpdkproto "github.com/ironcore-dev/dpservice/go/dpservice-go/proto"
+)
+
+// Redactor masks sensitive values in the output of Format. It is called with
+// the name of every field, e.g. "id", "ipv4" or "underlay", and its value and
+// returns the value to print.
+type Redactor func(field, value string) string
+
+var redactor atomic.Pointer[Redactor]
+
+// RegisterRedactor makes all subsequent Format calls pass their fields
+// through r. A nil r removes the registered redactor. It is safe to call
+// concurrently with Format.
+func RegisterRedactor(r Redactor) {
+	if r == nil {
+		redactor.Store(nil)
+		return
+	}
+	redactor.Store(&r)
+}
+
+// Format returns a stable one-line summary of a resource, for example
+// "Interface iface-1 vni=42 ipv4=10.0.0.1", with fields in a fixed order and
+// empty fields omitted. Resources may be passed by value or pointer. Values
+// of other types are formatted with %v.
+func Format(obj any) string {
+	ptr, ok := resourcePointer(obj)
+	if !ok {
+		return "<nil>"
+	}
+	f := &formatter{}
+	if r := redactor.Load(); r != nil {
+		f.redact = *r
+	}
+
+	switch r := ptr.(type) {
+	case *api.Interface:
+		f.kind("Interface", r.ID)
+		f.uint("vni", r.Spec.VNI)
+		f.addr("ipv4", r.Spec.IPv4)
+		f.addr("ipv6", r.Spec.IPv6)
+		f.addr("underlay", r.Spec.UnderlayRoute)
+		if r.Spec.VirtualFunction != nil {
+			f.field("vf", r.Spec.VirtualFunction.Name)
+		}
+		f.field("hostname", r.Spec.HostName)
+		f.status(r.Status)
+	case *api.LoadBalancer:
+		f.kind("LoadBalancer", r.ID)
+		f.uint("vni", r.Spec.VNI)
+		f.addr("vip", r.Spec.LbVipIP)
+		ports := make([]string, 0, len(r.Spec.Lbports))
+		for _, port := range r.Spec.Lbports {
+			ports = append(ports, fmt.Sprintf("%s/%d", strings.ToLower(dpdkproto.Protocol(port.Protocol).String()), port.Port))
+		}
+		f.field("ports", strings.Join(ports, ","))
+		f.addr("underlay", r.Spec.UnderlayRoute)
+		f.status(r.Status)
+	case *api.LoadBalancerTarget:
+		f.kind("LoadBalancerTarget", r.LoadbalancerID)
+		f.addr("target", r.Spec.TargetIP)
+		f.status(r.Status)
+	case *api.LoadBalancerPrefix:
+		f.kind("LoadBalancerPrefix", r.InterfaceID)
+		f.prefix("prefix", &r.Spec.Prefix)
+		f.addr("underlay", r.Spec.UnderlayRoute)
+		f.status(r.Status)
+	case *api.Prefix:
+		f.kind("Prefix", r.InterfaceID)
+		f.prefix("prefix", &r.Spec.Prefix)
+		f.uint("vni", r.Vni)
+		f.addr("underlay", r.Spec.UnderlayRoute)
+		f.status(r.Status)
+	case *api.VirtualIP:
+		f.kind("VirtualIP", r.InterfaceID)
+		f.addr("ip", r.Spec.IP)
+		f.addr("underlay", r.Spec.UnderlayRoute)
+		f.status(r.Status)
+	case *api.Nat:
+		f.kind("Nat", r.InterfaceID)
+		f.addr("ip", r.Spec.NatIP)
+		f.ports(r.Spec.MinPort, r.Spec.MaxPort)
+		f.uint("vni", r.Spec.Vni)
+		f.addr("underlay", r.Spec.UnderlayRoute)
+		f.status(r.Status)
+	case *api.NeighborNat:
+		f.kind("NeighborNat", "")
+		f.addr("ip", r.NatIP)
+		f.ports(r.Spec.MinPort, r.Spec.MaxPort)
+		f.uint("vni", r.Spec.Vni)
+		f.addr("underlay", r.Spec.UnderlayRoute)
+		f.status(r.Status)
+	case *api.Route:
+		f.kind("Route", "")
+		f.uint("vni", r.VNI)
+		f.prefix("prefix", r.Spec.Prefix)
+		if r.Spec.NextHop != nil {
+			f.uint("nexthop_vni", r.Spec.NextHop.VNI)
+			f.addr("nexthop", r.Spec.NextHop.IP)
+		}
+		f.status(r.Status)
+	case *api.FirewallRule:
+		f.kind("FirewallRule", r.InterfaceID)
+		f.field("rule", r.Spec.RuleID)
+		f.field("direction", r.Spec.TrafficDirection)
+		f.field("action", r.Spec.FirewallAction)
+		f.field("priority", strconv.FormatUint(uint64(r.Spec.Priority), 10))
+		f.prefix("src", r.Spec.SourcePrefix)
+		f.prefix("dst", r.Spec.DestinationPrefix)
+		f.status(r.Status)
+	default:
+		return fmt.Sprintf("%v", obj)
+	}
+	return f.b.String()
+}
+
+type formatter struct {
+	b      strings.Builder
+	redact Redactor
+}
+
+func (f *formatter) kind(kind, id string) {
+	f.b.WriteString(kind)
+	if id != "" {
+		f.b.WriteByte(' ')
+		f.b.WriteString(f.value("id", id))
+	}
+}
+
+func (f *formatter) value(name, value string) string {
+	if f.redact != nil {
+		return f.redact(name, value)
+	}
+	return value
+}
+
+func (f *formatter) field(name, value string) {
+	if value == "" {
+		return
+	}
+	fmt.Fprintf(&f.b, " %s=%s", name, f.value(name, value))
+}
+
+func (f *formatter) uint(name string, v uint32) {
+	if v != 0 {
+		f.field(name, strconv.FormatUint(uint64(v), 10))
+	}
+}
+
+func (f *formatter) addr(name string, addr *netip.Addr) {
+	if addr != nil && addr.IsValid() {
+		f.field(name, addr.String())
+	}
+}
+
+func (f *formatter) prefix(name string, prefix *netip.Prefix) {
+	if prefix != nil && prefix.IsValid() {
+		f.field(name, prefix.String())
+	}
+}
+
+func (f *formatter) ports(lo, hi uint32) {
+	if lo != 0 || hi != 0 {
+		f.field("ports", fmt.Sprintf("%d-%d", lo, hi))
+	}
+}
+
+func (f *formatter) status(status api.Status) {
+	if status.Code != 0 {
+		f.field("status", strconv.FormatUint(uint64(status.Code), 10))
+	}
+}
@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+)
+
+func TestFormat(t *testing.T) {
+	ipv4 := netip.MustParseAddr("10.0.0.1")
+	underlay := netip.MustParseAddr("fc00::1")
+	prefix := netip.MustParsePrefix("10.0.1.0/24")
+	nextHop := netip.MustParseAddr("fc00::2")
+
+	tests := []struct {
+		obj  any
+		want string
+	}{
+		{
+			&api.Interface{InterfaceMeta: api.InterfaceMeta{ID: "iface-1"}, Spec: api.InterfaceSpec{VNI: 42, IPv4: &ipv4, UnderlayRoute: &underlay}},
+			"Interface iface-1 vni=42 ipv4=10.0.0.1 underlay=fc00::1",
+		},
+		{
+			api.LoadBalancer{LoadBalancerMeta: api.LoadBalancerMeta{ID: "lb-1"}, Spec: api.LoadBalancerSpec{VNI: 7, LbVipIP: &ipv4, Lbports: []api.LBPort{{Protocol: 6, Port: 443}, {Protocol: 17, Port: 53}}}},
+			"LoadBalancer lb-1 vni=7 vip=10.0.0.1 ports=tcp/443,udp/53",
+		},
+		{
+			&api.Route{RouteMeta: api.RouteMeta{VNI: 42}, Spec: api.RouteSpec{Prefix: &prefix, NextHop: &api.RouteNextHop{VNI: 43, IP: &nextHop}}},
+			"Route vni=42 prefix=10.0.1.0/24 nexthop_vni=43 nexthop=fc00::2",
+		},
+		{
+			&api.Nat{NatMeta: api.NatMeta{InterfaceID: "iface-1"}, Spec: api.NatSpec{NatIP: &ipv4, MinPort: 1000, MaxPort: 2000}, Status: api.Status{Code: 343}},
+			"Nat iface-1 ip=10.0.0.1 ports=1000-2000 status=343",
+		},
+		{
+			&api.FirewallRule{FirewallRuleMeta: api.FirewallRuleMeta{InterfaceID: "iface-1"}, Spec: api.FirewallRuleSpec{RuleID: "r1", TrafficDirection: "Ingress", FirewallAction: "Accept", Priority: 100, SourcePrefix: &prefix}},
+			"FirewallRule iface-1 rule=r1 direction=Ingress action=Accept priority=100 src=10.0.1.0/24",
+		},
+		{(*api.Interface)(nil), "<nil>"},
+		{42, "42"},
+	}
+	for _, tt := range tests {
+		if got := Format(tt.obj); got != tt.want {
+			t.Errorf("expected %q, got %q", tt.want, got)
+		}
+	}
+}
+
+func TestFormatRedactor(t *testing.T) {
+	RegisterRedactor(func(field, value string) string {
+		if strings.HasPrefix(field, "ip") {
+			return "***"
+		}
+		return value
+	})
+	defer RegisterRedactor(nil)
+
+	ipv4 := netip.MustParseAddr("10.0.0.1")
+	iface := &api.Interface{InterfaceMeta: api.InterfaceMeta{ID: "iface-1"}, Spec: api.InterfaceSpec{VNI: 42, IPv4: &ipv4}}
+	if got, want := Format(iface), "Interface iface-1 vni=42 ipv4=***"; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+
+	RegisterRedactor(nil)
+	if got := Format(iface); !strings.Contains(got, "10.0.0.1") {
+		t.Fatalf("expected the IP after removing the redactor, got %q", got)
+	}
+}
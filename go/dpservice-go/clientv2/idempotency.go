@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+)
+
+// IdempotencyKeyMetadata is the gRPC metadata key carrying the key of
+// WithIdempotencyKey.
+const IdempotencyKeyMetadata = "x-dpservice-idempotency-key"
+
+// WithIdempotencyKey sends key as IdempotencyKeyMetadata with the call and
+// makes retries of Create methods safe: if a retry fails because the resource
+// already exists, the earlier attempt is assumed to have created it and the
+// existing resource is returned instead of the error.
+//
+// dpservice does not evaluate the key yet, so the client cannot tell its own
+// resource from one created by someone else in the meantime. Until the
+// server supports it, the option is a best-effort client-side guarantee and
+// only has an effect together with WithRetry.
+func WithIdempotencyKey(key string) CallOption {
+	return func(o *callOptions) {
+		o.idempotencyKey = key
+	}
+}
+
+// withExisting registers the lookup of the resource a Create call makes, used
+// to resolve an already-exists answer to a retry under WithIdempotencyKey.
+func withExisting[T any](opts []CallOption, get func(ctx context.Context) (T, error)) []CallOption {
+	return append(opts[:len(opts):len(opts)], func(o *callOptions) {
+		o.existing = func(ctx context.Context) (any, error) {
+			return get(ctx)
+		}
+	})
+}
+
+// recoverExisting returns the resource created by an earlier attempt of a
+// retried Create call, or false if it cannot be resolved.
+func recoverExisting[T any](ctx context.Context, o callOptions, retries int, err error) (T, bool) {
+	var zero T
+	if retries == 0 || o.idempotencyKey == "" || o.existing == nil || !IsAlreadyExists(err) {
+		return zero, false
+	}
+	existing, err := o.existing(ctx)
+	if err != nil {
+		return zero, false
+	}
+	if obj, ok := existing.(api.Object); ok && obj.GetStatus().Code != 0 {
+		return zero, false
+	}
+	res, ok := existing.(T)
+	return res, ok
+}
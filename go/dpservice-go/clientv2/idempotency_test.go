@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+	dperrors "github.com/ironcore-dev/dpservice/go/dpservice-go/errors"
+)
+
+func TestWithIdempotencyKey(t *testing.T) {
+	var keys []string
+	attempts := 0
+	fake := &fakeLegacy{
+		createInterface: func(ctx context.Context, iface *api.Interface) (*api.Interface, error) {
+			md, _ := metadata.FromOutgoingContext(ctx)
+			keys = append(keys, md.Get(IdempotencyKeyMetadata)...)
+			attempts++
+			if attempts%2 == 1 {
+				// The first attempt is applied but its answer is lost.
+				return &api.Interface{}, status.Error(codes.Unavailable, "connection reset")
+			}
+			return &api.Interface{}, dperrors.NewStatusError(dperrors.ALREADY_EXISTS, "exists")
+		},
+		getInterface: func(_ context.Context, id string) (*api.Interface, error) {
+			return &api.Interface{InterfaceMeta: api.InterfaceMeta{ID: id}, Spec: api.InterfaceSpec{VNI: 42}}, nil
+		},
+	}
+	ifaces := AsV2(fake).Interfaces()
+	iface := &api.Interface{InterfaceMeta: api.InterfaceMeta{ID: "vm1"}}
+
+	res, err := ifaces.Create(context.Background(), iface, WithRetry(3, nil), WithIdempotencyKey("op-1"))
+	if err != nil || res.ID != "vm1" || res.Spec.VNI != 42 {
+		t.Fatalf("expected the existing interface, got %v, %v", res, err)
+	}
+	if !slices.Equal(keys, []string{"op-1", "op-1"}) {
+		t.Fatalf("expected the key on every attempt, got %v", keys)
+	}
+
+	_, err = ifaces.Create(context.Background(), iface, WithRetry(3, nil))
+	if !IsAlreadyExists(err) {
+		t.Fatalf("expected AlreadyExists without a key, got %v", err)
+	}
+
+	// An AlreadyExists answer to the first attempt is not ours.
+	attempts = 1
+	_, err = ifaces.Create(context.Background(), iface, WithRetry(3, nil), WithIdempotencyKey("op-2"))
+	if !IsAlreadyExists(err) {
+		t.Fatalf("expected AlreadyExists on the first attempt, got %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"fmt"
+	"net/netip"
+	"reflect"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+)
+
+// ResourceID returns the string identifying a resource among the resources
+// of its kind, e.g. the interface ID of an api.Interface or api.Nat, or
+// "<interface ID>/<prefix>" of an api.Prefix. Resources may be passed by
+// value or pointer. It returns false for nil pointers and unknown types.
+func ResourceID(obj any) (string, bool) {
+	obj, ok := resourcePointer(obj)
+	if !ok {
+		return "", false
+	}
+
+	switch r := obj.(type) {
+	case *api.Interface:
+		return r.ID, true
+	case *api.LoadBalancer:
+		return r.ID, true
+	case *api.LoadBalancerTarget:
+		return r.LoadbalancerID + "/" + addrString(r.Spec.TargetIP), true
+	case *api.LoadBalancerPrefix:
+		return r.InterfaceID + "/" + r.Spec.Prefix.String(), true
+	case *api.Prefix:
+		return r.InterfaceID + "/" + r.Spec.Prefix.String(), true
+	case *api.VirtualIP:
+		return r.InterfaceID, true
+	case *api.Nat:
+		return r.InterfaceID, true
+	case *api.NeighborNat:
+		return fmt.Sprintf("%s/%d-%d", addrString(r.NatIP), r.Spec.MinPort, r.Spec.MaxPort), true
+	case *api.Route:
+		prefix := "<nil>"
+		if r.Spec.Prefix != nil {
+			prefix = r.Spec.Prefix.String()
+		}
+		return fmt.Sprintf("%d/%s", r.VNI, prefix), true
+	case *api.FirewallRule:
+		return r.InterfaceID + "/" + r.Spec.RuleID, true
+	}
+	return "", false
+}
+
+// resourcePointer returns a pointer to obj if it is a struct value, so that
+// type switches only need the pointer cases. It returns false for nil.
+func resourcePointer(obj any) (any, bool) {
+	v := reflect.ValueOf(obj)
+	switch v.Kind() {
+	case reflect.Struct:
+		p := reflect.New(v.Type())
+		p.Elem().Set(v)
+		return p.Interface(), true
+	case reflect.Pointer:
+		return obj, !v.IsNil()
+	case reflect.Invalid:
+		return nil, false
+	}
+	return obj, true
+}
+
+func addrString(addr *netip.Addr) string {
+	if addr == nil {
+		return "<nil>"
+	}
+	return addr.String()
+}
@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+)
+
+func TestResourceID(t *testing.T) {
+	target := netip.MustParseAddr("fc00::1")
+	prefix := netip.MustParsePrefix("10.0.0.0/24")
+	route := testRoute("10.0.1.0/24", 100, "fc00::2")
+
+	tests := []struct {
+		obj  any
+		want string
+	}{
+		{&api.Interface{InterfaceMeta: api.InterfaceMeta{ID: "vm1"}}, "vm1"},
+		{api.LoadBalancer{LoadBalancerMeta: api.LoadBalancerMeta{ID: "lb1"}}, "lb1"},
+		{&api.LoadBalancerTarget{LoadBalancerTargetMeta: api.LoadBalancerTargetMeta{LoadbalancerID: "lb1"}, Spec: api.LoadBalancerTargetSpec{TargetIP: &target}}, "lb1/fc00::1"},
+		{&api.Prefix{PrefixMeta: api.PrefixMeta{InterfaceID: "vm1"}, Spec: api.PrefixSpec{Prefix: prefix}}, "vm1/10.0.0.0/24"},
+		{&api.Nat{NatMeta: api.NatMeta{InterfaceID: "vm1"}}, "vm1"},
+		{&api.NeighborNat{NeighborNatMeta: api.NeighborNatMeta{NatIP: &target}, Spec: api.NeighborNatSpec{MinPort: 100, MaxPort: 200}}, "fc00::1/100-200"},
+		{route, "100/10.0.1.0/24"},
+		{&api.FirewallRule{FirewallRuleMeta: api.FirewallRuleMeta{InterfaceID: "vm1"}, Spec: api.FirewallRuleSpec{RuleID: "r1"}}, "vm1/r1"},
+	}
+	for _, tt := range tests {
+		if got, ok := ResourceID(tt.obj); !ok || got != tt.want {
+			t.Errorf("ResourceID(%T) = %q, %v; want %q", tt.obj, got, ok, tt.want)
+		}
+	}
+
+	for _, obj := range []any{nil, (*api.Interface)(nil), "vm1", &api.Version{}} {
+		if _, ok := ResourceID(obj); ok {
+			t.Errorf("expected ResourceID(%#v) to fail", obj)
+		}
+	}
+}
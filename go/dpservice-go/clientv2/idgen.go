@@ -0,0 +1,34 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import "github.com/google/uuid"
+
+// WithIDGenerator makes Create calls of interfaces, load balancers and
+// firewall rules without an ID send one made by gen, called with the domain
+// of the call. The generated ID is set on a copy of the resource, so it is
+// returned by Create but not written to the object passed in. UUIDGenerator
+// is a ready-made gen.
+func WithIDGenerator(gen func(domain string) string) CallOption {
+	return func(o *callOptions) {
+		o.idGenerator = gen
+	}
+}
+
+// UUIDGenerator returns a random UUID for any domain, for use with
+// WithIDGenerator.
+func UUIDGenerator(string) string {
+	return uuid.NewString()
+}
+
+// withGeneratedID returns obj, or under WithIDGenerator a copy of obj with
+// the generated ID if the ID field selected by id is empty.
+func withGeneratedID[T any](o callOptions, domain string, obj *T, id func(*T) *string) *T {
+	if o.idGenerator == nil || obj == nil || *id(obj) != "" {
+		return obj
+	}
+	cp := *obj
+	*id(&cp) = o.idGenerator(domain)
+	return &cp
+}
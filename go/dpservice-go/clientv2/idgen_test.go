@@ -0,0 +1,48 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+)
+
+func TestWithIDGenerator(t *testing.T) {
+	var sent []string
+	fake := &fakeLegacy{
+		createInterface: func(_ context.Context, iface *api.Interface) (*api.Interface, error) {
+			sent = append(sent, iface.ID)
+			return iface, nil
+		},
+		createFirewallRule: func(_ context.Context, rule *api.FirewallRule) (*api.FirewallRule, error) {
+			sent = append(sent, rule.Spec.RuleID)
+			return rule, nil
+		},
+	}
+	c := AsV2(fake, WithIDGenerator(func(domain string) string { return domain + "-1" }))
+
+	iface := &api.Interface{}
+	created, err := c.Interfaces().Create(context.Background(), iface)
+	if err != nil || created.ID != DomainInterfaces+"-1" || iface.ID != "" {
+		t.Fatalf("expected a generated ID on a copy, got %v, %q, %q", err, created.ID, iface.ID)
+	}
+	if created, err := c.Interfaces().Create(context.Background(), &api.Interface{InterfaceMeta: api.InterfaceMeta{ID: "vm1"}}); err != nil || created.ID != "vm1" {
+		t.Fatalf("expected a given ID to be kept, got %v, %q", err, created.ID)
+	}
+	rule, err := c.Firewall().Create(context.Background(), &api.FirewallRule{}, WithIDGenerator(UUIDGenerator))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := uuid.Parse(rule.Spec.RuleID); err != nil {
+		t.Fatalf("expected a UUID rule ID, got %q", rule.Spec.RuleID)
+	}
+	if want := []string{DomainInterfaces + "-1", "vm1", rule.Spec.RuleID}; !reflect.DeepEqual(sent, want) {
+		t.Fatalf("expected IDs %v to be sent, got %v", want, sent)
+	}
+}
@@ -0,0 +1,152 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"net/netip"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+)
+
+// InterfaceIndex is a local view of all interfaces of a server, indexed by
+// ID, primary IP and VNI. It is built from Interfaces().List and replaced as
+// a whole on every refresh, so lookups never see a partially refreshed
+// index. It is safe for concurrent use.
+//
+// The returned interfaces are shared between callers and must not be
+// modified.
+type InterfaceIndex struct {
+	client Client
+	opts   []CallOption
+
+	// mu serializes refreshes so that an older List never replaces a newer
+	// one.
+	mu       sync.Mutex
+	snapshot atomic.Pointer[interfaceSnapshot]
+	err      atomic.Pointer[error]
+
+	stop    context.CancelFunc
+	stopped chan struct{}
+}
+
+type interfaceSnapshot struct {
+	byID  map[string]*api.Interface
+	byIP  map[netip.Addr]*api.Interface
+	byVNI map[uint32][]*api.Interface
+	at    time.Time
+}
+
+// NewInterfaceIndex returns an index of the interfaces of c that refreshes
+// itself every refresh, starting right away, until Close is called. With a
+// non-positive refresh the index is only refreshed by Refresh. opts are
+// passed to every List. Until the first refresh succeeds the index is
+// empty.
+func NewInterfaceIndex(c Client, refresh time.Duration, opts ...CallOption) *InterfaceIndex {
+	ctx, stop := context.WithCancel(context.Background())
+	idx := &InterfaceIndex{client: c, opts: opts, stop: stop, stopped: make(chan struct{})}
+	idx.snapshot.Store(buildInterfaceSnapshot(nil, time.Time{}))
+	if refresh <= 0 {
+		close(idx.stopped)
+		return idx
+	}
+	go idx.run(ctx, refresh)
+	return idx
+}
+
+func (idx *InterfaceIndex) run(ctx context.Context, refresh time.Duration) {
+	defer close(idx.stopped)
+	ticker := time.NewTicker(refresh)
+	defer ticker.Stop()
+	for {
+		// Failures are reported by Err; the previous index stays in place.
+		_ = idx.Refresh(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Refresh lists the interfaces and replaces the index. On error the index
+// is left unchanged and the error is also reported by Err.
+func (idx *InterfaceIndex) Refresh(ctx context.Context) error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	list, err := idx.client.Interfaces().List(ctx, idx.opts...)
+	idx.err.Store(&err)
+	if err != nil {
+		return err
+	}
+	idx.snapshot.Store(buildInterfaceSnapshot(list.Items, time.Now()))
+	return nil
+}
+
+func buildInterfaceSnapshot(ifaces []api.Interface, at time.Time) *interfaceSnapshot {
+	s := &interfaceSnapshot{
+		byID:  make(map[string]*api.Interface, len(ifaces)),
+		byIP:  make(map[netip.Addr]*api.Interface, len(ifaces)),
+		byVNI: make(map[uint32][]*api.Interface),
+		at:    at,
+	}
+	for i := range ifaces {
+		iface := &ifaces[i]
+		s.byID[iface.ID] = iface
+		for _, ip := range []*netip.Addr{iface.Spec.IPv4, iface.Spec.IPv6} {
+			if ip != nil && ip.IsValid() {
+				s.byIP[ip.Unmap()] = iface
+			}
+		}
+		s.byVNI[iface.Spec.VNI] = append(s.byVNI[iface.Spec.VNI], iface)
+	}
+	return s
+}
+
+// ByID returns the interface with the given ID, or nil.
+func (idx *InterfaceIndex) ByID(id string) *api.Interface {
+	return idx.snapshot.Load().byID[id]
+}
+
+// ByIP returns the interface whose primary IPv4 or IPv6 address is ip, or
+// nil. IPv4-mapped IPv6 addresses match their IPv4 address.
+func (idx *InterfaceIndex) ByIP(ip netip.Addr) *api.Interface {
+	return idx.snapshot.Load().byIP[ip.Unmap()]
+}
+
+// ByVNI returns the interfaces in vni in list order.
+func (idx *InterfaceIndex) ByVNI(vni uint32) []*api.Interface {
+	return slices.Clone(idx.snapshot.Load().byVNI[vni])
+}
+
+// Len returns the number of indexed interfaces.
+func (idx *InterfaceIndex) Len() int {
+	return len(idx.snapshot.Load().byID)
+}
+
+// RefreshedAt returns when the index was last refreshed successfully, or
+// the zero time if it never was.
+func (idx *InterfaceIndex) RefreshedAt() time.Time {
+	return idx.snapshot.Load().at
+}
+
+// Err returns the error of the last refresh, or nil if it succeeded.
+func (idx *InterfaceIndex) Err() error {
+	if err := idx.err.Load(); err != nil {
+		return *err
+	}
+	return nil
+}
+
+// Close stops the periodic refresh and waits for a running one to finish.
+// The index keeps answering lookups from its last state. It does not close
+// the client.
+func (idx *InterfaceIndex) Close() {
+	idx.stop()
+	<-idx.stopped
+}
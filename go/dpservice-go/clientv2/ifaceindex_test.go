@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+)
+
+func indexedInterface(id string, vni uint32, ip string) api.Interface {
+	addr := netip.MustParseAddr(ip)
+	iface := api.Interface{InterfaceMeta: api.InterfaceMeta{ID: id}, Spec: api.InterfaceSpec{VNI: vni}}
+	if addr.Is4() {
+		iface.Spec.IPv4 = &addr
+	} else {
+		iface.Spec.IPv6 = &addr
+	}
+	return iface
+}
+
+func TestInterfaceIndex(t *testing.T) {
+	var fail bool
+	items := []api.Interface{
+		indexedInterface("vm1", 100, "10.0.0.1"),
+		indexedInterface("vm2", 100, "fd00::2"),
+		indexedInterface("vm3", 200, "10.0.0.3"),
+	}
+	fake := &fakeLegacy{
+		listInterfaces: func(context.Context) (*api.InterfaceList, error) {
+			if fail {
+				return nil, errors.New("unavailable")
+			}
+			return &api.InterfaceList{Items: items}, nil
+		},
+	}
+	idx := NewInterfaceIndex(AsV2(fake), 0)
+	defer idx.Close()
+	if idx.Len() != 0 || !idx.RefreshedAt().IsZero() {
+		t.Fatal("expected an empty index before the first refresh")
+	}
+	if err := idx.Refresh(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if iface := idx.ByID("vm2"); iface == nil || iface.ID != "vm2" {
+		t.Errorf("ByID: unexpected %v", iface)
+	}
+	if iface := idx.ByIP(netip.MustParseAddr("::ffff:10.0.0.3")); iface == nil || iface.ID != "vm3" {
+		t.Errorf("ByIP: unexpected %v", iface)
+	}
+	if iface := idx.ByIP(netip.MustParseAddr("fd00::2")); iface == nil || iface.ID != "vm2" {
+		t.Errorf("ByIP: unexpected %v", iface)
+	}
+	if got := idx.ByVNI(100); len(got) != 2 || got[0].ID != "vm1" || got[1].ID != "vm2" {
+		t.Errorf("ByVNI: unexpected %v", got)
+	}
+	if idx.ByID("vm4") != nil || idx.ByVNI(300) != nil {
+		t.Error("expected no result for unknown keys")
+	}
+
+	fail = true
+	if err := idx.Refresh(context.Background()); err == nil || idx.Err() == nil {
+		t.Fatal("expected refresh error")
+	}
+	if idx.Len() != 3 {
+		t.Errorf("expected the index to survive a failed refresh, got %d interfaces", idx.Len())
+	}
+}
+
+func TestInterfaceIndexPeriodicRefresh(t *testing.T) {
+	var mu sync.Mutex
+	var ids []string
+	fake := &fakeLegacy{
+		listInterfaces: func(ctx context.Context) (*api.InterfaceList, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			ids = append(ids, fmt.Sprintf("vm%d", len(ids)))
+			return interfaceList(ids...)(ctx)
+		},
+	}
+	idx := NewInterfaceIndex(AsV2(fake), time.Millisecond)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for idx.Len() < 3 {
+		if time.Now().After(deadline) {
+			t.Fatalf("index was not refreshed, has %d interfaces", idx.Len())
+		}
+		// Lookups race with the refreshes on purpose.
+		_ = idx.ByID("vm0")
+		time.Sleep(time.Millisecond)
+	}
+	idx.Close()
+
+	n := idx.Len()
+	time.Sleep(10 * time.Millisecond)
+	if idx.Len() != n {
+		t.Error("index was refreshed after Close")
+	}
+}
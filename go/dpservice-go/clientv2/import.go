@@ -0,0 +1,195 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+	dpdkproto "github.com/ironcore-dev/dpservice/go/dpservice-go/proto"
+)
+
+// ImportOptions configures ImportAll.
+type ImportOptions struct {
+	// CallOptions are applied to every call. WithContinueOnError is honored
+	// here.
+	CallOptions []CallOption
+}
+
+// WithContinueOnError makes ImportAll record a resource that cannot be
+// created in its report and go on with the next record instead of stopping.
+func WithContinueOnError() CallOption {
+	return func(o *callOptions) {
+		o.continueOnErr = true
+	}
+}
+
+// ImportAll reads the JSON-lines stream written by ExportAll from r and
+// creates its resources on c in stream order, which is dependency order.
+// Resources that already exist are skipped and are cleaned with
+// ToCreateRequest, like in Migrate. The outcome of every
+// record is added to the returned report.
+//
+// ImportAll stops at the first resource that cannot be created and returns
+// its error, unless WithContinueOnError is given; dependents of a load
+// balancer or interface that could not be created are then recorded as
+// failed without being attempted. A malformed stream or the end of ctx
+// always stops the import. The report is returned in every case.
+func ImportAll(ctx context.Context, c Client, r io.Reader, opts ImportOptions) (*MigrateReport, error) {
+	o := opts.CallOptions
+	continueOnErr := buildCallOptions(o...).continueOnErr
+	report := &MigrateReport{}
+	// failed holds the load balancers and interfaces that could not be
+	// created, keyed by kind and ID, e.g. "interface vm1".
+	failed := map[string]bool{}
+
+	dec := json.NewDecoder(r)
+	for {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		var rec ExportRecord
+		if err := dec.Decode(&rec); err != nil {
+			if errors.Is(err, io.EOF) {
+				return report, nil
+			}
+			return report, fmt.Errorf("read record %d: %w", len(report.Results)+1, err)
+		}
+
+		domain, name, parent, err := importRecord(ctx, c, rec, failed, o)
+		if !report.record(domain, name, err) {
+			if parent != "" {
+				failed[parent] = true
+			}
+			if !continueOnErr {
+				return report, fmt.Errorf("%s %s: %w", domain, name, err)
+			}
+		}
+	}
+}
+
+// importRecord creates the resource of rec. It returns the domain and name
+// the outcome is recorded under and, for load balancers and interfaces, the
+// key of the resource in failed.
+func importRecord(ctx context.Context, c Client, rec ExportRecord, failed map[string]bool, o []CallOption) (domain, name, parent string, err error) {
+	switch rec.Kind {
+	case api.LoadBalancerKind:
+		var lb api.LoadBalancer
+		if err := json.Unmarshal(rec.Object, &lb); err != nil {
+			return DomainLoadBalancers, "", "", err
+		}
+		_, err := c.LoadBalancers().Create(ctx, toCreate(&lb), o...)
+		return DomainLoadBalancers, lb.ID, "loadbalancer " + lb.ID, err
+	case api.LoadBalancerTargetKind:
+		var target api.LoadBalancerTarget
+		if err := json.Unmarshal(rec.Object, &target); err != nil {
+			return DomainLoadBalancerTargets, "", "", err
+		}
+		name := fmt.Sprintf("%s/%s", target.LoadbalancerID, target.Spec.TargetIP)
+		if err := parentFailed(failed, "loadbalancer", target.LoadbalancerID); err != nil {
+			return DomainLoadBalancerTargets, name, "", err
+		}
+		_, err := c.LoadBalancers().Targets().Create(ctx, toCreate(&target), o...)
+		return DomainLoadBalancerTargets, name, "", err
+	case api.InterfaceKind:
+		var iface api.Interface
+		if err := json.Unmarshal(rec.Object, &iface); err != nil {
+			return DomainInterfaces, "", "", err
+		}
+		_, err := c.Interfaces().Create(ctx, toCreate(&iface), o...)
+		return DomainInterfaces, iface.ID, "interface " + iface.ID, err
+	case api.PrefixKind:
+		var prefix api.Prefix
+		if err := json.Unmarshal(rec.Object, &prefix); err != nil {
+			return DomainInterfacePrefixes, "", "", err
+		}
+		name := prefix.InterfaceID + "/" + prefix.Spec.Prefix.String()
+		if err := parentFailed(failed, "interface", prefix.InterfaceID); err != nil {
+			return DomainInterfacePrefixes, name, "", err
+		}
+		_, err := c.Interfaces().Prefixes().Create(ctx, toCreate(&prefix), o...)
+		return DomainInterfacePrefixes, name, "", err
+	case api.LoadBalancerPrefixKind:
+		var prefix api.LoadBalancerPrefix
+		if err := json.Unmarshal(rec.Object, &prefix); err != nil {
+			return DomainLoadBalancerPrefixes, "", "", err
+		}
+		name := prefix.InterfaceID + "/" + prefix.Spec.Prefix.String()
+		if err := parentFailed(failed, "interface", prefix.InterfaceID); err != nil {
+			return DomainLoadBalancerPrefixes, name, "", err
+		}
+		_, err := c.LoadBalancers().Prefixes().Create(ctx, toCreate(&prefix), o...)
+		return DomainLoadBalancerPrefixes, name, "", err
+	case api.VirtualIPKind:
+		var vip api.VirtualIP
+		if err := json.Unmarshal(rec.Object, &vip); err != nil {
+			return DomainVirtualIPs, "", "", err
+		}
+		if err := parentFailed(failed, "interface", vip.InterfaceID); err != nil {
+			return DomainVirtualIPs, vip.InterfaceID, "", err
+		}
+		_, err := c.Interfaces().VIP().Create(ctx, toCreate(&vip), o...)
+		return DomainVirtualIPs, vip.InterfaceID, "", err
+	case api.NatKind:
+		var nat api.Nat
+		if err := json.Unmarshal(rec.Object, &nat); err != nil {
+			return DomainNATs, "", "", err
+		}
+		if err := parentFailed(failed, "interface", nat.InterfaceID); err != nil {
+			return DomainNATs, nat.InterfaceID, "", err
+		}
+		_, err := c.NATs().Create(ctx, toCreate(&nat), o...)
+		return DomainNATs, nat.InterfaceID, "", err
+	case api.FirewallRuleKind:
+		rule, err := decodeFirewallRule(rec.Object)
+		if err != nil {
+			return DomainFirewall, "", "", err
+		}
+		name := rule.InterfaceID + "/" + rule.Spec.RuleID
+		if err := parentFailed(failed, "interface", rule.InterfaceID); err != nil {
+			return DomainFirewall, name, "", err
+		}
+		_, err = c.Firewall().Create(ctx, toCreate(rule), o...)
+		return DomainFirewall, name, "", err
+	case api.RouteKind:
+		var route api.Route
+		if err := json.Unmarshal(rec.Object, &route); err != nil {
+			return DomainRoutes, "", "", err
+		}
+		_, err := c.Routes().Create(ctx, toCreate(&route), o...)
+		return DomainRoutes, fmt.Sprintf("%d/%s", route.VNI, route.Spec.Prefix), "", err
+	}
+	return rec.Kind, "", "", fmt.Errorf("unknown record kind %q", rec.Kind)
+}
+
+// decodeFirewallRule decodes the firewallRuleJSON form of ExportAll.
+func decodeFirewallRule(raw []byte) (*api.FirewallRule, error) {
+	var in firewallRuleJSON
+	if err := json.Unmarshal(raw, &in); err != nil {
+		return nil, err
+	}
+	rule := &api.FirewallRule{TypeMeta: in.TypeMeta, FirewallRuleMeta: in.FirewallRuleMeta, Spec: in.Spec.FirewallRuleSpec, Status: in.Status}
+	if len(in.Spec.ProtocolFilter) > 0 && string(in.Spec.ProtocolFilter) != "null" {
+		rule.Spec.ProtocolFilter = &dpdkproto.ProtocolFilter{}
+		if err := protojson.Unmarshal(in.Spec.ProtocolFilter, rule.Spec.ProtocolFilter); err != nil {
+			return nil, fmt.Errorf("protocol filter of rule %s: %w", rule.Spec.RuleID, err)
+		}
+	}
+	return rule, nil
+}
+
+// parentFailed returns an error if the load balancer or interface id that a
+// record depends on could not be imported.
+func parentFailed(failed map[string]bool, kind, id string) error {
+	if failed[kind+" "+id] {
+		return fmt.Errorf("%s %s was not imported", kind, id)
+	}
+	return nil
+}
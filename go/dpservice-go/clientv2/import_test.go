@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+	dperrors "github.com/ironcore-dev/dpservice/go/dpservice-go/errors"
+	dpdkproto "github.com/ironcore-dev/dpservice/go/dpservice-go/proto"
+)
+
+func TestImportAll(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ExportAll(context.Background(), AsV2(exportSource()), &buf, ExportOptions{}); err != nil {
+		t.Fatalf("unexpected export error: %v", err)
+	}
+	dst := &fakeLegacy{
+		createLoadBalancer: func(_ context.Context, lb *api.LoadBalancer) (*api.LoadBalancer, error) {
+			return lb, dperrors.NewStatusError(dperrors.ALREADY_EXISTS, "exists")
+		},
+	}
+
+	report, err := ImportAll(context.Background(), AsV2(dst), &buf, ImportOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var copied []string
+	for _, res := range report.Copied() {
+		copied = append(copied, res.Domain+":"+res.Name)
+	}
+	want := []string{
+		"LoadBalancers.Targets:lb1/fc00::1",
+		"Interfaces:vm1",
+		"Interfaces.VIP:vm1",
+		"Firewall:vm1/r1",
+		"Routes:100/10.0.0.0/24",
+	}
+	if !slices.Equal(copied, want) {
+		t.Fatalf("expected copied %v, got %v", want, copied)
+	}
+	if len(report.Results) != 6 || !report.Results[0].Existed {
+		t.Fatalf("expected the existing loadbalancer to be skipped, got %+v", report.Results)
+	}
+}
+
+func TestImportAllContinueOnError(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ExportAll(context.Background(), AsV2(exportSource()), &buf, ExportOptions{}); err != nil {
+		t.Fatalf("unexpected export error: %v", err)
+	}
+	stream := buf.String()
+	dst := &fakeLegacy{
+		createInterface: func(context.Context, *api.Interface) (*api.Interface, error) {
+			return &api.Interface{}, dperrors.NewStatusError(dperrors.OUT_OF_MEMORY, "oom")
+		},
+	}
+
+	report, err := ImportAll(context.Background(), AsV2(dst), strings.NewReader(stream), ImportOptions{})
+	if !dperrors.IsStatusErrorCode(err, dperrors.OUT_OF_MEMORY) || len(report.Results) != 3 {
+		t.Fatalf("expected the import to stop at vm1, got %+v, %v", report.Results, err)
+	}
+
+	report, err = ImportAll(context.Background(), AsV2(dst), strings.NewReader(stream), ImportOptions{
+		CallOptions: []CallOption{WithContinueOnError()},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if failed := report.Failed(); len(failed) != 3 || failed[1].Domain != DomainVirtualIPs {
+		t.Fatalf("expected vm1 and its dependents to fail, got %+v", failed)
+	}
+	if slices.Contains(dst.Calls(), "CreateVirtualIP") {
+		t.Fatalf("expected dependents of vm1 not to be attempted")
+	}
+	if copied := report.Copied(); copied[len(copied)-1].Domain != DomainRoutes {
+		t.Fatalf("expected the routes to be imported, got %+v", copied)
+	}
+
+	if _, err := ImportAll(context.Background(), AsV2(dst), strings.NewReader("{"), ImportOptions{}); err == nil {
+		t.Fatalf("expected an error for a malformed stream")
+	}
+}
+
+func TestImportAllFirewallFilters(t *testing.T) {
+	filters := []*dpdkproto.ProtocolFilter{
+		{Filter: &dpdkproto.ProtocolFilter_Tcp{Tcp: &dpdkproto.TcpFilter{SrcPortLower: -1, DstPortLower: 80, DstPortUpper: 80}}},
+		{Filter: &dpdkproto.ProtocolFilter_Udp{Udp: &dpdkproto.UdpFilter{SrcPortLower: -1, DstPortLower: 53, DstPortUpper: 53}}},
+		{Filter: &dpdkproto.ProtocolFilter_Icmp{Icmp: &dpdkproto.IcmpFilter{IcmpType: 8, IcmpCode: -1}}},
+		nil,
+	}
+	src := exportSource()
+	src.listFirewallRules = func(context.Context, string) (*api.FirewallRuleList, error) {
+		list := &api.FirewallRuleList{}
+		for i, filter := range filters {
+			list.Items = append(list.Items, api.FirewallRule{Spec: api.FirewallRuleSpec{RuleID: fmt.Sprint("r", i), ProtocolFilter: filter}})
+		}
+		return list, nil
+	}
+	var buf bytes.Buffer
+	if err := ExportAll(context.Background(), AsV2(src), &buf, ExportOptions{}); err != nil {
+		t.Fatalf("unexpected export error: %v", err)
+	}
+
+	var created []*api.FirewallRule
+	dst := &fakeLegacy{
+		createFirewallRule: func(_ context.Context, rule *api.FirewallRule) (*api.FirewallRule, error) {
+			created = append(created, rule)
+			return rule, nil
+		},
+	}
+	if _, err := ImportAll(context.Background(), AsV2(dst), &buf, ImportOptions{}); err != nil {
+		t.Fatalf("unexpected import error: %v", err)
+	}
+	if len(created) != len(filters) {
+		t.Fatalf("expected %d rules, got %d", len(filters), len(created))
+	}
+	for i, rule := range created {
+		if rule.InterfaceID != "vm1" || !proto.Equal(rule.Spec.ProtocolFilter, filters[i]) {
+			t.Errorf("rule %d: expected filter %v of vm1, got %v of %q", i, filters[i], rule.Spec.ProtocolFilter, rule.InterfaceID)
+		}
+	}
+}
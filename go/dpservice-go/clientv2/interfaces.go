@@ -0,0 +1,328 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+	"time"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+)
+
+// InterfaceConflictError is returned by Interfaces().CreateExclusive when the
+// ID of the interface is already taken.
+type InterfaceConflictError struct {
+	ID string
+	// Existing is the interface holding the ID, as returned by Get.
+	Existing *api.Interface
+	// Err is the already-exists error of Create.
+	Err error
+}
+
+func (e *InterfaceConflictError) Error() string {
+	return fmt.Sprintf("interface %s already exists: %s", e.ID, Format(e.Existing))
+}
+
+func (e *InterfaceConflictError) Unwrap() error {
+	return e.Err
+}
+
+func (c *ifaceClient) CreateExclusive(ctx context.Context, iface *api.Interface, opts ...CallOption) (*api.Interface, error) {
+	created, err := c.Create(ctx, iface, opts...)
+	if !IsAlreadyExists(err) {
+		return created, err
+	}
+	existing, getErr := c.Get(ctx, iface.ID, opts...)
+	if getErr != nil || existing.Status.Code != 0 {
+		// The interface vanished in the meantime; report the original error.
+		return nil, err
+	}
+	return nil, &InterfaceConflictError{ID: iface.ID, Existing: existing, Err: err}
+}
+
+// AmbiguousDeviceError is returned by Interfaces().GetByDevice when more than
+// one interface uses the device.
+type AmbiguousDeviceError struct {
+	Device       string
+	InterfaceIDs []string
+}
+
+func (e *AmbiguousDeviceError) Error() string {
+	return fmt.Sprintf("device %s is used by %d interfaces: %s", e.Device, len(e.InterfaceIDs), strings.Join(e.InterfaceIDs, ", "))
+}
+
+func (c *ifaceClient) GetByDevice(ctx context.Context, device string, opts ...CallOption) (*api.Interface, error) {
+	if device == "" {
+		return nil, &InvalidArgumentError{Domain: DomainInterfaces, Method: "GetByDevice", Argument: "device", Reason: "device is empty"}
+	}
+	if err := c.callOptions(opts).requireServerSide(DomainInterfaces, "GetByDevice"); err != nil {
+		return nil, err
+	}
+	ifaces, err := c.List(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var match *api.Interface
+	var ids []string
+	for i := range ifaces.Items {
+		if ifaces.Items[i].Spec.Device == device {
+			match = &ifaces.Items[i]
+			ids = append(ids, match.ID)
+		}
+	}
+	switch len(ids) {
+	case 0:
+		status, err := c.notFound(DomainInterfaces, "GetByDevice", opts, fmt.Sprintf("no interface uses device %s", device))
+		if err != nil {
+			return nil, err
+		}
+		return &api.Interface{Status: status}, nil
+	case 1:
+		return match, nil
+	}
+	return nil, &AmbiguousDeviceError{Device: device, InterfaceIDs: ids}
+}
+
+func (c *ifaceClient) GroupByVNI(ctx context.Context, opts ...CallOption) (map[uint32]*api.InterfaceList, error) {
+	ifaces, err := c.List(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	byVNI := make(map[uint32]*api.InterfaceList)
+	for _, iface := range ifaces.Items {
+		list, ok := byVNI[iface.Spec.VNI]
+		if !ok {
+			list = &api.InterfaceList{TypeMeta: api.TypeMeta{Kind: api.InterfaceListKind}, Items: []api.Interface{}}
+			byVNI[iface.Spec.VNI] = list
+		}
+		list.Items = append(list.Items, iface)
+	}
+	return byVNI, nil
+}
+
+func (c *ifaceClient) DeleteCascade(ctx context.Context, interfaceID string, opts ...CallOption) error {
+	if err := checkID(DomainInterfaces, "DeleteCascade", "interfaceID", interfaceID); err != nil {
+		return err
+	}
+	var errs []error
+	collect := func(what string, err error) {
+		if err != nil && !IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("%s: %w", what, err))
+		}
+	}
+
+	fw := c.Firewall()
+	if rules, err := fw.List(ctx, interfaceID, opts...); err != nil {
+		collect("list firewall rules", err)
+	} else {
+		for _, rule := range rules.Items {
+			_, err := fw.Delete(ctx, interfaceID, rule.Spec.RuleID, opts...)
+			collect("delete firewall rule "+rule.Spec.RuleID, err)
+		}
+	}
+
+	prefixes := c.Prefixes()
+	if list, err := prefixes.List(ctx, interfaceID, opts...); err != nil {
+		collect("list prefixes", err)
+	} else {
+		for _, prefix := range list.Items {
+			_, err := prefixes.Delete(ctx, interfaceID, &prefix.Spec.Prefix, opts...)
+			collect("delete prefix "+prefix.Spec.Prefix.String(), err)
+		}
+	}
+
+	lbPrefixes := &lbPrefixesClient{core: c.core}
+	if list, err := lbPrefixes.List(ctx, interfaceID, opts...); err != nil {
+		collect("list loadbalancer prefixes", err)
+	} else {
+		for _, prefix := range list.Items {
+			_, err := lbPrefixes.Delete(ctx, interfaceID, &prefix.Spec.Prefix, opts...)
+			collect("delete loadbalancer prefix "+prefix.Spec.Prefix.String(), err)
+		}
+	}
+
+	_, err := c.VIP().Delete(ctx, interfaceID, opts...)
+	collect("delete virtual IP", err)
+
+	_, err = (&natClient{core: c.core}).Delete(ctx, interfaceID, opts...)
+	collect("delete NAT", err)
+
+	// Deleting the interface while dependents are left over is rejected by
+	// the server, so only report what blocked the teardown.
+	if len(errs) == 0 {
+		_, err = c.Delete(ctx, interfaceID, opts...)
+		collect("delete interface", err)
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("cascade delete of interface %s: %w", interfaceID, errors.Join(errs...))
+	}
+	return nil
+}
+
+// WithIncludeMissing makes batch lookups such as Interfaces().GetMany map
+// missing resources to nil instead of omitting them.
+func WithIncludeMissing() CallOption {
+	return func(o *callOptions) {
+		o.includeMissing = true
+	}
+}
+
+func (c *ifaceClient) GetMany(ctx context.Context, ids []string, opts ...CallOption) (map[string]*api.Interface, *BulkError) {
+	o := c.callOptions(opts)
+	ctx, cancel := o.bulkContext(ctx)
+	defer cancel()
+
+	if err := contextDone(ctx, DomainInterfaces+".GetMany"); err != nil {
+		return map[string]*api.Interface{}, failAll(len(ids), err)
+	}
+
+	itemOpts := bulkItemOptions(opts)
+	ifaces := make([]*api.Interface, len(ids))
+	missing := make([]bool, len(ids))
+	bulkErr := runBulk(ctx, len(ids), o, func(ctx context.Context, i int) error {
+		iface, err := c.Get(ctx, ids[i], itemOpts...)
+		switch {
+		case IsNotFound(err):
+			missing[i] = true
+		case err != nil:
+			return err
+		case iface.Status.Code != 0:
+			missing[i] = true
+		default:
+			ifaces[i] = iface
+		}
+		return nil
+	})
+
+	byID := make(map[string]*api.Interface, len(ids))
+	for i, id := range ids {
+		if ifaces[i] != nil || (missing[i] && o.includeMissing) {
+			byID[id] = ifaces[i]
+		}
+	}
+	return byID, bulkErr
+}
+
+// WithResolveVNI makes Interfaces().Prefixes().Create fill in a zero Vni of
+// the returned prefix from its interface, which costs an extra Get of the
+// interface. The VNI is never sent to the server, as CreatePrefixRequest has
+// no VNI field; the server takes it from the interface, so the option only
+// decorates the returned object and cannot prevent a rejection. Routes do
+// not reference an interface, so their VNI cannot be resolved.
+func WithResolveVNI() CallOption {
+	return func(o *callOptions) {
+		o.resolveVNI = true
+	}
+}
+
+// resolveVNI returns prefix with the VNI of its interface if it has none.
+// The caller's prefix is not modified.
+func (c *ifacePrefixesClient) resolveVNI(ctx context.Context, prefix *api.Prefix, opts []CallOption) (*api.Prefix, error) {
+	if prefix == nil || prefix.Vni != 0 {
+		return prefix, nil
+	}
+	iface, err := (&ifaceClient{core: c.core}).Get(ctx, prefix.InterfaceID, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("resolve VNI of interface %s: %w", prefix.InterfaceID, err)
+	}
+	if iface.Status.Code != 0 {
+		return nil, fmt.Errorf("resolve VNI of interface %s: %s", prefix.InterfaceID, iface.Status.Message)
+	}
+	resolved := *prefix
+	resolved.Vni = iface.Spec.VNI
+	return &resolved, nil
+}
+
+func (c *ifacePrefixesClient) ListAll(ctx context.Context, opts ...CallOption) (*api.PrefixList, error) {
+	o := c.callOptions(opts)
+	ctx, cancel := o.bulkContext(ctx)
+	defer cancel()
+
+	if err := contextDone(ctx, DomainInterfacePrefixes+".ListAll"); err != nil {
+		return nil, err
+	}
+
+	itemOpts := bulkItemOptions(opts)
+	ifaces, err := (&ifaceClient{core: c.core}).List(ctx, itemOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	prefixes := make([][]api.Prefix, len(ifaces.Items))
+	bulkErr := runBulk(ctx, len(ifaces.Items), o, func(ctx context.Context, i int) error {
+		list, err := c.List(ctx, ifaces.Items[i].ID, itemOpts...)
+		switch {
+		case IsNotFound(err):
+			return nil
+		case err != nil:
+			return err
+		}
+		prefixes[i] = list.Items
+		return nil
+	})
+
+	all := &api.PrefixList{TypeMeta: api.TypeMeta{Kind: api.PrefixListKind}, Items: []api.Prefix{}}
+	for i, iface := range ifaces.Items {
+		for _, prefix := range prefixes[i] {
+			prefix.InterfaceID = iface.ID
+			all.Items = append(all.Items, prefix)
+		}
+	}
+	if bulkErr != nil {
+		return all, bulkErr
+	}
+	return all, nil
+}
+
+func (c *ifacePrefixesClient) Get(ctx context.Context, interfaceID string, prefix netip.Prefix, opts ...CallOption) (*api.Prefix, error) {
+	if err := checkID(DomainInterfacePrefixes, "Get", "interfaceID", interfaceID); err != nil {
+		return nil, err
+	}
+	if err := c.callOptions(opts).requireServerSide(DomainInterfacePrefixes, "Get"); err != nil {
+		return nil, err
+	}
+	prefixes, err := c.List(ctx, interfaceID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	for i := range prefixes.Items {
+		if prefixes.Items[i].Spec.Prefix == prefix {
+			return &prefixes.Items[i], nil
+		}
+	}
+
+	status, err := c.notFound(DomainInterfacePrefixes, "Get", opts, fmt.Sprintf("prefix %s not found on interface %s", prefix, interfaceID))
+	if err != nil {
+		return nil, err
+	}
+	return &api.Prefix{Status: status}, nil
+}
+
+func (c *ifacePrefixesClient) Exists(ctx context.Context, interfaceID string, prefix netip.Prefix, opts ...CallOption) (bool, error) {
+	return exists(c.Get(ctx, interfaceID, prefix, opts...))
+}
+
+func (c *ifaceClient) WaitFor(ctx context.Context, id string, pred func(*api.Interface) bool, poll time.Duration, opts ...CallOption) (*api.Interface, error) {
+	return waitFor(ctx, poll, "interface "+id, func(ctx context.Context) (*api.Interface, error) {
+		return c.Get(ctx, id, opts...)
+	}, pred)
+}
+
+func (c *ifaceClient) WaitUntilGone(ctx context.Context, interfaceID string, poll time.Duration, opts ...CallOption) error {
+	return waitGone(ctx, poll, "interface "+interfaceID+" to be gone", func(ctx context.Context) (*api.Interface, error) {
+		return c.Get(ctx, interfaceID, opts...)
+	})
+}
+
+func (c *vipClient) WaitFor(ctx context.Context, interfaceID string, pred func(*api.VirtualIP) bool, poll time.Duration, opts ...CallOption) (*api.VirtualIP, error) {
+	return waitFor(ctx, poll, "virtual IP of interface "+interfaceID, func(ctx context.Context) (*api.VirtualIP, error) {
+		return c.Get(ctx, interfaceID, opts...)
+	}, pred)
+}
@@ -0,0 +1,326 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	stderrors "errors"
+	"net/netip"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/errors"
+)
+
+func TestInterfacesDeleteCascade(t *testing.T) {
+	fake := &fakeLegacy{
+		listFirewallRules: func(context.Context, string) (*api.FirewallRuleList, error) {
+			return &api.FirewallRuleList{Items: []api.FirewallRule{
+				{Spec: api.FirewallRuleSpec{RuleID: "fr1"}},
+				{Spec: api.FirewallRuleSpec{RuleID: "fr2"}},
+			}}, nil
+		},
+		listPrefixes: func(context.Context, string) (*api.PrefixList, error) {
+			return &api.PrefixList{Items: []api.Prefix{
+				{Spec: api.PrefixSpec{Prefix: netip.MustParsePrefix("10.0.1.0/24")}},
+			}}, nil
+		},
+		deleteVirtualIP: func(context.Context, string) (*api.VirtualIP, error) {
+			return &api.VirtualIP{}, errors.NewStatusError(errors.SNAT_NO_DATA, "no vip")
+		},
+		deleteNat: func(context.Context, string) (*api.Nat, error) {
+			return &api.Nat{}, errors.NewStatusError(errors.SNAT_NO_DATA, "no nat")
+		},
+	}
+
+	if err := AsV2(fake).Interfaces().DeleteCascade(context.Background(), "vm1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{
+		"ListFirewallRules", "DeleteFirewallRule", "DeleteFirewallRule",
+		"ListPrefixes", "DeletePrefix",
+		"ListLoadBalancerPrefixes",
+		"DeleteVirtualIP", "DeleteNat", "DeleteInterface",
+	}
+	if got := fake.Calls(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected call order:\n got %v\nwant %v", got, want)
+	}
+}
+
+func TestInterfacesDeleteCascadeBlocked(t *testing.T) {
+	fake := &fakeLegacy{
+		listFirewallRules: func(context.Context, string) (*api.FirewallRuleList, error) {
+			return &api.FirewallRuleList{Items: []api.FirewallRule{{Spec: api.FirewallRuleSpec{RuleID: "fr1"}}}}, nil
+		},
+		deleteFirewallRule: func(context.Context, string, string) (*api.FirewallRule, error) {
+			return &api.FirewallRule{}, errors.NewStatusError(errors.RTE_RULE_DEL, "rule del")
+		},
+	}
+
+	err := AsV2(fake).Interfaces().DeleteCascade(context.Background(), "vm1")
+	if !errors.IsStatusErrorCode(err, errors.RTE_RULE_DEL) {
+		t.Fatalf("expected the firewall failure to be reported, got %v", err)
+	}
+	for _, call := range fake.Calls() {
+		if call == "DeleteInterface" {
+			t.Fatalf("interface must not be deleted while dependents remain")
+		}
+	}
+}
+
+func TestInterfacesGetMany(t *testing.T) {
+	fake := &fakeLegacy{
+		getInterface: func(_ context.Context, id string) (*api.Interface, error) {
+			switch id {
+			case "missing":
+				return &api.Interface{}, errors.NewStatusError(errors.NOT_FOUND, "not found")
+			case "broken":
+				return &api.Interface{}, errors.NewStatusError(errors.OUT_OF_MEMORY, "oom")
+			}
+			return &api.Interface{InterfaceMeta: api.InterfaceMeta{ID: id}}, nil
+		},
+	}
+	ifaces := AsV2(fake).Interfaces()
+	ids := []string{"vm1", "missing", "broken", "vm2"}
+
+	got, bulkErr := ifaces.GetMany(context.Background(), ids, WithConcurrency(2))
+	if failed := bulkErr.Failed(); len(failed) != 1 || failed[0] != 2 {
+		t.Fatalf("expected only index 2 to fail, got %v", failed)
+	}
+	if len(got) != 2 || got["vm1"].ID != "vm1" || got["vm2"].ID != "vm2" {
+		t.Fatalf("expected vm1 and vm2, got %+v", got)
+	}
+
+	got, _ = ifaces.GetMany(context.Background(), ids, WithIncludeMissing())
+	if iface, ok := got["missing"]; len(got) != 3 || !ok || iface != nil {
+		t.Fatalf("expected the missing interface to map to nil, got %+v", got)
+	}
+}
+
+func TestInterfacesWaitFor(t *testing.T) {
+	polls := 0
+	fake := &fakeLegacy{
+		getInterface: func(_ context.Context, id string) (*api.Interface, error) {
+			polls++
+			switch polls {
+			case 1:
+				return &api.Interface{}, errors.NewStatusError(errors.NOT_FOUND, "not found")
+			case 2:
+				return &api.Interface{InterfaceMeta: api.InterfaceMeta{ID: id}}, nil
+			}
+			return &api.Interface{InterfaceMeta: api.InterfaceMeta{ID: id}, Spec: api.InterfaceSpec{UnderlayRoute: &netip.Addr{}}}, nil
+		},
+	}
+
+	iface, err := AsV2(fake).Interfaces().WaitFor(context.Background(), "vm1", func(iface *api.Interface) bool {
+		return iface.Spec.UnderlayRoute != nil
+	}, time.Millisecond)
+	if err != nil || iface == nil || iface.ID != "vm1" || polls != 3 {
+		t.Fatalf("expected vm1 after 3 polls, got %v, %v after %d", iface, err, polls)
+	}
+}
+
+func TestInterfacesWaitForError(t *testing.T) {
+	fake := &fakeLegacy{
+		getInterface: func(context.Context, string) (*api.Interface, error) {
+			return &api.Interface{}, errors.NewStatusError(errors.OUT_OF_MEMORY, "oom")
+		},
+	}
+
+	_, err := AsV2(fake).Interfaces().WaitFor(context.Background(), "vm1", func(*api.Interface) bool { return true }, time.Millisecond)
+	if !errors.IsStatusErrorCode(err, errors.OUT_OF_MEMORY) {
+		t.Fatalf("expected the OUT_OF_MEMORY error, got %v", err)
+	}
+}
+
+func TestInterfacesWaitForTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := AsV2(&fakeLegacy{}).Interfaces().WaitFor(ctx, "vm1", func(*api.Interface) bool { return false }, time.Millisecond)
+	if _, ok := err.(*TimeoutError); !ok {
+		t.Fatalf("expected a TimeoutError, got %v", err)
+	}
+}
+
+func TestInterfacesWaitUntilGone(t *testing.T) {
+	polls := 0
+	fake := &fakeLegacy{
+		getInterface: func(_ context.Context, id string) (*api.Interface, error) {
+			polls++
+			if polls < 3 {
+				return &api.Interface{InterfaceMeta: api.InterfaceMeta{ID: id}}, nil
+			}
+			return &api.Interface{}, errors.NewStatusError(errors.NOT_FOUND, "not found")
+		},
+	}
+	ifaces := AsV2(fake).Interfaces()
+
+	if err := ifaces.WaitUntilGone(context.Background(), "vm1", time.Millisecond); err != nil || polls != 3 {
+		t.Fatalf("expected vm1 to be gone after 3 polls, got %v after %d", err, polls)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	fake.getInterface = nil
+	if _, ok := ifaces.WaitUntilGone(ctx, "vm1", time.Millisecond).(*TimeoutError); !ok {
+		t.Fatalf("expected a TimeoutError while the interface exists")
+	}
+}
+
+func TestPrefixesCreateResolveVNI(t *testing.T) {
+	var created *api.Prefix
+	fake := &fakeLegacy{
+		getInterface: func(_ context.Context, id string) (*api.Interface, error) {
+			return &api.Interface{InterfaceMeta: api.InterfaceMeta{ID: id}, Spec: api.InterfaceSpec{VNI: 42}}, nil
+		},
+		createPrefix: func(_ context.Context, prefix *api.Prefix) (*api.Prefix, error) {
+			created = prefix
+			return prefix, nil
+		},
+	}
+	prefixes := AsV2(fake).Interfaces().Prefixes()
+	prefix := &api.Prefix{PrefixMeta: api.PrefixMeta{InterfaceID: "vm1"}, Spec: api.PrefixSpec{Prefix: netip.MustParsePrefix("10.0.1.0/24")}}
+
+	if _, err := prefixes.Create(context.Background(), prefix); err != nil || created.Vni != 0 || len(fake.Calls()) != 1 {
+		t.Fatalf("expected no lookup without WithResolveVNI, got %v, calls %v", err, fake.Calls())
+	}
+
+	if _, err := prefixes.Create(context.Background(), prefix, WithResolveVNI()); err != nil || created.Vni != 42 || prefix.Vni != 0 {
+		t.Fatalf("expected VNI 42 on a copy of the prefix, got %v, %d, %d", err, created.Vni, prefix.Vni)
+	}
+
+	prefix.Vni = 7
+	fake.getInterface = nil
+	if _, err := prefixes.Create(context.Background(), prefix, WithResolveVNI()); err != nil || created.Vni != 7 || len(fake.Calls()) != 4 {
+		t.Fatalf("expected the given VNI to be kept without a lookup, got %v, %d, calls %v", err, created.Vni, fake.Calls())
+	}
+}
+
+func TestPrefixesListAll(t *testing.T) {
+	fake := &fakeLegacy{
+		listInterfaces: interfaceList("vm1", "gone", "vm2", "broken"),
+		listPrefixes: func(_ context.Context, id string) (*api.PrefixList, error) {
+			switch id {
+			case "gone":
+				return &api.PrefixList{}, errors.NewStatusError(errors.NOT_FOUND, "not found")
+			case "broken":
+				return &api.PrefixList{}, errors.NewStatusError(errors.OUT_OF_MEMORY, "oom")
+			}
+			return &api.PrefixList{Items: []api.Prefix{
+				{Spec: api.PrefixSpec{Prefix: netip.MustParsePrefix("10.0.1.0/24")}},
+				{Spec: api.PrefixSpec{Prefix: netip.MustParsePrefix("10.0.2.0/24")}},
+			}}, nil
+		},
+	}
+
+	list, err := AsV2(fake).Interfaces().Prefixes().ListAll(context.Background(), WithConcurrency(2))
+	var bulkErr *BulkError
+	if !stderrors.As(err, &bulkErr) || len(bulkErr.Items) != 1 || bulkErr.Items[0].Index != 3 {
+		t.Fatalf("expected a bulk error for the broken interface, got %v", err)
+	}
+	var got []string
+	for _, prefix := range list.Items {
+		got = append(got, prefix.InterfaceID+" "+prefix.Spec.Prefix.String())
+	}
+	want := []string{"vm1 10.0.1.0/24", "vm1 10.0.2.0/24", "vm2 10.0.1.0/24", "vm2 10.0.2.0/24"}
+	if !reflect.DeepEqual(got, want) || list.Kind != api.PrefixListKind {
+		t.Fatalf("expected %v, got %v (kind %q)", want, got, list.Kind)
+	}
+}
+
+func TestInterfacesGetByDevice(t *testing.T) {
+	fake := &fakeLegacy{
+		listInterfaces: func(context.Context) (*api.InterfaceList, error) {
+			return &api.InterfaceList{Items: []api.Interface{
+				{InterfaceMeta: api.InterfaceMeta{ID: "vm1"}, Spec: api.InterfaceSpec{Device: "0000:3b:00.2"}},
+				{InterfaceMeta: api.InterfaceMeta{ID: "vm2"}, Spec: api.InterfaceSpec{Device: "0000:3b:00.3"}},
+				{InterfaceMeta: api.InterfaceMeta{ID: "vm3"}, Spec: api.InterfaceSpec{Device: "0000:3b:00.3"}},
+			}}, nil
+		},
+	}
+	ifaces := AsV2(fake).Interfaces()
+
+	if iface, err := ifaces.GetByDevice(context.Background(), "0000:3b:00.2"); err != nil || iface.ID != "vm1" {
+		t.Fatalf("expected vm1, got %v, %+v", err, iface)
+	}
+	var ambiguous *AmbiguousDeviceError
+	if _, err := ifaces.GetByDevice(context.Background(), "0000:3b:00.3"); !stderrors.As(err, &ambiguous) || !reflect.DeepEqual(ambiguous.InterfaceIDs, []string{"vm2", "vm3"}) {
+		t.Fatalf("expected an AmbiguousDeviceError for vm2 and vm3, got %v", err)
+	}
+	if _, err := ifaces.GetByDevice(context.Background(), "0000:3b:00.4"); !IsNotFound(err) {
+		t.Fatalf("expected NOT_FOUND for an unused device, got %v", err)
+	}
+	if iface, err := ifaces.GetByDevice(context.Background(), "0000:3b:00.4", WithIgnoredCodes(errors.NOT_FOUND)); err != nil || iface.Status.Code != errors.NOT_FOUND {
+		t.Fatalf("expected a NOT_FOUND status, got %v, %+v", err, iface)
+	}
+}
+
+func TestInterfacesGroupByVNI(t *testing.T) {
+	iface := func(id string, vni uint32) api.Interface {
+		return api.Interface{InterfaceMeta: api.InterfaceMeta{ID: id}, Spec: api.InterfaceSpec{VNI: vni}}
+	}
+	fake := &fakeLegacy{
+		listInterfaces: func(context.Context) (*api.InterfaceList, error) {
+			return &api.InterfaceList{Items: []api.Interface{iface("vm1", 100), iface("vm2", 200), iface("vm3", 100)}}, nil
+		},
+	}
+
+	groups, err := AsV2(fake).Interfaces().GroupByVNI(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := map[uint32][]string{}
+	for vni, list := range groups {
+		if list.Kind != api.InterfaceListKind {
+			t.Fatalf("expected a typed list for VNI %d, got %q", vni, list.Kind)
+		}
+		for _, iface := range list.Items {
+			ids[vni] = append(ids[vni], iface.ID)
+		}
+	}
+	if want := map[uint32][]string{100: {"vm1", "vm3"}, 200: {"vm2"}}; !reflect.DeepEqual(ids, want) {
+		t.Fatalf("expected %v, got %v", want, ids)
+	}
+	if len(fake.Calls()) != 1 {
+		t.Fatalf("expected a single List, got %v", fake.Calls())
+	}
+}
+
+func TestInterfacesCreateExclusive(t *testing.T) {
+	hostIP := netip.MustParseAddr("10.0.0.1")
+	fake := &fakeLegacy{
+		createInterface: func(context.Context, *api.Interface) (*api.Interface, error) {
+			return &api.Interface{}, errors.NewStatusError(errors.ALREADY_EXISTS, "exists")
+		},
+		getInterface: func(_ context.Context, id string) (*api.Interface, error) {
+			return &api.Interface{InterfaceMeta: api.InterfaceMeta{ID: id}, Spec: api.InterfaceSpec{VNI: 7, IPv4: &hostIP, HostName: "node-b"}}, nil
+		},
+	}
+	ifaces := AsV2(fake).Interfaces()
+
+	_, err := ifaces.CreateExclusive(context.Background(), &api.Interface{InterfaceMeta: api.InterfaceMeta{ID: "vm1"}})
+	conflict, ok := err.(*InterfaceConflictError)
+	if !ok || conflict.Existing.Spec.HostName != "node-b" || !IsAlreadyExists(err) {
+		t.Fatalf("expected a conflict with the interface of node-b, got %v", err)
+	}
+	if got, want := err.Error(), "interface vm1 already exists: Interface vm1 vni=7 ipv4=10.0.0.1 hostname=node-b"; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+
+	fake.getInterface = func(context.Context, string) (*api.Interface, error) {
+		return &api.Interface{}, errors.NewStatusError(errors.NOT_FOUND, "not found")
+	}
+	_, err = ifaces.CreateExclusive(context.Background(), &api.Interface{InterfaceMeta: api.InterfaceMeta{ID: "vm1"}})
+	if _, ok := err.(*InterfaceConflictError); ok || !IsAlreadyExists(err) {
+		t.Fatalf("expected the plain already-exists error once the interface is gone, got %v", err)
+	}
+
+	fake.createInterface = nil
+	if _, err := ifaces.CreateExclusive(context.Background(), &api.Interface{InterfaceMeta: api.InterfaceMeta{ID: "vm2"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
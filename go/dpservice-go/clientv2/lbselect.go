@@ -0,0 +1,200 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"net/netip"
+	"slices"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+)
+
+// FlowTuple identifies a flow sent to a load balancer.
+type FlowTuple struct {
+	SrcIP   netip.Addr
+	DstIP   netip.Addr
+	SrcPort uint16
+	DstPort uint16
+	// Protocol is the IANA protocol number, e.g. 6 for TCP.
+	Protocol uint8
+}
+
+// FlowHashFunc maps a flow to a slot of the Maglev lookup table of a load
+// balancer.
+type FlowHashFunc func(FlowTuple) uint32
+
+// WithFlowHash replaces DefaultFlowHash for LoadBalancers().SelectTarget.
+func WithFlowHash(h FlowHashFunc) CallOption {
+	return func(o *callOptions) {
+		o.targetHasher = func(f FlowTuple) uint64 { return uint64(h(f)) }
+	}
+}
+
+// WithTargetHasher is like WithFlowHash for 64-bit hashes: the target of a
+// flow is the Maglev table slot h(flow) modulo the table size. A fixed hasher
+// makes the selection reproducible in load-distribution tests and lets other
+// selection policies be tried client-side. The last of WithFlowHash and
+// WithTargetHasher given wins; without either, DefaultFlowHash is used.
+func WithTargetHasher(h func(flow FlowTuple) uint64) CallOption {
+	return func(o *callOptions) {
+		o.targetHasher = h
+	}
+}
+
+// DefaultFlowHash is the FNV-1a hash of the flow tuple. dpservice hashes its
+// internal conntrack key instead, which depends on the build and is not part
+// of the API, so targets selected with DefaultFlowHash show how a set of
+// flows is spread rather than which target a given flow gets on the server.
+func DefaultFlowHash(f FlowTuple) uint32 {
+	h := fnv.New32a()
+	src, dst := f.SrcIP.As16(), f.DstIP.As16()
+	h.Write(src[:])
+	h.Write(dst[:])
+	var rest [5]byte
+	binary.BigEndian.PutUint16(rest[0:], f.SrcPort)
+	binary.BigEndian.PutUint16(rest[2:], f.DstPort)
+	rest[4] = f.Protocol
+	h.Write(rest[:])
+	return h.Sum32()
+}
+
+func (c *lbClient) SelectTarget(ctx context.Context, lbID string, flow FlowTuple, opts ...CallOption) (*api.LoadBalancerTarget, error) {
+	if err := checkID(DomainLoadBalancers, "SelectTarget", "lbID", lbID); err != nil {
+		return nil, err
+	}
+	lb, err := c.Get(ctx, lbID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if lb.Status.Code != 0 {
+		return &api.LoadBalancerTarget{Status: lb.Status}, nil
+	}
+	if !lbPortMatch(lb.Spec.Lbports, flow) {
+		return c.noTarget(opts, fmt.Sprintf("loadbalancer %s does not balance protocol %d port %d", lbID, flow.Protocol, flow.DstPort))
+	}
+
+	targets, err := c.Targets().List(ctx, lbID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	backends := make([]api.LoadBalancerTarget, 0, len(targets.Items))
+	for _, target := range targets.Items {
+		if target.Spec.TargetIP != nil {
+			backends = append(backends, target)
+		}
+	}
+	if len(backends) == 0 {
+		return c.noTarget(opts, fmt.Sprintf("loadbalancer %s has no targets", lbID))
+	}
+	// The server keeps the backends ordered by address.
+	slices.SortFunc(backends, func(a, b api.LoadBalancerTarget) int {
+		x, y := a.Spec.TargetIP.As16(), b.Spec.TargetIP.As16()
+		return bytes.Compare(x[:], y[:])
+	})
+
+	hash := func(f FlowTuple) uint64 { return uint64(DefaultFlowHash(f)) }
+	if h := c.callOptions(opts).targetHasher; h != nil {
+		hash = h
+	}
+	table := maglevTable(backends)
+	return &backends[table[hash(flow)%maglevTableSize]], nil
+}
+
+func (c *lbClient) noTarget(opts []CallOption, msg string) (*api.LoadBalancerTarget, error) {
+	status, err := c.notFound(DomainLoadBalancers, "SelectTarget", opts, msg)
+	if err != nil {
+		return nil, err
+	}
+	return &api.LoadBalancerTarget{Status: status}, nil
+}
+
+func lbPortMatch(ports []api.LBPort, flow FlowTuple) bool {
+	for _, port := range ports {
+		if port.Port == uint32(flow.DstPort) && port.Protocol == uint32(flow.Protocol) {
+			return true
+		}
+	}
+	return false
+}
+
+// maglevTableSize is the size of the lookup table of dpservice
+// (DP_LB_MAGLEV_LOOKUP_SIZE), a prime well above the maximum of 64 targets.
+const maglevTableSize = 503
+
+// maglevTable computes the Maglev lookup table of dpservice for the sorted
+// backends. Each slot holds the index of a backend.
+func maglevTable(backends []api.LoadBalancerTarget) []int {
+	offsets := make([]uint32, len(backends))
+	skips := make([]uint32, len(backends))
+	for i, backend := range backends {
+		ip := backend.Spec.TargetIP.As16()
+		offsets[i] = murmurHash2(ip) % maglevTableSize
+		skips[i] = djbHash(ip)%(maglevTableSize-1) + 1
+	}
+
+	table := make([]int, maglevTableSize)
+	for i := range table {
+		table[i] = -1
+	}
+	next := make([]uint32, len(backends))
+	filled := 0
+	for filled < maglevTableSize {
+		progress := false
+		for i := range backends {
+			for next[i] < maglevTableSize {
+				pos := (offsets[i] + next[i]*skips[i]) % maglevTableSize
+				next[i]++
+				if table[pos] == -1 {
+					table[pos] = i
+					filled++
+					progress = true
+					break
+				}
+			}
+			if filled == maglevTableSize {
+				return table
+			}
+		}
+		if !progress {
+			break
+		}
+	}
+	// Like the server, fill slots left free by exhausted permutations.
+	for i := range table {
+		if table[i] == -1 {
+			table[i] = i % len(backends)
+		}
+	}
+	return table
+}
+
+func murmurHash2(ip [16]byte) uint32 {
+	const m = 0x5bd1e995
+	h := uint32(len(ip))
+	for i := 0; i < len(ip); i += 4 {
+		k := binary.LittleEndian.Uint32(ip[i:])
+		k *= m
+		k ^= k >> 24
+		k *= m
+		h *= m
+		h ^= k
+	}
+	h ^= h >> 13
+	h *= m
+	h ^= h >> 15
+	return h
+}
+
+func djbHash(ip [16]byte) uint32 {
+	h := uint32(5381)
+	for _, b := range ip {
+		h = h<<5 + h + uint32(b)
+	}
+	return h &^ (1 << 31)
+}
@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+	dperrors "github.com/ironcore-dev/dpservice/go/dpservice-go/errors"
+)
+
+func lbTargets(ips ...string) *api.LoadBalancerTargetList {
+	list := &api.LoadBalancerTargetList{}
+	for _, ip := range ips {
+		addr := netip.MustParseAddr(ip)
+		list.Items = append(list.Items, api.LoadBalancerTarget{Spec: api.LoadBalancerTargetSpec{TargetIP: &addr}})
+	}
+	return list
+}
+
+func TestMaglevTable(t *testing.T) {
+	// Golden prefix computed with the lookup table code of the server.
+	table := maglevTable(lbTargets("2001:db8::1", "2001:db8::2", "2001:db8::3").Items)
+	var prefix strings.Builder
+	for _, i := range table[:20] {
+		fmt.Fprint(&prefix, i)
+	}
+	if got, want := prefix.String(), "00111112010011110002"; got != want {
+		t.Fatalf("expected table prefix %s, got %s", want, got)
+	}
+
+	counts := make([]int, 3)
+	for _, i := range table {
+		counts[i]++
+	}
+	for i, n := range counts {
+		if n < 160 || n > 176 {
+			t.Fatalf("expected an even spread, backend %d got %d of %d slots", i, n, len(table))
+		}
+	}
+}
+
+func TestLoadBalancersSelectTarget(t *testing.T) {
+	fake := &fakeLegacy{
+		getLoadBalancer: func(context.Context, string) (*api.LoadBalancer, error) {
+			return &api.LoadBalancer{Spec: api.LoadBalancerSpec{Lbports: []api.LBPort{{Protocol: 6, Port: 443}}}}, nil
+		},
+		listLoadBalancerTargets: func(context.Context, string) (*api.LoadBalancerTargetList, error) {
+			return lbTargets("2001:db8::3", "2001:db8::1", "2001:db8::2"), nil
+		},
+	}
+	lbs := AsV2(fake).LoadBalancers()
+	flow := FlowTuple{
+		SrcIP:    netip.MustParseAddr("10.0.0.1"),
+		DstIP:    netip.MustParseAddr("10.0.0.100"),
+		SrcPort:  40000,
+		DstPort:  443,
+		Protocol: 6,
+	}
+
+	// Slot 2 belongs to the second backend in address order.
+	target, err := lbs.SelectTarget(context.Background(), "lb1", flow, WithFlowHash(func(FlowTuple) uint32 { return 2 }))
+	if err != nil || target.Spec.TargetIP.String() != "2001:db8::2" {
+		t.Fatalf("expected 2001:db8::2, got %v, %v", target, err)
+	}
+
+	// The 64-bit hash is reduced modulo the table size; the last hash option wins.
+	target, err = lbs.SelectTarget(context.Background(), "lb1", flow,
+		WithFlowHash(func(FlowTuple) uint32 { return 0 }),
+		WithTargetHasher(func(FlowTuple) uint64 { return 1<<32*maglevTableSize + 2 }))
+	if err != nil || target.Spec.TargetIP.String() != "2001:db8::2" {
+		t.Fatalf("expected 2001:db8::2 with WithTargetHasher, got %v, %v", target, err)
+	}
+
+	a, errA := lbs.SelectTarget(context.Background(), "lb1", flow)
+	b, errB := lbs.SelectTarget(context.Background(), "lb1", flow)
+	if errA != nil || errB != nil || *a.Spec.TargetIP != *b.Spec.TargetIP {
+		t.Fatalf("expected a stable selection, got %v, %v and %v, %v", a, errA, b, errB)
+	}
+
+	flow.DstPort = 80
+	_, err = lbs.SelectTarget(context.Background(), "lb1", flow)
+	if !dperrors.IsStatusErrorCode(err, dperrors.NOT_FOUND) {
+		t.Fatalf("expected NOT_FOUND for an unbalanced port, got %v", err)
+	}
+
+	flow.DstPort = 443
+	fake.listLoadBalancerTargets = nil
+	_, err = lbs.SelectTarget(context.Background(), "lb1", flow)
+	if !dperrors.IsStatusErrorCode(err, dperrors.NOT_FOUND) {
+		t.Fatalf("expected NOT_FOUND without targets, got %v", err)
+	}
+}
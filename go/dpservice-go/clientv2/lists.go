@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import "github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+
+// normalizeList returns list results as a non-nil list with a non-nil, possibly
+// empty, Items slice so callers never have to nil-check them. Values of other
+// types are returned unchanged.
+func normalizeList(v any) any {
+	switch l := v.(type) {
+	case *api.LoadBalancerList:
+		if l == nil {
+			l = &api.LoadBalancerList{TypeMeta: api.TypeMeta{Kind: api.LoadBalancerListKind}}
+		}
+		if l.Items == nil {
+			l.Items = []api.LoadBalancer{}
+		}
+		return l
+	case *api.LoadBalancerTargetList:
+		if l == nil {
+			l = &api.LoadBalancerTargetList{TypeMeta: api.TypeMeta{Kind: api.LoadBalancerTargetListKind}}
+		}
+		if l.Items == nil {
+			l.Items = []api.LoadBalancerTarget{}
+		}
+		return l
+	case *api.PrefixList:
+		if l == nil {
+			l = &api.PrefixList{TypeMeta: api.TypeMeta{Kind: api.PrefixListKind}}
+		}
+		if l.Items == nil {
+			l.Items = []api.Prefix{}
+		}
+		return l
+	case *api.InterfaceList:
+		if l == nil {
+			l = &api.InterfaceList{TypeMeta: api.TypeMeta{Kind: api.InterfaceListKind}}
+		}
+		if l.Items == nil {
+			l.Items = []api.Interface{}
+		}
+		return l
+	case *api.RouteList:
+		if l == nil {
+			l = &api.RouteList{TypeMeta: api.TypeMeta{Kind: api.RouteListKind}}
+		}
+		if l.Items == nil {
+			l.Items = []api.Route{}
+		}
+		return l
+	case *api.NatList:
+		if l == nil {
+			l = &api.NatList{TypeMeta: api.TypeMeta{Kind: api.NatListKind}}
+		}
+		if l.Items == nil {
+			l.Items = []api.Nat{}
+		}
+		return l
+	case *api.FirewallRuleList:
+		if l == nil {
+			l = &api.FirewallRuleList{TypeMeta: api.TypeMeta{Kind: api.FirewallRuleListKind}}
+		}
+		if l.Items == nil {
+			l.Items = []api.FirewallRule{}
+		}
+		return l
+	}
+	return v
+}
@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+)
+
+// nilListLegacy returns nil lists from every list call.
+func nilListLegacy() *fakeLegacy {
+	return &fakeLegacy{
+		listLoadBalancers: func(context.Context) (*api.LoadBalancerList, error) { return nil, nil },
+		listLoadBalancerPrefixes: func(context.Context, string) (*api.PrefixList, error) {
+			return nil, nil
+		},
+		listLoadBalancerTargets: func(context.Context, string) (*api.LoadBalancerTargetList, error) {
+			return nil, nil
+		},
+		listInterfaces:    func(context.Context) (*api.InterfaceList, error) { return nil, nil },
+		listPrefixes:      func(context.Context, string) (*api.PrefixList, error) { return nil, nil },
+		listRoutes:        func(context.Context, uint32) (*api.RouteList, error) { return nil, nil },
+		listNats:          func(context.Context, *netip.Addr, string) (*api.NatList, error) { return nil, nil },
+		listLocalNats:     func(context.Context, *netip.Addr) (*api.NatList, error) { return nil, nil },
+		listNeighborNats:  func(context.Context, *netip.Addr) (*api.NatList, error) { return nil, nil },
+		listFirewallRules: func(context.Context, string) (*api.FirewallRuleList, error) { return nil, nil },
+	}
+}
+
+func TestListsAreNeverNil(t *testing.T) {
+	ctx := context.Background()
+	ip := netip.MustParseAddr("10.0.0.1")
+
+	for name, fake := range map[string]*fakeLegacy{
+		"nil lists":   nilListLegacy(),
+		"empty lists": {},
+	} {
+		v2 := AsV2(fake)
+		t.Run(name, func(t *testing.T) {
+			t.Run(DomainLoadBalancers, func(t *testing.T) {
+				l, err := v2.LoadBalancers().List(ctx)
+				if err != nil || l == nil || l.Items == nil {
+					t.Fatalf("expected non-nil list and items, got %+v, %v", l, err)
+				}
+			})
+			t.Run(DomainLoadBalancerPrefixes, func(t *testing.T) {
+				l, err := v2.LoadBalancers().Prefixes().List(ctx, "vm1")
+				if err != nil || l == nil || l.Items == nil {
+					t.Fatalf("expected non-nil list and items, got %+v, %v", l, err)
+				}
+			})
+			t.Run(DomainLoadBalancerTargets, func(t *testing.T) {
+				l, err := v2.LoadBalancers().Targets().List(ctx, "lb1")
+				if err != nil || l == nil || l.Items == nil {
+					t.Fatalf("expected non-nil list and items, got %+v, %v", l, err)
+				}
+			})
+			t.Run(DomainInterfaces, func(t *testing.T) {
+				l, err := v2.Interfaces().List(ctx)
+				if err != nil || l == nil || l.Items == nil {
+					t.Fatalf("expected non-nil list and items, got %+v, %v", l, err)
+				}
+			})
+			t.Run(DomainInterfacePrefixes, func(t *testing.T) {
+				l, err := v2.Interfaces().Prefixes().List(ctx, "vm1")
+				if err != nil || l == nil || l.Items == nil {
+					t.Fatalf("expected non-nil list and items, got %+v, %v", l, err)
+				}
+			})
+			t.Run(DomainRoutes, func(t *testing.T) {
+				l, err := v2.Routes().List(ctx, 100)
+				if err != nil || l == nil || l.Items == nil {
+					t.Fatalf("expected non-nil list and items, got %+v, %v", l, err)
+				}
+			})
+			t.Run(DomainNATs, func(t *testing.T) {
+				for _, list := range []func(context.Context, *netip.Addr, ...CallOption) (*api.NatList, error){
+					v2.NATs().ListAny, v2.NATs().ListLocal, v2.NATs().ListNeighbors,
+				} {
+					l, err := list(ctx, &ip)
+					if err != nil || l == nil || l.Items == nil {
+						t.Fatalf("expected non-nil list and items, got %+v, %v", l, err)
+					}
+				}
+			})
+			t.Run(DomainFirewall, func(t *testing.T) {
+				l, err := v2.Firewall().List(ctx, "vm1")
+				if err != nil || l == nil || l.Items == nil {
+					t.Fatalf("expected non-nil list and items, got %+v, %v", l, err)
+				}
+			})
+		})
+	}
+}
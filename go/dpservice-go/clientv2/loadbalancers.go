@@ -0,0 +1,273 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"slices"
+	"time"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+)
+
+// LoadBalancerDetail is the combined view of a load balancer, the prefixes
+// routed to it from an interface and its targets.
+type LoadBalancerDetail struct {
+	LoadBalancer *api.LoadBalancer
+	// Prefixes is nil if no interface was given or listing failed.
+	Prefixes *api.PrefixList
+	// Targets is nil if listing failed.
+	Targets *api.LoadBalancerTargetList
+
+	// PrefixesErr and TargetsErr hold the error of the respective section.
+	PrefixesErr error
+	TargetsErr  error
+}
+
+func (c *lbClient) Describe(ctx context.Context, lbID, interfaceID string, opts ...CallOption) (*LoadBalancerDetail, error) {
+	if err := checkID(DomainLoadBalancers, "Describe", "lbID", lbID); err != nil {
+		return nil, err
+	}
+	lb, err := c.Get(ctx, lbID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	detail := &LoadBalancerDetail{LoadBalancer: lb}
+
+	if interfaceID != "" {
+		prefixes, err := c.Prefixes().List(ctx, interfaceID, opts...)
+		if err != nil {
+			detail.PrefixesErr = err
+		} else {
+			detail.Prefixes = prefixes
+		}
+	}
+
+	targets, err := c.Targets().List(ctx, lbID, opts...)
+	if err != nil {
+		detail.TargetsErr = err
+	} else {
+		detail.Targets = targets
+	}
+	return detail, nil
+}
+
+// LBVerification is the result of LoadBalancers().VerifyProgrammed.
+type LBVerification struct {
+	LoadBalancer *api.LoadBalancer
+	// MissingTargets and ExtraTargets are the wanted targets the load
+	// balancer lacks and the targets it has that are not wanted.
+	MissingTargets []netip.Addr
+	ExtraTargets   []netip.Addr
+	// MissingPrefixes and ExtraPrefixes are the same for the loadbalancer
+	// prefixes of the interface.
+	MissingPrefixes []netip.Prefix
+	ExtraPrefixes   []netip.Prefix
+}
+
+// Programmed reports whether the load balancer has exactly the wanted
+// targets and prefixes.
+func (v *LBVerification) Programmed() bool {
+	return len(v.MissingTargets) == 0 && len(v.ExtraTargets) == 0 &&
+		len(v.MissingPrefixes) == 0 && len(v.ExtraPrefixes) == 0
+}
+
+func (c *lbClient) VerifyProgrammed(ctx context.Context, lbID, interfaceID string, wantTargets []netip.Addr, wantPrefixes []netip.Prefix, opts ...CallOption) (*LBVerification, error) {
+	if len(wantPrefixes) > 0 && interfaceID == "" {
+		return nil, &InvalidArgumentError{Domain: DomainLoadBalancers, Method: "VerifyProgrammed", Argument: "interfaceID", Reason: "ID is empty but prefixes are wanted"}
+	}
+	detail, err := c.Describe(ctx, lbID, interfaceID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	v := &LBVerification{LoadBalancer: detail.LoadBalancer}
+	if detail.LoadBalancer.Status.Code != 0 {
+		// The load balancer is missing and its error was ignored.
+		v.MissingTargets, v.MissingPrefixes = slices.Clone(wantTargets), slices.Clone(wantPrefixes)
+		return v, nil
+	}
+	if err := errors.Join(detail.TargetsErr, detail.PrefixesErr); err != nil {
+		return nil, err
+	}
+
+	var targets []netip.Addr
+	for _, target := range detail.Targets.Items {
+		if target.Spec.TargetIP != nil {
+			targets = append(targets, *target.Spec.TargetIP)
+		}
+	}
+	v.MissingTargets, v.ExtraTargets = setDiff(wantTargets, targets)
+
+	if detail.Prefixes != nil {
+		prefixes := make([]netip.Prefix, len(detail.Prefixes.Items))
+		for i, prefix := range detail.Prefixes.Items {
+			prefixes[i] = prefix.Spec.Prefix.Masked()
+		}
+		want := make([]netip.Prefix, len(wantPrefixes))
+		for i, prefix := range wantPrefixes {
+			want[i] = prefix.Masked()
+		}
+		v.MissingPrefixes, v.ExtraPrefixes = setDiff(want, prefixes)
+	}
+	return v, nil
+}
+
+// setDiff returns the elements of want missing from have and those of have
+// not in want, each in input order.
+func setDiff[T comparable](want, have []T) (missing, extra []T) {
+	for _, w := range want {
+		if !slices.Contains(have, w) {
+			missing = append(missing, w)
+		}
+	}
+	for _, h := range have {
+		if !slices.Contains(want, h) {
+			extra = append(extra, h)
+		}
+	}
+	return missing, extra
+}
+
+// ErrNoTargetWeights is the cause of the *NotSupportedError returned by
+// LoadBalancers().Targets().SetWeight.
+var ErrNoTargetWeights = errors.New("dpservice balances load balancer targets evenly and has no target weights")
+
+func (c *lbTargetsClient) SetWeight(_ context.Context, lbID string, targetIP netip.Addr, _ uint32, _ ...CallOption) (*api.LoadBalancerTarget, error) {
+	if err := checkID(DomainLoadBalancerTargets, "SetWeight", "lbID", lbID); err != nil {
+		return nil, err
+	}
+	if err := checkAddr(DomainLoadBalancerTargets, "SetWeight", "targetIP", &targetIP); err != nil {
+		return nil, err
+	}
+	return nil, &NotSupportedError{Domain: DomainLoadBalancerTargets, Method: "SetWeight", Err: ErrNoTargetWeights}
+}
+
+func (c *lbTargetsClient) Get(ctx context.Context, lbID string, targetIP netip.Addr, opts ...CallOption) (*api.LoadBalancerTarget, error) {
+	if err := checkID(DomainLoadBalancerTargets, "Get", "lbID", lbID); err != nil {
+		return nil, err
+	}
+	if err := c.callOptions(opts).requireServerSide(DomainLoadBalancerTargets, "Get"); err != nil {
+		return nil, err
+	}
+	targets, err := c.List(ctx, lbID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	for i := range targets.Items {
+		if ip := targets.Items[i].Spec.TargetIP; ip != nil && *ip == targetIP {
+			return &targets.Items[i], nil
+		}
+	}
+
+	status, err := c.notFound(DomainLoadBalancerTargets, "Get", opts, fmt.Sprintf("target %s not found on loadbalancer %s", targetIP, lbID))
+	if err != nil {
+		return nil, err
+	}
+	return &api.LoadBalancerTarget{Status: status}, nil
+}
+
+func (c *lbPrefixesClient) Get(ctx context.Context, interfaceID string, prefix netip.Prefix, opts ...CallOption) (*api.LoadBalancerPrefix, error) {
+	if err := checkID(DomainLoadBalancerPrefixes, "Get", "interfaceID", interfaceID); err != nil {
+		return nil, err
+	}
+	if err := c.callOptions(opts).requireServerSide(DomainLoadBalancerPrefixes, "Get"); err != nil {
+		return nil, err
+	}
+	prefixes, err := c.List(ctx, interfaceID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range prefixes.Items {
+		if p.Spec.Prefix == prefix {
+			return &api.LoadBalancerPrefix{
+				TypeMeta:               api.TypeMeta{Kind: api.LoadBalancerPrefixKind},
+				LoadBalancerPrefixMeta: api.LoadBalancerPrefixMeta{InterfaceID: interfaceID},
+				Spec:                   api.LoadBalancerPrefixSpec{Prefix: p.Spec.Prefix, UnderlayRoute: p.Spec.UnderlayRoute},
+				Status:                 p.Status,
+			}, nil
+		}
+	}
+
+	status, err := c.notFound(DomainLoadBalancerPrefixes, "Get", opts, fmt.Sprintf("loadbalancer prefix %s not found on interface %s", prefix, interfaceID))
+	if err != nil {
+		return nil, err
+	}
+	return &api.LoadBalancerPrefix{Status: status}, nil
+}
+
+func (c *lbPrefixesClient) Exists(ctx context.Context, interfaceID string, prefix netip.Prefix, opts ...CallOption) (bool, error) {
+	return exists(c.Get(ctx, interfaceID, prefix, opts...))
+}
+
+func (c *lbClient) WaitFor(ctx context.Context, id string, pred func(*api.LoadBalancer) bool, poll time.Duration, opts ...CallOption) (*api.LoadBalancer, error) {
+	return waitFor(ctx, poll, "loadbalancer "+id, func(ctx context.Context) (*api.LoadBalancer, error) {
+		return c.Get(ctx, id, opts...)
+	}, pred)
+}
+
+// LBWithInterface pairs a load balancer with the interface routing its
+// virtual IP, see LoadBalancers.ListWithInterfaces.
+type LBWithInterface struct {
+	LoadBalancer api.LoadBalancer
+	// Interface is nil if no interface has a loadbalancer prefix covering
+	// the virtual IP of the load balancer.
+	Interface *api.Interface
+}
+
+func (c *lbClient) ListWithInterfaces(ctx context.Context, opts ...CallOption) ([]LBWithInterface, error) {
+	o := c.callOptions(opts)
+	ctx, cancel := o.bulkContext(ctx)
+	defer cancel()
+
+	if err := contextDone(ctx, DomainLoadBalancers+".ListWithInterfaces"); err != nil {
+		return nil, err
+	}
+
+	itemOpts := bulkItemOptions(opts)
+	lbs, err := c.List(ctx, itemOpts...)
+	if err != nil {
+		return nil, err
+	}
+	ifaces, err := (&ifaceClient{core: c.core}).List(ctx, itemOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	prefixes := make([][]api.Prefix, len(ifaces.Items))
+	bulkErr := runBulk(ctx, len(ifaces.Items), o, func(ctx context.Context, i int) error {
+		list, err := c.Prefixes().List(ctx, ifaces.Items[i].ID, itemOpts...)
+		switch {
+		case IsNotFound(err):
+			return nil
+		case err != nil:
+			return err
+		}
+		prefixes[i] = list.Items
+		return nil
+	})
+
+	result := make([]LBWithInterface, len(lbs.Items))
+	for i, lb := range lbs.Items {
+		result[i].LoadBalancer = lb
+		if lb.Spec.LbVipIP == nil {
+			continue
+		}
+		for j := range ifaces.Items {
+			iface := &ifaces.Items[j]
+			if iface.Spec.VNI == lb.Spec.VNI && slices.ContainsFunc(prefixes[j], func(p api.Prefix) bool {
+				return p.Spec.Prefix.Contains(*lb.Spec.LbVipIP)
+			}) {
+				result[i].Interface = iface
+				break
+			}
+		}
+	}
+	if bulkErr != nil {
+		return result, bulkErr
+	}
+	return result, nil
+}
@@ -0,0 +1,180 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	stderrors "errors"
+	"net/netip"
+	"testing"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/errors"
+)
+
+func TestLoadBalancersDescribe(t *testing.T) {
+	target := netip.MustParseAddr("fc00::1")
+	fake := &fakeLegacy{
+		getLoadBalancer: func(_ context.Context, id string) (*api.LoadBalancer, error) {
+			return &api.LoadBalancer{LoadBalancerMeta: api.LoadBalancerMeta{ID: id}}, nil
+		},
+		listLoadBalancerPrefixes: func(context.Context, string) (*api.PrefixList, error) {
+			return &api.PrefixList{}, errors.NewStatusError(errors.NO_VM, "no vm")
+		},
+		listLoadBalancerTargets: func(_ context.Context, id string) (*api.LoadBalancerTargetList, error) {
+			return &api.LoadBalancerTargetList{Items: []api.LoadBalancerTarget{
+				{Spec: api.LoadBalancerTargetSpec{TargetIP: &target}},
+			}}, nil
+		},
+	}
+
+	detail, err := AsV2(fake).LoadBalancers().Describe(context.Background(), "lb1", "vm1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if detail.LoadBalancer.ID != "lb1" {
+		t.Fatalf("unexpected load balancer %+v", detail.LoadBalancer)
+	}
+	if detail.Prefixes != nil || !IsNotFound(detail.PrefixesErr) {
+		t.Fatalf("expected prefix section to carry the error, got %+v, %v", detail.Prefixes, detail.PrefixesErr)
+	}
+	if detail.TargetsErr != nil || len(detail.Targets.Items) != 1 {
+		t.Fatalf("expected one target, got %+v, %v", detail.Targets, detail.TargetsErr)
+	}
+
+	fake.getLoadBalancer = func(context.Context, string) (*api.LoadBalancer, error) {
+		return &api.LoadBalancer{}, errors.NewStatusError(errors.NOT_FOUND, "not found")
+	}
+	if _, err := AsV2(fake).LoadBalancers().Describe(context.Background(), "lb1", ""); !IsNotFound(err) {
+		t.Fatalf("expected load balancer error to fail the call, got %v", err)
+	}
+}
+
+func TestLoadBalancerTargetsGet(t *testing.T) {
+	t1, t2 := netip.MustParseAddr("fc00::1"), netip.MustParseAddr("fc00::2")
+	fake := &fakeLegacy{
+		listLoadBalancerTargets: func(_ context.Context, id string) (*api.LoadBalancerTargetList, error) {
+			return &api.LoadBalancerTargetList{Items: []api.LoadBalancerTarget{
+				{LoadBalancerTargetMeta: api.LoadBalancerTargetMeta{LoadbalancerID: id}, Spec: api.LoadBalancerTargetSpec{TargetIP: &t1}},
+				{LoadBalancerTargetMeta: api.LoadBalancerTargetMeta{LoadbalancerID: id}, Spec: api.LoadBalancerTargetSpec{TargetIP: &t2}},
+			}}, nil
+		},
+	}
+	targets := AsV2(fake).LoadBalancers().Targets()
+
+	target, err := targets.Get(context.Background(), "lb1", t2)
+	if err != nil || *target.Spec.TargetIP != t2 {
+		t.Fatalf("expected target %s, got %+v, %v", t2, target, err)
+	}
+
+	missing := netip.MustParseAddr("fc00::3")
+	if _, err := targets.Get(context.Background(), "lb1", missing); !IsNotFound(err) {
+		t.Fatalf("expected NotFound, got %v", err)
+	}
+	target, err = targets.Get(context.Background(), "lb1", missing, WithIgnoredCodes(errors.NOT_FOUND))
+	if err != nil || target.Status.Code != errors.NOT_FOUND {
+		t.Fatalf("expected an ignored NOT_FOUND status, got %+v, %v", target, err)
+	}
+}
+
+func TestLoadBalancersListWithInterfaces(t *testing.T) {
+	vip1, vip2 := netip.MustParseAddr("45.86.6.6"), netip.MustParseAddr("45.86.6.7")
+	fake := &fakeLegacy{
+		listLoadBalancers: func(context.Context) (*api.LoadBalancerList, error) {
+			return &api.LoadBalancerList{Items: []api.LoadBalancer{
+				{LoadBalancerMeta: api.LoadBalancerMeta{ID: "lb1"}, Spec: api.LoadBalancerSpec{VNI: 100, LbVipIP: &vip1}},
+				{LoadBalancerMeta: api.LoadBalancerMeta{ID: "lb2"}, Spec: api.LoadBalancerSpec{VNI: 100, LbVipIP: &vip2}},
+			}}, nil
+		},
+		listInterfaces: func(context.Context) (*api.InterfaceList, error) {
+			return &api.InterfaceList{Items: []api.Interface{
+				{InterfaceMeta: api.InterfaceMeta{ID: "gone"}, Spec: api.InterfaceSpec{VNI: 100}},
+				{InterfaceMeta: api.InterfaceMeta{ID: "other-vni"}, Spec: api.InterfaceSpec{VNI: 200}},
+				{InterfaceMeta: api.InterfaceMeta{ID: "vm1"}, Spec: api.InterfaceSpec{VNI: 100}},
+			}}, nil
+		},
+		listLoadBalancerPrefixes: func(_ context.Context, id string) (*api.PrefixList, error) {
+			if id == "gone" {
+				return &api.PrefixList{}, errors.NewStatusError(errors.NOT_FOUND, "not found")
+			}
+			return &api.PrefixList{Items: []api.Prefix{{Spec: api.PrefixSpec{Prefix: netip.MustParsePrefix("45.86.6.6/32")}}}}, nil
+		},
+	}
+
+	lbs, err := AsV2(fake).LoadBalancers().ListWithInterfaces(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(lbs) != 2 || lbs[0].Interface == nil || lbs[0].Interface.ID != "vm1" {
+		t.Fatalf("expected lb1 to be routed by vm1, got %+v", lbs)
+	}
+	if lbs[1].Interface != nil {
+		t.Fatalf("expected no interface for lb2, got %+v", lbs[1].Interface)
+	}
+}
+
+func TestLoadBalancerTargetsSetWeight(t *testing.T) {
+	fake := &fakeLegacy{}
+	targets := AsV2(fake).LoadBalancers().Targets()
+
+	_, err := targets.SetWeight(context.Background(), "lb1", netip.MustParseAddr("fc00::1"), 10)
+	var notSupported *NotSupportedError
+	if !stderrors.As(err, &notSupported) || !stderrors.Is(err, ErrNoTargetWeights) {
+		t.Fatalf("expected NotSupportedError wrapping ErrNoTargetWeights, got %v", err)
+	}
+	var invalid *InvalidArgumentError
+	if _, err := targets.SetWeight(context.Background(), "lb1", netip.Addr{}, 10); !stderrors.As(err, &invalid) {
+		t.Fatalf("expected InvalidArgumentError, got %v", err)
+	}
+	if len(fake.Calls()) != 0 {
+		t.Fatalf("expected no RPC, got %v", fake.Calls())
+	}
+}
+
+func TestLoadBalancersVerifyProgrammed(t *testing.T) {
+	t1, t2, t3 := netip.MustParseAddr("fc00::1"), netip.MustParseAddr("fc00::2"), netip.MustParseAddr("fc00::3")
+	p1, p2 := netip.MustParsePrefix("10.0.0.0/24"), netip.MustParsePrefix("10.0.1.0/24")
+	fake := &fakeLegacy{
+		getLoadBalancer: func(_ context.Context, id string) (*api.LoadBalancer, error) {
+			return &api.LoadBalancer{LoadBalancerMeta: api.LoadBalancerMeta{ID: id}}, nil
+		},
+		listLoadBalancerPrefixes: func(context.Context, string) (*api.PrefixList, error) {
+			return &api.PrefixList{Items: []api.Prefix{{Spec: api.PrefixSpec{Prefix: p1}}, {Spec: api.PrefixSpec{Prefix: p2}}}}, nil
+		},
+		listLoadBalancerTargets: func(context.Context, string) (*api.LoadBalancerTargetList, error) {
+			return &api.LoadBalancerTargetList{Items: []api.LoadBalancerTarget{
+				{Spec: api.LoadBalancerTargetSpec{TargetIP: &t1}},
+				{Spec: api.LoadBalancerTargetSpec{TargetIP: &t3}},
+			}}, nil
+		},
+	}
+	lbs := AsV2(fake).LoadBalancers()
+
+	v, err := lbs.VerifyProgrammed(context.Background(), "lb1", "vm1", []netip.Addr{t1, t2}, []netip.Prefix{netip.MustParsePrefix("10.0.0.1/24")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v.Programmed() || len(v.MissingTargets) != 1 || v.MissingTargets[0] != t2 || len(v.ExtraTargets) != 1 || v.ExtraTargets[0] != t3 {
+		t.Fatalf("unexpected target verification %+v", v)
+	}
+	if len(v.MissingPrefixes) != 0 || len(v.ExtraPrefixes) != 1 || v.ExtraPrefixes[0] != p2 {
+		t.Fatalf("unexpected prefix verification %+v", v)
+	}
+
+	v, err = lbs.VerifyProgrammed(context.Background(), "lb1", "vm1", []netip.Addr{t3, t1}, []netip.Prefix{p2, p1})
+	if err != nil || !v.Programmed() {
+		t.Fatalf("expected the load balancer to be programmed, got %+v, %v", v, err)
+	}
+
+	fake.listLoadBalancerTargets = func(context.Context, string) (*api.LoadBalancerTargetList, error) {
+		return &api.LoadBalancerTargetList{}, stderrors.New("boom")
+	}
+	if _, err := lbs.VerifyProgrammed(context.Background(), "lb1", "", nil, nil); err == nil {
+		t.Fatal("expected a failing target list to fail the verification")
+	}
+	var argErr *InvalidArgumentError
+	if _, err := lbs.VerifyProgrammed(context.Background(), "lb1", "", nil, []netip.Prefix{p1}); !stderrors.As(err, &argErr) {
+		t.Fatalf("expected an *InvalidArgumentError for prefixes without interface, got %v", err)
+	}
+}
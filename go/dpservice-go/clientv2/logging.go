@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// WithLogger makes calls log their outcome to l: completed calls at debug
+// level and failed calls at error level, with the domain, method and duration
+// as attributes. A logger attached with ContextWithLogger takes precedence.
+func WithLogger(l *slog.Logger) CallOption {
+	return func(o *callOptions) {
+		o.logger = l
+	}
+}
+
+type loggerKey struct{}
+
+// ContextWithLogger returns a copy of ctx carrying l. Calls made with the
+// returned context log to l instead of the logger configured with WithLogger,
+// which allows request-scoped attributes without cloning the client.
+func ContextWithLogger(ctx context.Context, l *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, l)
+}
+
+// loggerFor returns the logger for a call made with ctx, or nil if logging is
+// disabled.
+func (o callOptions) loggerFor(ctx context.Context) *slog.Logger {
+	if l, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok && l != nil {
+		return l
+	}
+	return o.logger
+}
+
+func logCall(ctx context.Context, l *slog.Logger, domain, method string, took time.Duration, err error) {
+	if l == nil {
+		return
+	}
+	attrs := []slog.Attr{
+		slog.String("domain", domain),
+		slog.String("method", method),
+		slog.Duration("duration", took),
+	}
+	if err != nil {
+		l.LogAttrs(ctx, slog.LevelError, "dpservice call failed", append(attrs, slog.Any("error", err))...)
+		return
+	}
+	l.LogAttrs(ctx, slog.LevelDebug, "dpservice call", attrs...)
+}
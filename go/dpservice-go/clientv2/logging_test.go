@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+	dperrors "github.com/ironcore-dev/dpservice/go/dpservice-go/errors"
+)
+
+func bufferLogger() (*slog.Logger, *bytes.Buffer) {
+	var buf bytes.Buffer
+	return slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})), &buf
+}
+
+func TestWithLogger(t *testing.T) {
+	fake := &fakeLegacy{
+		getInterface: func(context.Context, string) (*api.Interface, error) {
+			return &api.Interface{}, dperrors.NewStatusError(dperrors.NOT_FOUND, "not found")
+		},
+	}
+	clientLogger, clientBuf := bufferLogger()
+	c := AsV2(fake, WithLogger(clientLogger))
+
+	_, _ = c.Routes().List(context.Background(), 1)
+	_, _ = c.Interfaces().Get(context.Background(), "vm1")
+	out := clientBuf.String()
+	if !strings.Contains(out, "level=DEBUG msg=\"dpservice call\" domain=Routes method=List") {
+		t.Fatalf("expected a debug line for Routes.List, got %q", out)
+	}
+	if !strings.Contains(out, "level=ERROR msg=\"dpservice call failed\" domain=Interfaces method=Get") {
+		t.Fatalf("expected an error line for Interfaces.Get, got %q", out)
+	}
+
+	// A context logger replaces the client logger for calls made with it.
+	ctxLogger, ctxBuf := bufferLogger()
+	clientBuf.Reset()
+	ctx := ContextWithLogger(context.Background(), ctxLogger.With("reconcile", "r-1"))
+	_, _ = c.Routes().List(ctx, 1)
+	if clientBuf.Len() != 0 {
+		t.Fatalf("expected no output on the client logger, got %q", clientBuf.String())
+	}
+	if !strings.Contains(ctxBuf.String(), "reconcile=r-1") {
+		t.Fatalf("expected the context logger attributes, got %q", ctxBuf.String())
+	}
+}
@@ -0,0 +1,35 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"slices"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+	dperrors "github.com/ironcore-dev/dpservice/go/dpservice-go/errors"
+)
+
+// notFound is the outcome of a list-and-match lookup without a match. Like
+// the legacy client, it yields a NOT_FOUND status error, or only the status
+// if NOT_FOUND is ignored for the call.
+func (c *core) notFound(domain, method string, opts []CallOption, msg string) (api.Status, error) {
+	if o := c.callOptions(opts); slices.Contains(o.ignoredCodes, dperrors.NOT_FOUND) {
+		if o.onIgnored != nil {
+			o.onIgnored(domain, method, dperrors.NOT_FOUND)
+		}
+		return api.Status{Code: dperrors.NOT_FOUND, Message: msg}, nil
+	}
+	return api.Status{}, dperrors.NewStatusError(dperrors.NOT_FOUND, msg)
+}
+
+// exists maps the result of a Get to whether the resource exists.
+func exists(obj api.Object, err error) (bool, error) {
+	if IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return obj.GetStatus().Code == 0, nil
+}
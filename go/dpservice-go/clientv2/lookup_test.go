@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+	dperrors "github.com/ironcore-dev/dpservice/go/dpservice-go/errors"
+)
+
+func TestExists(t *testing.T) {
+	have := netip.MustParsePrefix("10.0.0.0/24")
+	missing := netip.MustParsePrefix("10.0.1.0/24")
+	prefixes := func(context.Context, string) (*api.PrefixList, error) {
+		return &api.PrefixList{Items: []api.Prefix{{Spec: api.PrefixSpec{Prefix: have}}}}, nil
+	}
+	fake := &fakeLegacy{
+		listPrefixes:             prefixes,
+		listLoadBalancerPrefixes: prefixes,
+		listRoutes: func(context.Context, uint32) (*api.RouteList, error) {
+			return &api.RouteList{Items: []api.Route{testRoute("10.0.0.0/24", 100, "fc00::1")}}, nil
+		},
+	}
+	c := AsV2(fake)
+	ctx := context.Background()
+
+	checks := map[string]func(netip.Prefix, ...CallOption) (bool, error){
+		"Interfaces.Prefixes": func(p netip.Prefix, opts ...CallOption) (bool, error) {
+			return c.Interfaces().Prefixes().Exists(ctx, "vm1", p, opts...)
+		},
+		"LoadBalancers.Prefixes": func(p netip.Prefix, opts ...CallOption) (bool, error) {
+			return c.LoadBalancers().Prefixes().Exists(ctx, "vm1", p, opts...)
+		},
+		"Routes": func(p netip.Prefix, opts ...CallOption) (bool, error) {
+			return c.Routes().Exists(ctx, 100, p, opts...)
+		},
+	}
+	for name, exists := range checks {
+		if ok, err := exists(have); err != nil || !ok {
+			t.Errorf("%s: expected %s to exist, got %v, %v", name, have, ok, err)
+		}
+		if ok, err := exists(missing); err != nil || ok {
+			t.Errorf("%s: expected %s to be missing, got %v, %v", name, missing, ok, err)
+		}
+		if ok, err := exists(missing, WithIgnoredCodes(dperrors.NOT_FOUND)); err != nil || ok {
+			t.Errorf("%s: expected %s to be missing with NOT_FOUND ignored, got %v, %v", name, missing, ok, err)
+		}
+	}
+
+	fake.listRoutes = func(context.Context, uint32) (*api.RouteList, error) {
+		return &api.RouteList{}, status.Error(codes.Unavailable, "down")
+	}
+	if _, err := c.Routes().Exists(ctx, 100, have); status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected the list error, got %v", err)
+	}
+}
@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// MetricsRecorder receives one observation per call, e.g. to feed a latency
+// histogram and an error counter.
+type MetricsRecorder interface {
+	// ObserveCall is called once a call has completed with the labels
+	// selected by WithMetricLabels and the error returned to the caller.
+	ObserveCall(ctx context.Context, labels []attribute.KeyValue, took time.Duration, err error)
+}
+
+// MetricLabelsFunc selects the labels of an observation. obj is the result
+// of the call, or nil if the call failed or returned no result.
+type MetricLabelsFunc func(domain, method string, obj any) []attribute.KeyValue
+
+// WithMetrics reports every call to r.
+func WithMetrics(r MetricsRecorder) CallOption {
+	return func(o *callOptions) {
+		o.metrics = r
+	}
+}
+
+// WithMetricLabels replaces the labels attached to observations of
+// WithMetrics, which default to the domain and method only. Labelling by
+// resource identity, e.g. with ResourceID, creates one series per resource,
+// so keep the label cardinality bounded.
+func WithMetricLabels(fn MetricLabelsFunc) CallOption {
+	return func(o *callOptions) {
+		o.metricLabels = fn
+	}
+}
+
+// DefaultMetricLabels labels observations by domain and method.
+func DefaultMetricLabels(domain, method string, _ any) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("domain", domain),
+		attribute.String("method", method),
+	}
+}
+
+func observeCall(ctx context.Context, o callOptions, domain, method string, res any, took time.Duration, err error) {
+	if o.metrics == nil {
+		return
+	}
+	labels := o.metricLabels
+	if labels == nil {
+		labels = DefaultMetricLabels
+	}
+	var obj any
+	if v := reflect.ValueOf(res); err == nil && v.IsValid() && !(v.Kind() == reflect.Pointer && v.IsNil()) {
+		obj = res
+	}
+	o.metrics.ObserveCall(ctx, labels(domain, method, obj), took, err)
+}
@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+	dperrors "github.com/ironcore-dev/dpservice/go/dpservice-go/errors"
+)
+
+type observation struct {
+	labels []attribute.KeyValue
+	err    error
+}
+
+type recorder struct{ observations []observation }
+
+func (r *recorder) ObserveCall(_ context.Context, labels []attribute.KeyValue, _ time.Duration, err error) {
+	r.observations = append(r.observations, observation{labels: labels, err: err})
+}
+
+func TestWithMetrics(t *testing.T) {
+	fake := &fakeLegacy{
+		getInterface: func(_ context.Context, id string) (*api.Interface, error) {
+			if id == "missing" {
+				return nil, dperrors.NewStatusError(dperrors.NOT_FOUND, "not found")
+			}
+			return &api.Interface{InterfaceMeta: api.InterfaceMeta{ID: id}, Spec: api.InterfaceSpec{VNI: 100}}, nil
+		},
+	}
+	rec := &recorder{}
+	c := AsV2(fake, WithMetrics(rec))
+
+	_, _ = c.Interfaces().Get(context.Background(), "vm1")
+	if got := rec.observations; len(got) != 1 || len(got[0].labels) != 2 ||
+		got[0].labels[0] != attribute.String("domain", DomainInterfaces) || got[0].labels[1] != attribute.String("method", "Get") {
+		t.Fatalf("expected one observation labelled by domain and method, got %+v", got)
+	}
+
+	var objs []any
+	vniLabel := WithMetricLabels(func(domain, method string, obj any) []attribute.KeyValue {
+		objs = append(objs, obj)
+		if iface, ok := obj.(*api.Interface); ok {
+			return []attribute.KeyValue{attribute.Int("vni", int(iface.Spec.VNI))}
+		}
+		return nil
+	})
+	rec.observations = nil
+	_, _ = c.Interfaces().Get(context.Background(), "vm1", vniLabel)
+	_, _ = c.Interfaces().Get(context.Background(), "missing", vniLabel)
+
+	if len(objs) != 2 || objs[1] != nil {
+		t.Fatalf("expected one label call per observation and a nil object on error, got %+v", objs)
+	}
+	if got := rec.observations; len(got) != 2 || got[0].labels[0] != attribute.Int("vni", 100) || got[1].err == nil {
+		t.Fatalf("unexpected observations %+v", got)
+	}
+}
@@ -0,0 +1,216 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+)
+
+// MigrateOptions configures Migrate.
+type MigrateOptions struct {
+	// CallOptions are applied to every call on both clients.
+	CallOptions []CallOption
+	// RouteVNIs lists additional VNIs whose routes are copied. The VNIs of
+	// all interfaces and load balancers are always included.
+	RouteVNIs []uint32
+}
+
+// MigrateResult is the outcome of copying a single resource.
+type MigrateResult struct {
+	// Domain is the kind of the resource, e.g. DomainInterfaces.
+	Domain string
+	// Name identifies the resource within its domain.
+	Name string
+	// Existed is set if the resource already existed on the destination.
+	Existed bool
+	// Err is set if the resource could not be read or created.
+	Err error
+}
+
+// MigrateReport lists the outcome of every resource handled by Migrate in
+// the order it was processed.
+type MigrateReport struct {
+	Results []MigrateResult
+}
+
+// Copied returns the results of resources that were created on the
+// destination.
+func (r *MigrateReport) Copied() []MigrateResult {
+	return r.filter(func(res MigrateResult) bool { return res.Err == nil && !res.Existed })
+}
+
+// Failed returns the results of resources that could not be migrated.
+func (r *MigrateReport) Failed() []MigrateResult {
+	return r.filter(func(res MigrateResult) bool { return res.Err != nil })
+}
+
+// Err joins the errors of all failed resources, or returns nil.
+func (r *MigrateReport) Err() error {
+	var errs []error
+	for _, res := range r.Failed() {
+		errs = append(errs, fmt.Errorf("%s %s: %w", res.Domain, res.Name, res.Err))
+	}
+	return errors.Join(errs...)
+}
+
+func (r *MigrateReport) filter(keep func(MigrateResult) bool) []MigrateResult {
+	var out []MigrateResult
+	for _, res := range r.Results {
+		if keep(res) {
+			out = append(out, res)
+		}
+	}
+	return out
+}
+
+// record adds the outcome of a create call and reports whether the resource
+// is now present on the destination.
+func (r *MigrateReport) record(domain, name string, err error) bool {
+	res := MigrateResult{Domain: domain, Name: name}
+	switch {
+	case IsAlreadyExists(err):
+		res.Existed = true
+	case err != nil:
+		res.Err = err
+	}
+	r.Results = append(r.Results, res)
+	return res.Err == nil
+}
+
+// Migrate copies load balancers with their targets, interfaces with their
+// prefixes, loadbalancer prefixes, virtual IPs, NATs and firewall rules, and
+// the routes of all involved VNIs from src to dst. Resources are created in
+// dependency order and resources that already exist on dst are skipped.
+// Dependents of an interface that could not be created are not attempted.
+// Resources are cleaned with ToCreateRequest, so underlay routes and other
+// server-assigned fields are assigned by dst and not copied.
+//
+// Failures of individual resources are recorded in the report, whose Err
+// method joins them. The returned error is only set if the load balancers or
+// interfaces of src cannot be listed.
+func Migrate(ctx context.Context, src, dst Client, opts MigrateOptions) (*MigrateReport, error) {
+	o := opts.CallOptions
+	report := &MigrateReport{}
+	vnis := slices.Clone(opts.RouteVNIs)
+
+	lbs, err := src.LoadBalancers().List(ctx, o...)
+	if err != nil {
+		return nil, fmt.Errorf("list loadbalancers: %w", err)
+	}
+	ifaces, err := src.Interfaces().List(ctx, o...)
+	if err != nil {
+		return nil, fmt.Errorf("list interfaces: %w", err)
+	}
+
+	for _, lb := range lbs.Items {
+		vnis = append(vnis, lb.Spec.VNI)
+		_, err := dst.LoadBalancers().Create(ctx, toCreate(&lb), o...)
+		if !report.record(DomainLoadBalancers, lb.ID, err) {
+			continue
+		}
+		migrateLoadBalancerTargets(ctx, src, dst, lb.ID, report, o)
+	}
+
+	for _, iface := range ifaces.Items {
+		vnis = append(vnis, iface.Spec.VNI)
+		_, err := dst.Interfaces().Create(ctx, toCreate(&iface), o...)
+		if !report.record(DomainInterfaces, iface.ID, err) {
+			continue
+		}
+		migrateInterfaceDependents(ctx, src, dst, iface.ID, report, o)
+	}
+
+	slices.Sort(vnis)
+	for _, vni := range slices.Compact(vnis) {
+		migrateRoutes(ctx, src, dst, vni, report, o)
+	}
+	return report, nil
+}
+
+func migrateLoadBalancerTargets(ctx context.Context, src, dst Client, lbID string, report *MigrateReport, o []CallOption) {
+	targets, err := src.LoadBalancers().Targets().List(ctx, lbID, o...)
+	if err != nil {
+		report.record(DomainLoadBalancerTargets, lbID, err)
+		return
+	}
+	for _, target := range targets.Items {
+		req := toCreate(&target)
+		req.LoadbalancerID = lbID
+		_, err := dst.LoadBalancers().Targets().Create(ctx, req, o...)
+		report.record(DomainLoadBalancerTargets, fmt.Sprintf("%s/%s", lbID, target.Spec.TargetIP), err)
+	}
+}
+
+func migrateInterfaceDependents(ctx context.Context, src, dst Client, ifaceID string, report *MigrateReport, o []CallOption) {
+	if prefixes, err := src.Interfaces().Prefixes().List(ctx, ifaceID, o...); err != nil {
+		report.record(DomainInterfacePrefixes, ifaceID, err)
+	} else {
+		for _, prefix := range prefixes.Items {
+			req := toCreate(&prefix)
+			req.InterfaceID = ifaceID
+			_, err := dst.Interfaces().Prefixes().Create(ctx, req, o...)
+			report.record(DomainInterfacePrefixes, ifaceID+"/"+prefix.Spec.Prefix.String(), err)
+		}
+	}
+
+	if prefixes, err := src.LoadBalancers().Prefixes().List(ctx, ifaceID, o...); err != nil {
+		report.record(DomainLoadBalancerPrefixes, ifaceID, err)
+	} else {
+		for _, prefix := range prefixes.Items {
+			_, err := dst.LoadBalancers().Prefixes().Create(ctx, &api.LoadBalancerPrefix{
+				LoadBalancerPrefixMeta: api.LoadBalancerPrefixMeta{InterfaceID: ifaceID},
+				Spec:                   api.LoadBalancerPrefixSpec{Prefix: prefix.Spec.Prefix},
+			}, o...)
+			report.record(DomainLoadBalancerPrefixes, ifaceID+"/"+prefix.Spec.Prefix.String(), err)
+		}
+	}
+
+	if vip, err := src.Interfaces().VIP().Get(ctx, ifaceID, o...); err == nil {
+		req := toCreate(vip)
+		req.InterfaceID = ifaceID
+		_, err := dst.Interfaces().VIP().Create(ctx, req, o...)
+		report.record(DomainVirtualIPs, ifaceID, err)
+	} else if !IsNotFound(err) {
+		report.record(DomainVirtualIPs, ifaceID, err)
+	}
+
+	if nat, err := src.NATs().Get(ctx, ifaceID, o...); err == nil {
+		req := toCreate(nat)
+		req.InterfaceID = ifaceID
+		_, err := dst.NATs().Create(ctx, req, o...)
+		report.record(DomainNATs, ifaceID, err)
+	} else if !IsNotFound(err) {
+		report.record(DomainNATs, ifaceID, err)
+	}
+
+	if rules, err := src.Firewall().List(ctx, ifaceID, o...); err != nil {
+		report.record(DomainFirewall, ifaceID, err)
+	} else {
+		for _, rule := range rules.Items {
+			req := toCreate(&rule)
+			req.InterfaceID = ifaceID
+			_, err := dst.Firewall().Create(ctx, req, o...)
+			report.record(DomainFirewall, ifaceID+"/"+rule.Spec.RuleID, err)
+		}
+	}
+}
+
+func migrateRoutes(ctx context.Context, src, dst Client, vni uint32, report *MigrateReport, o []CallOption) {
+	routes, err := src.Routes().List(ctx, vni, o...)
+	if err != nil {
+		report.record(DomainRoutes, fmt.Sprint(vni), err)
+		return
+	}
+	for _, route := range routes.Items {
+		req := toCreate(&route)
+		req.VNI = vni
+		_, err := dst.Routes().Create(ctx, req, o...)
+		report.record(DomainRoutes, fmt.Sprintf("%d/%s", vni, route.Spec.Prefix), err)
+	}
+}
@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"net/netip"
+	"slices"
+	"testing"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+	dperrors "github.com/ironcore-dev/dpservice/go/dpservice-go/errors"
+)
+
+func TestMigrate(t *testing.T) {
+	target := netip.MustParseAddr("fc00::1")
+	vip := netip.MustParseAddr("45.86.6.6")
+	src := &fakeLegacy{
+		listLoadBalancers: func(context.Context) (*api.LoadBalancerList, error) {
+			return &api.LoadBalancerList{Items: []api.LoadBalancer{
+				{LoadBalancerMeta: api.LoadBalancerMeta{ID: "lb1"}, Spec: api.LoadBalancerSpec{VNI: 200}},
+			}}, nil
+		},
+		listLoadBalancerTargets: func(context.Context, string) (*api.LoadBalancerTargetList, error) {
+			return &api.LoadBalancerTargetList{Items: []api.LoadBalancerTarget{{Spec: api.LoadBalancerTargetSpec{TargetIP: &target}}}}, nil
+		},
+		listInterfaces: func(context.Context) (*api.InterfaceList, error) {
+			return &api.InterfaceList{Items: []api.Interface{
+				{InterfaceMeta: api.InterfaceMeta{ID: "vm1"}, Spec: api.InterfaceSpec{VNI: 100}},
+				{InterfaceMeta: api.InterfaceMeta{ID: "vm2"}, Spec: api.InterfaceSpec{VNI: 100}},
+			}}, nil
+		},
+		getVirtualIP: func(_ context.Context, id string) (*api.VirtualIP, error) {
+			return &api.VirtualIP{VirtualIPMeta: api.VirtualIPMeta{InterfaceID: id}, Spec: api.VirtualIPSpec{IP: &vip}}, nil
+		},
+		getNat: func(context.Context, string) (*api.Nat, error) {
+			return &api.Nat{}, dperrors.NewStatusError(dperrors.SNAT_NO_DATA, "no nat")
+		},
+		listFirewallRules: func(context.Context, string) (*api.FirewallRuleList, error) {
+			return &api.FirewallRuleList{Items: []api.FirewallRule{{Spec: api.FirewallRuleSpec{RuleID: "r1"}}}}, nil
+		},
+		listRoutes: func(_ context.Context, vni uint32) (*api.RouteList, error) {
+			r := testRoute("10.0.0.0/24", vni, "fc00::1")
+			return &api.RouteList{Items: []api.Route{r}}, nil
+		},
+	}
+	dst := &fakeLegacy{
+		createInterface: func(_ context.Context, iface *api.Interface) (*api.Interface, error) {
+			if iface.ID == "vm2" {
+				return &api.Interface{}, dperrors.NewStatusError(dperrors.OUT_OF_MEMORY, "oom")
+			}
+			return iface, nil
+		},
+		createRoute: func(_ context.Context, route *api.Route) (*api.Route, error) {
+			if route.VNI == 200 {
+				return route, dperrors.NewStatusError(dperrors.ROUTE_EXISTS, "exists")
+			}
+			return route, nil
+		},
+	}
+
+	report, err := Migrate(context.Background(), AsV2(src), AsV2(dst), MigrateOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var copied []string
+	for _, res := range report.Copied() {
+		copied = append(copied, res.Domain+":"+res.Name)
+	}
+	want := []string{
+		"LoadBalancers:lb1",
+		"LoadBalancers.Targets:lb1/fc00::1",
+		"Interfaces:vm1",
+		"Interfaces.VIP:vm1",
+		"Firewall:vm1/r1",
+		"Routes:100/10.0.0.0/24",
+	}
+	if !slices.Equal(copied, want) {
+		t.Fatalf("expected copied %v, got %v", want, copied)
+	}
+
+	failed := report.Failed()
+	if len(failed) != 1 || failed[0].Name != "vm2" || !dperrors.IsStatusErrorCode(report.Err(), dperrors.OUT_OF_MEMORY) {
+		t.Fatalf("expected only vm2 to fail, got %+v", failed)
+	}
+	if i := slices.IndexFunc(report.Results, func(r MigrateResult) bool { return r.Domain == DomainRoutes && r.Existed }); i < 0 {
+		t.Fatalf("expected the existing route of VNI 200 to be skipped, got %+v", report.Results)
+	}
+	vipCreates := 0
+	for _, call := range dst.Calls() {
+		if call == "CreateVirtualIP" {
+			vipCreates++
+		}
+	}
+	if vipCreates != 1 {
+		t.Fatalf("expected no dependents of vm2 to be migrated, got %d VIP creates", vipCreates)
+	}
+}
@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+)
+
+func (c *natClient) ListByInterface(ctx context.Context, opts ...CallOption) (map[string]*api.Nat, error) {
+	o := c.callOptions(opts)
+	ctx, cancel := o.bulkContext(ctx)
+	defer cancel()
+
+	if err := contextDone(ctx, DomainNATs+".ListByInterface"); err != nil {
+		return nil, err
+	}
+
+	itemOpts := bulkItemOptions(opts)
+	ifaces, err := (&ifaceClient{core: c.core}).List(ctx, itemOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	nats := make([]*api.Nat, len(ifaces.Items))
+	bulkErr := runBulk(ctx, len(ifaces.Items), o, func(ctx context.Context, i int) error {
+		nat, err := c.Get(ctx, ifaces.Items[i].ID, itemOpts...)
+		switch {
+		case IsNotFound(err):
+			return nil
+		case err != nil:
+			return err
+		case nat.Status.Code == 0:
+			nats[i] = nat
+		}
+		return nil
+	})
+
+	byInterface := make(map[string]*api.Nat, len(ifaces.Items))
+	for i, iface := range ifaces.Items {
+		byInterface[iface.ID] = nats[i]
+	}
+	if bulkErr != nil {
+		return byInterface, bulkErr
+	}
+	return byInterface, nil
+}
+
+func (c *natClient) ListAllIPs(ctx context.Context, opts ...CallOption) ([]netip.Addr, error) {
+	nats, err := c.ListByInterface(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var ips []netip.Addr
+	for _, nat := range nats {
+		if nat != nil && nat.Spec.NatIP != nil {
+			ips = append(ips, *nat.Spec.NatIP)
+		}
+	}
+	slices.SortFunc(ips, netip.Addr.Compare)
+	return slices.Compact(ips), nil
+}
+
+func (c *natClient) WaitFor(ctx context.Context, interfaceID string, pred func(*api.Nat) bool, poll time.Duration, opts ...CallOption) (*api.Nat, error) {
+	return waitFor(ctx, poll, "NAT of interface "+interfaceID, func(ctx context.Context) (*api.Nat, error) {
+		return c.Get(ctx, interfaceID, opts...)
+	}, pred)
+}
+
+// AmbiguousNeighborNATError is returned by NATs().GetNeighborByRoute when
+// more than one neighbor NAT matches the underlay route.
+type AmbiguousNeighborNATError struct {
+	NatIP     netip.Addr
+	Underlay  netip.Prefix
+	Neighbors []*api.NeighborNat
+}
+
+func (e *AmbiguousNeighborNATError) Error() string {
+	ranges := make([]string, len(e.Neighbors))
+	for i, n := range e.Neighbors {
+		ranges[i] = fmt.Sprintf("ports %d-%d via %s", n.Spec.MinPort, n.Spec.MaxPort, n.Spec.UnderlayRoute)
+	}
+	return fmt.Sprintf("%d neighbor NATs of %s match underlay %s: %s", len(e.Neighbors), e.NatIP, e.Underlay, strings.Join(ranges, ", "))
+}
+
+func (c *natClient) GetNeighborByRoute(ctx context.Context, natIP netip.Addr, underlay netip.Prefix, opts ...CallOption) (*api.NeighborNat, error) {
+	if !underlay.IsValid() {
+		return nil, &InvalidArgumentError{Domain: DomainNATs, Method: "GetNeighborByRoute", Argument: "underlay", Reason: "prefix is not valid"}
+	}
+	if err := c.callOptions(opts).requireServerSide(DomainNATs, "GetNeighborByRoute"); err != nil {
+		return nil, err
+	}
+	nats, err := c.ListNeighbors(ctx, &natIP, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []*api.NeighborNat
+	for _, nat := range nats.Items {
+		if nat.Spec.UnderlayRoute == nil || !underlay.Contains(nat.Spec.UnderlayRoute.Unmap()) {
+			continue
+		}
+		matches = append(matches, &api.NeighborNat{
+			TypeMeta:        api.TypeMeta{Kind: api.NeighborNatKind},
+			NeighborNatMeta: api.NeighborNatMeta{NatIP: &natIP},
+			Spec: api.NeighborNatSpec{
+				Vni:           nat.Spec.Vni,
+				MinPort:       nat.Spec.MinPort,
+				MaxPort:       nat.Spec.MaxPort,
+				UnderlayRoute: nat.Spec.UnderlayRoute,
+			},
+		})
+	}
+	switch len(matches) {
+	case 0:
+		status, err := c.notFound(DomainNATs, "GetNeighborByRoute", opts, fmt.Sprintf("no neighbor NAT of %s routes via %s", natIP, underlay))
+		if err != nil {
+			return nil, err
+		}
+		return &api.NeighborNat{Status: status}, nil
+	case 1:
+		return matches[0], nil
+	}
+	return nil, &AmbiguousNeighborNATError{NatIP: natIP, Underlay: underlay, Neighbors: matches}
+}
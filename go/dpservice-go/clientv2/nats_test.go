@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"testing"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+	dperrors "github.com/ironcore-dev/dpservice/go/dpservice-go/errors"
+)
+
+func interfaceList(ids ...string) func(context.Context) (*api.InterfaceList, error) {
+	return func(context.Context) (*api.InterfaceList, error) {
+		list := &api.InterfaceList{}
+		for _, id := range ids {
+			list.Items = append(list.Items, api.Interface{InterfaceMeta: api.InterfaceMeta{ID: id}})
+		}
+		return list, nil
+	}
+}
+
+func TestNATsListByInterface(t *testing.T) {
+	natIP := netip.MustParseAddr("45.86.6.6")
+	fake := &fakeLegacy{
+		listInterfaces: interfaceList("vm1", "vm2", "vm3"),
+		getNat: func(_ context.Context, id string) (*api.Nat, error) {
+			switch id {
+			case "vm1":
+				return &api.Nat{NatMeta: api.NatMeta{InterfaceID: id}, Spec: api.NatSpec{NatIP: &natIP}}, nil
+			case "vm2":
+				return &api.Nat{}, dperrors.NewStatusError(dperrors.SNAT_NO_DATA, "no nat")
+			}
+			return &api.Nat{}, dperrors.NewStatusError(dperrors.OUT_OF_MEMORY, "oom")
+		},
+	}
+
+	nats, err := AsV2(fake).NATs().ListByInterface(context.Background(), WithConcurrency(2))
+	var bulkErr *BulkError
+	if !errors.As(err, &bulkErr) || len(bulkErr.Items) != 1 || bulkErr.Items[0].Index != 2 {
+		t.Fatalf("expected a BulkError for vm3, got %v", err)
+	}
+	if len(nats) != 3 || nats["vm1"] == nil || *nats["vm1"].Spec.NatIP != natIP {
+		t.Fatalf("expected NAT of vm1, got %+v", nats)
+	}
+	if nat, ok := nats["vm2"]; !ok || nat != nil {
+		t.Fatalf("expected vm2 to map to a nil NAT, got %+v", nat)
+	}
+
+	fake.listInterfaces = interfaceList("vm1", "vm2")
+	if _, err := AsV2(fake).NATs().ListByInterface(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestNATsListAllIPs(t *testing.T) {
+	ips := map[string]string{"vm1": "45.86.6.6", "vm2": "45.86.6.1", "vm3": "45.86.6.6"}
+	fake := &fakeLegacy{
+		listInterfaces: interfaceList("vm1", "vm2", "vm3", "vm4"),
+		getNat: func(_ context.Context, id string) (*api.Nat, error) {
+			ip, ok := ips[id]
+			if !ok {
+				return &api.Nat{}, dperrors.NewStatusError(dperrors.SNAT_NO_DATA, "no nat")
+			}
+			addr := netip.MustParseAddr(ip)
+			return &api.Nat{Spec: api.NatSpec{NatIP: &addr}}, nil
+		},
+	}
+
+	got, err := AsV2(fake).NATs().ListAllIPs(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []netip.Addr{netip.MustParseAddr("45.86.6.1"), netip.MustParseAddr("45.86.6.6")}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestNATsGetNeighborByRoute(t *testing.T) {
+	natIP := netip.MustParseAddr("45.86.6.6")
+	neighbor := func(underlay string, minPort, maxPort uint32) api.Nat {
+		addr := netip.MustParseAddr(underlay)
+		return api.Nat{Spec: api.NatSpec{NatIP: &natIP, UnderlayRoute: &addr, MinPort: minPort, MaxPort: maxPort, Vni: 100}}
+	}
+	fake := &fakeLegacy{
+		listNeighborNats: func(context.Context, *netip.Addr) (*api.NatList, error) {
+			return &api.NatList{Items: []api.Nat{
+				neighbor("fc00:1::1", 1000, 2000),
+				neighbor("fc00:2::1", 2000, 3000),
+				neighbor("fc00:2::2", 3000, 4000),
+			}}, nil
+		},
+	}
+	nats := AsV2(fake).NATs()
+	ctx := context.Background()
+
+	got, err := nats.GetNeighborByRoute(ctx, natIP, netip.MustParsePrefix("fc00:1::1/128"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Spec.MinPort != 1000 || got.Spec.MaxPort != 2000 || *got.NatIP != natIP || got.Kind != api.NeighborNatKind {
+		t.Errorf("unexpected neighbor NAT %+v", got)
+	}
+
+	_, err = nats.GetNeighborByRoute(ctx, natIP, netip.MustParsePrefix("fc00:2::/64"))
+	var ambiguous *AmbiguousNeighborNATError
+	if !errors.As(err, &ambiguous) || len(ambiguous.Neighbors) != 2 {
+		t.Errorf("expected AmbiguousNeighborNATError, got %v", err)
+	}
+
+	if _, err := nats.GetNeighborByRoute(ctx, natIP, netip.MustParsePrefix("fc00:3::/64")); !IsNotFound(err) {
+		t.Errorf("expected not found, got %v", err)
+	}
+	got, err = nats.GetNeighborByRoute(ctx, natIP, netip.MustParsePrefix("fc00:3::/64"), WithIgnoredCodes(dperrors.NOT_FOUND))
+	if err != nil || got.Status.Code != dperrors.NOT_FOUND {
+		t.Errorf("expected not found status, got %v, %v", got, err)
+	}
+}
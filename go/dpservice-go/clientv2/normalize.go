@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"net/netip"
+	"reflect"
+)
+
+// WithNormalizeAddresses makes Create and Delete methods send their
+// addresses and prefixes in the form dpservice expects: zones are dropped,
+// IPv4-mapped IPv6 addresses such as ::ffff:10.0.0.1 are sent as IPv4 and
+// prefixes are masked to their network address, e.g. 10.0.0.7/24 to
+// 10.0.0.0/24. Textual variants like leading zeros or uppercase hex cannot
+// reach the server, as netip.Addr has no such forms. The objects passed in
+// are not modified; a normalized copy is sent instead.
+func WithNormalizeAddresses() CallOption {
+	return func(o *callOptions) {
+		o.normalizeAddrs = true
+	}
+}
+
+var (
+	addrType   = reflect.TypeOf(netip.Addr{})
+	prefixType = reflect.TypeOf(netip.Prefix{})
+)
+
+// normalized returns obj under WithNormalizeAddresses, a copy of obj with
+// every address and prefix it references normalized.
+func normalized[T any](o callOptions, obj *T) *T {
+	if !o.normalizeAddrs || obj == nil {
+		return obj
+	}
+	cp := *obj
+	normalizeValue(reflect.ValueOf(&cp).Elem())
+	return &cp
+}
+
+// normalizeValue normalizes the addresses and prefixes of a settable value,
+// copying the pointed-to values and slices on the way so that the original
+// ones are left untouched.
+func normalizeValue(v reflect.Value) {
+	switch v.Type() {
+	case addrType:
+		v.Set(reflect.ValueOf(normalizeAddr(v.Interface().(netip.Addr))))
+		return
+	case prefixType:
+		v.Set(reflect.ValueOf(normalizePrefix(v.Interface().(netip.Prefix))))
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return
+		}
+		cp := reflect.New(v.Type().Elem())
+		cp.Elem().Set(v.Elem())
+		normalizeValue(cp.Elem())
+		v.Set(cp)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if field := v.Field(i); field.CanSet() {
+				normalizeValue(field)
+			}
+		}
+	case reflect.Slice:
+		if kind := v.Type().Elem().Kind(); v.IsNil() || kind != reflect.Struct && kind != reflect.Pointer {
+			return
+		}
+		cp := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		reflect.Copy(cp, v)
+		for i := 0; i < cp.Len(); i++ {
+			normalizeValue(cp.Index(i))
+		}
+		v.Set(cp)
+	}
+}
+
+func normalizeAddr(addr netip.Addr) netip.Addr {
+	return addr.WithZone("").Unmap()
+}
+
+func normalizePrefix(prefix netip.Prefix) netip.Prefix {
+	if !prefix.IsValid() {
+		return prefix
+	}
+	addr, bits := prefix.Addr(), prefix.Bits()
+	if addr.Is4In6() && bits >= 96 {
+		addr, bits = addr.Unmap(), bits-96
+	}
+	return netip.PrefixFrom(addr, bits).Masked()
+}
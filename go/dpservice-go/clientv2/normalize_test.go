@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+)
+
+func TestWithNormalizeAddresses(t *testing.T) {
+	var created *api.FirewallRule
+	var deleted *netip.Prefix
+	var iface *api.Interface
+	fake := &fakeLegacy{
+		createFirewallRule: func(_ context.Context, rule *api.FirewallRule) (*api.FirewallRule, error) {
+			created = rule
+			return rule, nil
+		},
+		deleteRoute: func(_ context.Context, _ uint32, prefix *netip.Prefix) (*api.Route, error) {
+			deleted = prefix
+			return &api.Route{}, nil
+		},
+		createInterface: func(_ context.Context, i *api.Interface) (*api.Interface, error) {
+			iface = i
+			return i, nil
+		},
+	}
+	c := AsV2(fake, WithNormalizeAddresses())
+
+	src := netip.MustParsePrefix("::ffff:10.0.0.7/120")
+	dst := netip.MustParsePrefix("2001:DB8:0:0::1/64")
+	rule := &api.FirewallRule{Spec: api.FirewallRuleSpec{RuleID: "fr1", SourcePrefix: &src, DestinationPrefix: &dst}}
+	if _, err := c.Firewall().Create(context.Background(), rule); err != nil {
+		t.Fatal(err)
+	}
+	if got := created.Spec.SourcePrefix.String(); got != "10.0.0.0/24" {
+		t.Errorf("expected the mapped source prefix to be unmapped and masked, got %s", got)
+	}
+	if got := created.Spec.DestinationPrefix.String(); got != "2001:db8::/64" {
+		t.Errorf("expected the destination prefix to be masked, got %s", got)
+	}
+	if rule.Spec.SourcePrefix.String() != "::ffff:10.0.0.7/120" || created == rule {
+		t.Errorf("expected the caller's rule to be left untouched, got %s", rule.Spec.SourcePrefix)
+	}
+
+	prefix := netip.MustParsePrefix("10.0.1.9/24")
+	if _, err := c.Routes().Delete(context.Background(), 42, &prefix); err != nil {
+		t.Fatal(err)
+	}
+	if deleted.String() != "10.0.1.0/24" || prefix.String() != "10.0.1.9/24" {
+		t.Errorf("expected 10.0.1.0/24 to be deleted and the argument kept, got %s and %s", deleted, prefix)
+	}
+
+	ipv4 := netip.MustParseAddr("::ffff:192.0.2.1")
+	ipv6 := netip.MustParseAddr("fe80::1%eth0")
+	if _, err := c.Interfaces().Create(context.Background(), &api.Interface{Spec: api.InterfaceSpec{IPv4: &ipv4, IPv6: &ipv6}}); err != nil {
+		t.Fatal(err)
+	}
+	if iface.Spec.IPv4.String() != "192.0.2.1" || iface.Spec.IPv6.String() != "fe80::1" {
+		t.Errorf("expected 192.0.2.1 and fe80::1, got %s and %s", iface.Spec.IPv4, iface.Spec.IPv6)
+	}
+
+	if _, err := AsV2(fake).Routes().Delete(context.Background(), 42, &prefix); err != nil || deleted != &prefix {
+		t.Errorf("expected the prefix to be passed through without the option, got %v, %s", err, deleted)
+	}
+}
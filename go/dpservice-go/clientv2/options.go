@@ -0,0 +1,202 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"time"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/semaphore"
+	"golang.org/x/sync/singleflight"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+)
+
+// CallOption allows customizing client call behavior. CallOptions passed to a
+// constructor become client defaults and are applied before the options of
+// each individual call.
+type CallOption func(*callOptions)
+
+type callOptions struct {
+	ignoredCodes []uint32
+	timeout      time.Duration
+	maxAttempts  int
+	backoff      BackoffFunc
+	detached     bool
+	concurrency  int
+	// perItemTimeout overrides the derived per-item budget of bulk helpers.
+	perItemTimeout time.Duration
+	// minServerVersion is the server version required by
+	// WithRequireServerVersion.
+	minServerVersion string
+	// rateLimiter and domainLimiters throttle every attempt of a call.
+	rateLimiter    RateLimiter
+	domainLimiters []domainLimiter
+	// serializer and callArgs implement WithSerializeByKey.
+	serializer *serializer
+	callArgs   []any
+	// rollbackOnError makes bulk creates undo themselves on failure.
+	rollbackOnError bool
+	// orphanCandidates are the interface IDs checked by FindOrphans.
+	orphanCandidates []string
+	// chunkSize splits the items of bulk helpers into sequential chunks.
+	chunkSize int
+	// retryBudget throttles the retries of WithRetry across calls.
+	retryBudget *retryBudget
+	// coalesceRetryLogs logs one summary per retried call instead of one
+	// line per retry.
+	coalesceRetryLogs bool
+	// semaphore, if set, is acquired by bulk helpers for each item.
+	semaphore       *semaphore.Weighted
+	semaphoreWeight int64
+	fields          []string
+	includeMissing  bool
+	resolveVNI      bool
+	sortByPriority  bool
+	validate        bool
+	normalizeAddrs  bool
+	idGenerator     func(domain string) string
+	continueOnErr   bool
+	serverSideOnly  bool
+	serverTimeout   time.Duration
+	targetHasher    func(FlowTuple) uint64
+	family          AddressFamily
+	idempotencyKey  string
+	flights         *singleflight.Group
+	flightKey       string
+	summaryVNIs     []uint32
+	onIgnored       func(domain, method string, code uint32)
+	wireTap         WireTapFunc
+	events          *eventSink
+	propagator      propagation.TextMapPropagator
+	tracerProvider  trace.TracerProvider
+	spanFilter      func(domain, method string) bool
+	logger          *slog.Logger
+	metrics         MetricsRecorder
+	metricLabels    MetricLabelsFunc
+	transport       Transport
+	trailer         *metadata.MD
+	maxItems        int
+	truncate        bool
+	truncated       *bool
+	before          []func(domain, method string, ctx context.Context) context.Context
+	after           []func(domain, method string, err error)
+	// existing looks up the resource made by a Create call, see withExisting.
+	existing func(ctx context.Context) (any, error)
+}
+
+// WithIgnoredCodes configures error codes that should be treated as non-fatal.
+func WithIgnoredCodes(codes ...uint32) CallOption {
+	return func(o *callOptions) {
+		o.ignoredCodes = append(o.ignoredCodes, codes...)
+	}
+}
+
+// WithIgnoreAlreadyExists ignores every status code reporting that the
+// resource to be created already exists, see IsAlreadyExists. Bulk helpers
+// pass it on to their sub-calls like any other option, so such items count
+// as created instead of failing.
+func WithIgnoreAlreadyExists() CallOption {
+	return WithIgnoredCodes(alreadyExistsCodes...)
+}
+
+// WithOnIgnored registers fn to be called whenever a status code configured
+// with WithIgnoredCodes suppresses the error of a call. The call still
+// succeeds; fn only makes the suppression observable.
+func WithOnIgnored(fn func(domain, method string, code uint32)) CallOption {
+	return func(o *callOptions) {
+		o.onIgnored = fn
+	}
+}
+
+// reportIgnored calls the WithOnIgnored callback if the status of the result
+// v carries an ignored code.
+func (o callOptions) reportIgnored(domain, method string, v any) {
+	if o.onIgnored == nil {
+		return
+	}
+	v, ok := resourcePointer(v)
+	if !ok {
+		return
+	}
+	var status api.Status
+	switch r := v.(type) {
+	case api.Object:
+		status = r.GetStatus()
+	case api.List:
+		status = r.GetStatus()
+	default:
+		return
+	}
+	if status.Code != 0 && slices.Contains(o.ignoredCodes, status.Code) {
+		o.onIgnored(domain, method, status.Code)
+	}
+}
+
+// WithTimeout bounds each call by the given duration on top of any deadline
+// already carried by the context. For bulk helpers the duration bounds the
+// whole operation, see WithPerItemTimeout.
+func WithTimeout(d time.Duration) CallOption {
+	return func(o *callOptions) {
+		o.timeout = d
+	}
+}
+
+// WithDetachedContext runs the call on context.WithoutCancel(ctx): values are
+// preserved, but cancellation and the deadline of ctx are dropped. This is
+// meant for critical cleanup, e.g. deferred teardown after a request context
+// is already done.
+//
+// Use with care: a detached call can no longer be aborted by its caller and
+// may block for as long as the server takes to answer. Combine it with
+// WithTimeout, which is still honored and applied to the detached context.
+func WithDetachedContext() CallOption {
+	return func(o *callOptions) {
+		o.detached = true
+	}
+}
+
+// WithBefore registers a hook that runs before each call. The context it
+// returns is used for the call, which allows attaching metadata or values.
+// Multiple hooks run in registration order.
+func WithBefore(hook func(domain, method string, ctx context.Context) context.Context) CallOption {
+	return func(o *callOptions) {
+		if hook != nil {
+			o.before = append(o.before, hook)
+		}
+	}
+}
+
+// WithAfter registers a hook that runs once each call has completed, with the
+// error returned to the caller. Multiple hooks run in registration order.
+func WithAfter(hook func(domain, method string, err error)) CallOption {
+	return func(o *callOptions) {
+		if hook != nil {
+			o.after = append(o.after, hook)
+		}
+	}
+}
+
+func buildCallOptions(opts ...CallOption) callOptions {
+	var o callOptions
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+	return o
+}
+
+// legacyIgnored converts the ignored codes to the legacy variadic []uint32 form.
+func (o callOptions) legacyIgnored() [][]uint32 {
+	if len(o.ignoredCodes) == 0 {
+		return nil
+	}
+	return [][]uint32{o.ignoredCodes}
+}
@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/errors"
+)
+
+type ctxKey struct{}
+
+func TestBeforeAfterHooks(t *testing.T) {
+	var events []string
+	fake := &fakeLegacy{
+		getInterface: func(ctx context.Context, id string) (*api.Interface, error) {
+			events = append(events, "call:"+ctx.Value(ctxKey{}).(string))
+			return &api.Interface{}, errors.NewStatusError(errors.NO_VM, "no vm")
+		},
+	}
+
+	v2 := AsV2(fake,
+		WithBefore(func(domain, method string, ctx context.Context) context.Context {
+			events = append(events, "default-before:"+domain+"."+method)
+			return context.WithValue(ctx, ctxKey{}, "default")
+		}),
+		WithAfter(func(domain, method string, err error) {
+			events = append(events, "default-after:"+domain+"."+method)
+		}),
+	)
+
+	_, _ = v2.Interfaces().Get(context.Background(), "vm1",
+		WithBefore(func(domain, method string, ctx context.Context) context.Context {
+			events = append(events, "before:"+ctx.Value(ctxKey{}).(string))
+			return context.WithValue(ctx, ctxKey{}, "call")
+		}),
+		WithAfter(func(domain, method string, err error) {
+			if !IsNotFound(err) {
+				t.Errorf("expected after hook to see the call error, got %v", err)
+			}
+			events = append(events, "after")
+		}),
+	)
+
+	want := []string{
+		"default-before:Interfaces.Get",
+		"before:default",
+		"call:call",
+		"default-after:Interfaces.Get",
+		"after",
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Fatalf("unexpected hook order:\n got %v\nwant %v", events, want)
+	}
+}
+
+func TestDetachedContext(t *testing.T) {
+	fake := &fakeLegacy{
+		deleteInterface: func(ctx context.Context, id string) (*api.Interface, error) {
+			if ctx.Value(ctxKey{}) != "value" {
+				t.Errorf("expected context values to be preserved")
+			}
+			if err := ctx.Err(); err != nil {
+				return &api.Interface{}, err
+			}
+			if _, ok := ctx.Deadline(); !ok {
+				t.Errorf("expected WithTimeout to apply to the detached context")
+			}
+			return &api.Interface{}, nil
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), ctxKey{}, "value"))
+	cancel()
+
+	v2 := AsV2(fake)
+	if _, err := v2.Interfaces().Delete(ctx, "vm1", WithDetachedContext(), WithTimeout(time.Second)); err != nil {
+		t.Fatalf("expected detached call to ignore the cancelled parent, got %v", err)
+	}
+	if _, err := v2.Interfaces().Delete(ctx, "vm1", WithTimeout(time.Second)); err != context.Canceled {
+		t.Fatalf("expected attached call to fail with context.Canceled, got %v", err)
+	}
+}
+
+func TestWithOnIgnored(t *testing.T) {
+	fake := &fakeLegacy{
+		getInterface: func(context.Context, string) (*api.Interface, error) {
+			return &api.Interface{Status: api.Status{Code: errors.NOT_FOUND}}, errors.NewStatusError(errors.NOT_FOUND, "not found")
+		},
+	}
+	c := AsV2(fake)
+	var got []string
+	onIgnored := WithOnIgnored(func(domain, method string, code uint32) {
+		got = append(got, fmt.Sprintf("%s.%s:%d", domain, method, code))
+	})
+
+	if _, err := c.Interfaces().Get(context.Background(), "vm1", WithIgnoredCodes(errors.NOT_FOUND), onIgnored); err != nil {
+		t.Fatalf("expected the ignored code to be suppressed, got %v", err)
+	}
+	if _, err := c.Routes().Get(context.Background(), 1, netip.MustParsePrefix("10.0.0.0/24"), WithIgnoredCodes(errors.NOT_FOUND), onIgnored); err != nil {
+		t.Fatalf("expected the ignored code to be suppressed, got %v", err)
+	}
+	if _, err := c.Interfaces().Get(context.Background(), "vm1", onIgnored); err == nil {
+		t.Fatal("expected the error without ignored codes")
+	}
+
+	want := []string{"Interfaces.Get:201", "Routes.Get:201"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,151 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+)
+
+// OrphanReport lists the sub-resources found by FindOrphans, grouped by type.
+// Every entry has the ID of its missing interface set.
+type OrphanReport struct {
+	Prefixes             []api.Prefix
+	LoadBalancerPrefixes []api.LoadBalancerPrefix
+	FirewallRules        []api.FirewallRule
+	VirtualIPs           []api.VirtualIP
+}
+
+// Len returns the number of orphaned sub-resources in the report.
+func (r *OrphanReport) Len() int {
+	return len(r.Prefixes) + len(r.LoadBalancerPrefixes) + len(r.FirewallRules) + len(r.VirtualIPs)
+}
+
+// WithOrphanCandidates makes FindOrphans check the sub-resources of ids.
+// dpservice lists prefixes, firewall rules and virtual IPs only by interface,
+// so orphans can only be found for interface IDs that are given, e.g. from
+// the inventory of the caller.
+func WithOrphanCandidates(ids ...string) CallOption {
+	return func(o *callOptions) {
+		o.orphanCandidates = append(o.orphanCandidates, ids...)
+	}
+}
+
+// FindOrphans reports the prefixes, loadbalancer prefixes, firewall rules and
+// virtual IPs still held by interfaces that are not listed by c. The IDs to
+// check are given with WithOrphanCandidates; those of existing interfaces
+// are skipped, and without candidates the report is empty.
+//
+// dpservice itself cannot hold orphans: deleting an interface removes its
+// sub-resources, and listing them for an unknown interface fails with
+// NO_VM, which counts as none. FindOrphans is meant for servers or proxies
+// that do not clean up this way. An interface created while its
+// sub-resources are listed is not an orphan, so the interfaces are listed
+// again afterwards and candidates that exist by then are left out.
+//
+// A candidate whose sub-resources cannot be listed for another reason than
+// NOT_FOUND is skipped and its error is joined into the returned error along
+// with the report of the other candidates. FindOrphans fails without a
+// report only if the interfaces cannot be listed.
+func FindOrphans(ctx context.Context, c Client, opts ...CallOption) (*OrphanReport, error) {
+	existing, err := listInterfaceIDs(ctx, c, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := slices.Clone(buildCallOptions(opts...).orphanCandidates)
+	slices.Sort(candidates)
+	found := map[string]*OrphanReport{}
+	var missing []string
+	var errs []error
+	for _, id := range slices.Compact(candidates) {
+		if id == "" || existing[id] {
+			continue
+		}
+		found[id] = &OrphanReport{}
+		missing = append(missing, id)
+		if err := findInterfaceOrphans(ctx, c, id, found[id], opts); err != nil {
+			errs = append(errs, fmt.Errorf("interface %s: %w", id, err))
+		}
+	}
+
+	report := &OrphanReport{}
+	if len(missing) == 0 {
+		return report, errors.Join(errs...)
+	}
+	if existing, err = listInterfaceIDs(ctx, c, opts); err != nil {
+		return nil, err
+	}
+	for _, id := range missing {
+		if existing[id] {
+			continue
+		}
+		report.Prefixes = append(report.Prefixes, found[id].Prefixes...)
+		report.LoadBalancerPrefixes = append(report.LoadBalancerPrefixes, found[id].LoadBalancerPrefixes...)
+		report.FirewallRules = append(report.FirewallRules, found[id].FirewallRules...)
+		report.VirtualIPs = append(report.VirtualIPs, found[id].VirtualIPs...)
+	}
+	return report, errors.Join(errs...)
+}
+
+// listInterfaceIDs returns the IDs of the interfaces listed by c.
+func listInterfaceIDs(ctx context.Context, c Client, opts []CallOption) (map[string]bool, error) {
+	ifaces, err := c.Interfaces().List(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("list interfaces: %w", err)
+	}
+	ids := make(map[string]bool, len(ifaces.Items))
+	for _, iface := range ifaces.Items {
+		ids[iface.ID] = true
+	}
+	return ids, nil
+}
+
+func findInterfaceOrphans(ctx context.Context, c Client, ifaceID string, report *OrphanReport, o []CallOption) error {
+	var errs []error
+	if prefixes, err := c.Interfaces().Prefixes().List(ctx, ifaceID, o...); err == nil {
+		for _, prefix := range prefixes.Items {
+			prefix.InterfaceID = ifaceID
+			report.Prefixes = append(report.Prefixes, prefix)
+		}
+	} else if !IsNotFound(err) {
+		errs = append(errs, fmt.Errorf("list prefixes: %w", err))
+	}
+
+	if prefixes, err := c.LoadBalancers().Prefixes().List(ctx, ifaceID, o...); err == nil {
+		for _, prefix := range prefixes.Items {
+			report.LoadBalancerPrefixes = append(report.LoadBalancerPrefixes, api.LoadBalancerPrefix{
+				TypeMeta:               api.TypeMeta{Kind: api.LoadBalancerPrefixKind},
+				LoadBalancerPrefixMeta: api.LoadBalancerPrefixMeta{InterfaceID: ifaceID},
+				Spec:                   api.LoadBalancerPrefixSpec{Prefix: prefix.Spec.Prefix, UnderlayRoute: prefix.Spec.UnderlayRoute},
+				Status:                 prefix.Status,
+			})
+		}
+	} else if !IsNotFound(err) {
+		errs = append(errs, fmt.Errorf("list loadbalancer prefixes: %w", err))
+	}
+
+	if rules, err := c.Firewall().List(ctx, ifaceID, o...); err == nil {
+		for _, rule := range rules.Items {
+			rule.InterfaceID = ifaceID
+			report.FirewallRules = append(report.FirewallRules, rule)
+		}
+	} else if !IsNotFound(err) {
+		errs = append(errs, fmt.Errorf("list firewall rules: %w", err))
+	}
+
+	if vip, err := c.Interfaces().VIP().Get(ctx, ifaceID, o...); err == nil {
+		if vip.Status.Code == 0 {
+			vip.InterfaceID = ifaceID
+			report.VirtualIPs = append(report.VirtualIPs, *vip)
+		}
+	} else if !IsNotFound(err) {
+		errs = append(errs, fmt.Errorf("get virtual ip: %w", err))
+	}
+	return errors.Join(errs...)
+}
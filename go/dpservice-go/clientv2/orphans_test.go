@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"slices"
+	"testing"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+	dperrors "github.com/ironcore-dev/dpservice/go/dpservice-go/errors"
+)
+
+func TestFindOrphans(t *testing.T) {
+	boom := errors.New("boom")
+	var listed []string
+	fake := &fakeLegacy{
+		listInterfaces: interfaceList("vm1"),
+		listPrefixes: func(_ context.Context, id string) (*api.PrefixList, error) {
+			listed = append(listed, id)
+			switch id {
+			case "gone":
+				return &api.PrefixList{Items: []api.Prefix{{Spec: api.PrefixSpec{Prefix: netip.MustParsePrefix("10.0.0.0/24")}}}}, nil
+			case "broken":
+				return nil, boom
+			}
+			return &api.PrefixList{}, dperrors.NewStatusError(dperrors.NO_VM, "no vm")
+		},
+		listFirewallRules: func(_ context.Context, id string) (*api.FirewallRuleList, error) {
+			if id == "gone" {
+				return &api.FirewallRuleList{Items: []api.FirewallRule{{Spec: api.FirewallRuleSpec{RuleID: "r1"}}}}, nil
+			}
+			return &api.FirewallRuleList{}, dperrors.NewStatusError(dperrors.NO_VM, "no vm")
+		},
+		getVirtualIP: func(context.Context, string) (*api.VirtualIP, error) {
+			return &api.VirtualIP{}, dperrors.NewStatusError(dperrors.NO_VM, "no vm")
+		},
+	}
+
+	report, err := FindOrphans(context.Background(), AsV2(fake),
+		WithOrphanCandidates("vm1", "gone", "clean", "broken", "gone"))
+	if !errors.Is(err, boom) {
+		t.Fatalf("expected the error of the broken candidate, got %v", err)
+	}
+	if report.Len() != 2 {
+		t.Fatalf("expected 2 orphans, got %+v", report)
+	}
+	if report.Prefixes[0].InterfaceID != "gone" || report.FirewallRules[0].InterfaceID != "gone" {
+		t.Fatalf("expected orphans of interface gone, got %+v", report)
+	}
+	if want := []string{"broken", "clean", "gone"}; !slices.Equal(listed, want) {
+		t.Fatalf("expected only missing interfaces to be checked once, got %v want %v", listed, want)
+	}
+
+	// An interface created while its sub-resources are listed is no orphan.
+	fake.listInterfaces = func(context.Context) (*api.InterfaceList, error) {
+		if len(listed) > 3 {
+			return interfaceList("vm1", "gone")(context.Background())
+		}
+		return interfaceList("vm1")(context.Background())
+	}
+	report, err = FindOrphans(context.Background(), AsV2(fake), WithOrphanCandidates("gone"))
+	if err != nil || report.Len() != 0 {
+		t.Fatalf("expected an interface created meanwhile not to be reported, got %+v, %v", report, err)
+	}
+
+	report, err = FindOrphans(context.Background(), AsV2(fake))
+	if err != nil || report.Len() != 0 {
+		t.Fatalf("expected empty report without candidates, got %+v, %v", report, err)
+	}
+}
@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// ParseAddr parses an IP address in the form accepted by dpservice. Besides
+// syntax errors it rejects zoned addresses and IPv4-mapped IPv6 addresses,
+// which the server does not accept.
+func ParseAddr(s string) (netip.Addr, error) {
+	if strings.TrimSpace(s) == "" {
+		return netip.Addr{}, fmt.Errorf("invalid IP address: empty string")
+	}
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("invalid IP address %q: expected IPv4 (192.0.2.1) or IPv6 (2001:db8::1) notation", s)
+	}
+	if err := validateAddr(addr); err != nil {
+		return netip.Addr{}, fmt.Errorf("invalid IP address %q: %w", s, err)
+	}
+	return addr, nil
+}
+
+// ParsePrefix parses a CIDR prefix in the form accepted by dpservice. Besides
+// syntax errors it rejects prefixes with host bits set, zoned addresses and
+// IPv4-mapped IPv6 addresses.
+func ParsePrefix(s string) (netip.Prefix, error) {
+	if strings.TrimSpace(s) == "" {
+		return netip.Prefix{}, fmt.Errorf("invalid prefix: empty string")
+	}
+	if !strings.Contains(s, "/") {
+		return netip.Prefix{}, fmt.Errorf("invalid prefix %q: missing prefix length, expected CIDR notation such as 10.0.0.0/24", s)
+	}
+	prefix, err := netip.ParsePrefix(s)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid prefix %q: expected CIDR notation such as 10.0.0.0/24 or 2001:db8::/64", s)
+	}
+	if err := validatePrefix(prefix); err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid prefix %q: %w", s, err)
+	}
+	return prefix, nil
+}
+
+// validateAddr checks that addr is usable as a dpservice address.
+func validateAddr(addr netip.Addr) error {
+	switch {
+	case !addr.IsValid():
+		return fmt.Errorf("address is not set")
+	case addr.Zone() != "":
+		return fmt.Errorf("zoned addresses are not supported")
+	case addr.Is4In6():
+		return fmt.Errorf("IPv4-mapped IPv6 addresses are not supported, use %s", addr.Unmap())
+	}
+	return nil
+}
+
+// validatePrefix checks that prefix is usable as a dpservice prefix.
+func validatePrefix(prefix netip.Prefix) error {
+	if !prefix.IsValid() {
+		return fmt.Errorf("prefix is not set")
+	}
+	if err := validateAddr(prefix.Addr()); err != nil {
+		return err
+	}
+	if masked := prefix.Masked(); masked != prefix {
+		return fmt.Errorf("host bits are set, use %s", masked)
+	}
+	return nil
+}
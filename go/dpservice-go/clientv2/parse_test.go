@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseAddr(t *testing.T) {
+	for _, tc := range []struct {
+		in, want, err string
+	}{
+		{in: "10.0.0.1", want: "10.0.0.1"},
+		{in: "2001:db8::1", want: "2001:db8::1"},
+		{in: "", err: "empty string"},
+		{in: "10.0.0.256", err: "expected IPv4"},
+		{in: "fe80::1%eth0", err: "zoned"},
+		{in: "::ffff:10.0.0.1", err: "use 10.0.0.1"},
+	} {
+		addr, err := ParseAddr(tc.in)
+		if tc.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("ParseAddr(%q): expected error containing %q, got %v", tc.in, tc.err, err)
+			}
+			continue
+		}
+		if err != nil || addr.String() != tc.want {
+			t.Errorf("ParseAddr(%q) = %s, %v, want %s", tc.in, addr, err, tc.want)
+		}
+	}
+}
+
+func TestParsePrefix(t *testing.T) {
+	for _, tc := range []struct {
+		in, want, err string
+	}{
+		{in: "10.0.0.0/24", want: "10.0.0.0/24"},
+		{in: "2001:db8::/64", want: "2001:db8::/64"},
+		{in: " ", err: "empty string"},
+		{in: "10.0.0.0", err: "missing prefix length"},
+		{in: "10.0.0.0/33", err: "CIDR notation"},
+		{in: "10.0.0.1/24", err: "host bits are set, use 10.0.0.0/24"},
+		{in: "::ffff:10.0.0.0/120", err: "IPv4-mapped"},
+	} {
+		prefix, err := ParsePrefix(tc.in)
+		if tc.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("ParsePrefix(%q): expected error containing %q, got %v", tc.in, tc.err, err)
+			}
+			continue
+		}
+		if err != nil || prefix.String() != tc.want {
+			t.Errorf("ParsePrefix(%q) = %s, %v, want %s", tc.in, prefix, err, tc.want)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+)
+
+// TimeoutError is returned by polling helpers when the deadline of their
+// context passes before the awaited condition holds, and by polling and bulk
+// helpers called with a context whose deadline has already passed.
+type TimeoutError struct {
+	// What describes the awaited condition.
+	What string
+	// LastErr is the error of the last poll, if it failed.
+	LastErr error
+}
+
+func (e *TimeoutError) Error() string {
+	if e.LastErr != nil {
+		return fmt.Sprintf("timed out waiting for %s: %v", e.What, e.LastErr)
+	}
+	return "timed out waiting for " + e.What
+}
+
+// Unwrap makes errors.Is(err, context.DeadlineExceeded) hold.
+func (e *TimeoutError) Unwrap() error {
+	return context.DeadlineExceeded
+}
+
+// CanceledError is returned by polling helpers when their context is
+// canceled, e.g. on shutdown, before the awaited condition holds, and by
+// polling and bulk helpers called with an already canceled context.
+type CanceledError struct {
+	// What describes the awaited condition.
+	What string
+	// Cause is the cancellation cause of the context, see context.Cause.
+	Cause error
+}
+
+func (e *CanceledError) Error() string {
+	return fmt.Sprintf("canceled waiting for %s: %v", e.What, e.Cause)
+}
+
+// Unwrap exposes the cancellation cause, which is context.Canceled unless
+// the context was canceled with a custom cause.
+func (e *CanceledError) Unwrap() error {
+	return e.Cause
+}
+
+// poll calls check every interval until it reports done or fails. The wait
+// between polls selects on ctx, so cancellation is noticed at once rather
+// than after the interval. If ctx ends first, a *TimeoutError or
+// *CanceledError is returned.
+func poll(ctx context.Context, interval time.Duration, what string, check func(ctx context.Context) (done bool, err error)) error {
+	if err := contextDone(ctx, what); err != nil {
+		return err
+	}
+	for {
+		done, err := check(ctx)
+		if ctx.Err() != nil {
+			return waitError(ctx, what, err)
+		}
+		if err != nil || done {
+			return err
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return waitError(ctx, what, nil)
+		case <-timer.C:
+		}
+	}
+}
+
+// contextDone returns a *TimeoutError or *CanceledError if ctx has already
+// ended, so that helpers fail before issuing RPCs or starting goroutines.
+func contextDone(ctx context.Context, what string) error {
+	if ctx.Err() == nil {
+		return nil
+	}
+	return waitError(ctx, what, nil)
+}
+
+func waitError(ctx context.Context, what string, lastErr error) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return &TimeoutError{What: what, LastErr: lastErr}
+	}
+	return &CanceledError{What: what, Cause: context.Cause(ctx)}
+}
+
+// waitFor polls get until it returns a resource satisfying pred and returns
+// that resource. Missing resources are polled again, other errors end the
+// wait.
+func waitFor[T api.Object](ctx context.Context, interval time.Duration, what string, get func(ctx context.Context) (T, error), pred func(T) bool) (T, error) {
+	var res T
+	err := poll(ctx, interval, what, func(ctx context.Context) (bool, error) {
+		obj, err := get(ctx)
+		if ok, err := exists(obj, err); !ok {
+			return false, err
+		}
+		if !pred(obj) {
+			return false, nil
+		}
+		res = obj
+		return true, nil
+	})
+	return res, err
+}
+
+// waitGone polls get until the resource is missing. Other errors end the
+// wait.
+func waitGone[T api.Object](ctx context.Context, interval time.Duration, what string, get func(ctx context.Context) (T, error)) error {
+	return poll(ctx, interval, what, func(ctx context.Context) (bool, error) {
+		ok, err := exists(get(ctx))
+		return !ok && err == nil, err
+	})
+}
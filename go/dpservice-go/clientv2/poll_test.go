@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPollCanceledDuringSleep(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	polls := 0
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+
+	start := time.Now()
+	err := poll(ctx, time.Hour, "nothing", func(context.Context) (bool, error) {
+		polls++
+		return false, nil
+	})
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected a prompt return on cancellation, took %v", elapsed)
+	}
+	var canceled *CanceledError
+	if !errors.As(err, &canceled) || !errors.Is(err, context.Canceled) || polls != 1 {
+		t.Fatalf("expected a CanceledError after 1 poll, got %v after %d", err, polls)
+	}
+}
+
+func TestPollTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := poll(ctx, time.Hour, "nothing", func(context.Context) (bool, error) { return false, nil })
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected a prompt return on timeout, took %v", elapsed)
+	}
+	var timeout *TimeoutError
+	if !errors.As(err, &timeout) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected a TimeoutError, got %v", err)
+	}
+}
+
+func TestPollDone(t *testing.T) {
+	polls := 0
+	err := poll(context.Background(), time.Millisecond, "third poll", func(context.Context) (bool, error) {
+		polls++
+		return polls == 3, nil
+	})
+	if err != nil || polls != 3 {
+		t.Fatalf("expected success after 3 polls, got %v after %d", err, polls)
+	}
+}
@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// WithFields restricts the Spec of returned objects, and of the items of
+// returned lists, to the given fields. Fields are named as in the JSON form of
+// the spec, for example "vni" or "primary_ipv4" for interfaces; unknown names
+// fail the call before any RPC is made. Metadata and status are always kept.
+//
+// dpservice does not support field masks yet, so the full object is still
+// transferred and the other fields are cleared client-side. The option only
+// expresses intent and does not reduce bandwidth until server support lands.
+func WithFields(fields ...string) CallOption {
+	return func(o *callOptions) {
+		o.fields = append(o.fields, fields...)
+	}
+}
+
+// specType returns the Spec struct type of an object type or of the item type
+// of a list type.
+func specType(t reflect.Type) (reflect.Type, bool) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, false
+	}
+	if f, ok := t.FieldByName("Spec"); ok && f.Type.Kind() == reflect.Struct {
+		return f.Type, true
+	}
+	if f, ok := t.FieldByName("Items"); ok && f.Type.Kind() == reflect.Slice {
+		return specType(f.Type.Elem())
+	}
+	return nil, false
+}
+
+// jsonFieldName returns the JSON name of a struct field.
+func jsonFieldName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name == "" {
+		return f.Name
+	}
+	return name
+}
+
+// checkFields validates that every field exists in the spec of objects of type t.
+func checkFields(t reflect.Type, fields []string) error {
+	spec, ok := specType(t)
+	if !ok {
+		return fmt.Errorf("field projection is not supported for %s", t)
+	}
+	known := map[string]bool{}
+	for i := 0; i < spec.NumField(); i++ {
+		known[jsonFieldName(spec.Field(i))] = true
+	}
+	for _, field := range fields {
+		if !known[field] {
+			valid := make([]string, 0, len(known))
+			for name := range known {
+				valid = append(valid, name)
+			}
+			sort.Strings(valid)
+			return fmt.Errorf("unknown field %q for %s, valid fields are: %s", field, spec, strings.Join(valid, ", "))
+		}
+	}
+	return nil
+}
+
+// projectFields clears every Spec field not listed in fields, in the object v
+// points to or in each item of the list v points to.
+func projectFields(v any, fields []string) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return
+	}
+	keep := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		keep[field] = true
+	}
+
+	obj := rv.Elem()
+	if items := obj.FieldByName("Items"); items.IsValid() && items.Kind() == reflect.Slice {
+		for i := 0; i < items.Len(); i++ {
+			projectSpec(items.Index(i).FieldByName("Spec"), keep)
+		}
+		return
+	}
+	projectSpec(obj.FieldByName("Spec"), keep)
+}
+
+func projectSpec(spec reflect.Value, keep map[string]bool) {
+	if !spec.IsValid() || spec.Kind() != reflect.Struct {
+		return
+	}
+	for i := 0; i < spec.NumField(); i++ {
+		if !keep[jsonFieldName(spec.Type().Field(i))] {
+			spec.Field(i).SetZero()
+		}
+	}
+}
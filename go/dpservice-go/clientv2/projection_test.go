@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+)
+
+func TestWithFields(t *testing.T) {
+	ip := netip.MustParseAddr("10.0.0.1")
+	iface := func() *api.Interface {
+		return &api.Interface{
+			InterfaceMeta: api.InterfaceMeta{ID: "vm1"},
+			Spec:          api.InterfaceSpec{VNI: 100, IPv4: &ip, Device: "0000:01:00.0", HostName: "vm1"},
+		}
+	}
+	fake := &fakeLegacy{
+		getInterface: func(context.Context, string) (*api.Interface, error) { return iface(), nil },
+		listInterfaces: func(context.Context) (*api.InterfaceList, error) {
+			return &api.InterfaceList{Items: []api.Interface{*iface(), *iface()}}, nil
+		},
+	}
+	v2 := AsV2(fake)
+
+	got, err := v2.Interfaces().Get(context.Background(), "vm1", WithFields("vni", "primary_ipv4"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := api.InterfaceSpec{VNI: 100, IPv4: &ip}
+	if got.ID != "vm1" || got.Spec != want {
+		t.Fatalf("unexpected projection %+v", got)
+	}
+
+	list, err := v2.Interfaces().List(context.Background(), WithFields("device"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, item := range list.Items {
+		if item.Spec != (api.InterfaceSpec{Device: "0000:01:00.0"}) {
+			t.Fatalf("unexpected projection %+v", item.Spec)
+		}
+	}
+
+	if _, err := v2.Interfaces().Get(context.Background(), "vm1", WithFields("vnii")); err == nil {
+		t.Fatalf("expected unknown field to be rejected")
+	}
+	if calls := fake.Calls(); len(calls) != 2 {
+		t.Fatalf("expected no RPC for an invalid projection, got %v", calls)
+	}
+}
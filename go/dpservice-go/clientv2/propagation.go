@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"google.golang.org/grpc/metadata"
+)
+
+// WithPropagators sets the propagator injecting the trace context and
+// baggage of the call context into the outgoing gRPC metadata, for example
+// propagation.NewCompositeTextMapPropagator(propagation.TraceContext{},
+// propagation.Baggage{}). Without it, the global propagator of
+// otel.GetTextMapPropagator is used, which injects nothing unless configured.
+func WithPropagators(p propagation.TextMapPropagator) CallOption {
+	return func(o *callOptions) {
+		o.propagator = p
+	}
+}
+
+// metadataCarrier adapts outgoing gRPC metadata to propagation.TextMapCarrier.
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	if vals := metadata.MD(c).Get(key); len(vals) > 0 {
+		return vals[0]
+	}
+	return ""
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// injectPropagation returns ctx with the propagated fields of ctx added to
+// its outgoing gRPC metadata.
+func injectPropagation(ctx context.Context, o callOptions) context.Context {
+	p := o.propagator
+	if p == nil {
+		p = otel.GetTextMapPropagator()
+	}
+	if len(p.Fields()) == 0 {
+		return ctx
+	}
+	md, _ := metadata.FromOutgoingContext(ctx)
+	md = md.Copy()
+	p.Inject(ctx, metadataCarrier(md))
+	return metadata.NewOutgoingContext(ctx, md)
+}
@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+)
+
+func TestWithPropagators(t *testing.T) {
+	var got metadata.MD
+	fake := &fakeLegacy{
+		getInterface: func(ctx context.Context, _ string) (*api.Interface, error) {
+			got, _ = metadata.FromOutgoingContext(ctx)
+			return &api.Interface{}, nil
+		},
+	}
+	member, _ := baggage.NewMember("tenant", "acme")
+	bag, _ := baggage.New(member)
+	ctx := baggage.ContextWithBaggage(context.Background(), bag)
+	ctx = metadata.AppendToOutgoingContext(ctx, "x-request-id", "42")
+
+	c := AsV2(fake, WithPropagators(propagation.Baggage{}))
+	if _, err := c.Interfaces().Get(ctx, "vm1"); err != nil {
+		t.Fatal(err)
+	}
+	if vals := got.Get("baggage"); len(vals) != 1 || vals[0] != "tenant=acme" {
+		t.Fatalf("expected the baggage to be injected, got %v", got)
+	}
+	if vals := got.Get("x-request-id"); len(vals) != 1 {
+		t.Fatalf("expected existing metadata to be kept, got %v", got)
+	}
+	if md, _ := metadata.FromOutgoingContext(ctx); len(md.Get("baggage")) != 0 {
+		t.Fatalf("expected the caller's metadata to be left untouched, got %v", md)
+	}
+}
+
+func TestGlobalPropagator(t *testing.T) {
+	prev := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.Baggage{})
+	defer otel.SetTextMapPropagator(prev)
+
+	var got metadata.MD
+	fake := &fakeLegacy{
+		getInterface: func(ctx context.Context, _ string) (*api.Interface, error) {
+			got, _ = metadata.FromOutgoingContext(ctx)
+			return &api.Interface{}, nil
+		},
+	}
+	member, _ := baggage.NewMember("tenant", "acme")
+	bag, _ := baggage.New(member)
+	ctx := baggage.ContextWithBaggage(context.Background(), bag)
+
+	if _, err := AsV2(fake).Interfaces().Get(ctx, "vm1"); err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Get("baggage")) != 1 {
+		t.Fatalf("expected the global propagator to be honored, got %v", got)
+	}
+}
@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"fmt"
+)
+
+// RateLimiter throttles calls. Wait blocks until a call may proceed or ctx
+// ends. *rate.Limiter of golang.org/x/time/rate implements it.
+type RateLimiter interface {
+	Wait(ctx context.Context) error
+}
+
+// WithRateLimiter makes every RPC wait on l first. Retried attempts wait
+// again, so l bounds the requests sent to the server rather than the calls.
+func WithRateLimiter(l RateLimiter) CallOption {
+	return func(o *callOptions) {
+		o.rateLimiter = l
+	}
+}
+
+// WithDomainRateLimiter makes the RPCs of domain, e.g. DomainCapture, wait on
+// l in addition to the limiter of WithRateLimiter, so that a noisy domain
+// cannot use up the budget of the others. Domains match exactly:
+// DomainInterfaces does not cover DomainVirtualIPs. A later limiter for the
+// same domain replaces an earlier one.
+//
+// The global limiter is waited on first, then the domain limiter. Waiting
+// counts against the timeout and deadline of the call; when ctx ends during
+// a wait, the call fails with a *TimeoutError or *CanceledError without
+// sending the RPC. Tokens taken from the global limiter are not returned
+// when the wait on the domain limiter fails.
+func WithDomainRateLimiter(domain string, l RateLimiter) CallOption {
+	return func(o *callOptions) {
+		o.domainLimiters = append(o.domainLimiters, domainLimiter{domain: domain, limiter: l})
+	}
+}
+
+type domainLimiter struct {
+	domain  string
+	limiter RateLimiter
+}
+
+// waitRateLimits waits on the limiters that apply to domain.method.
+func waitRateLimits(ctx context.Context, o callOptions, domain, method string) error {
+	var domainLimit RateLimiter
+	for _, dl := range o.domainLimiters {
+		if dl.domain == domain {
+			domainLimit = dl.limiter
+		}
+	}
+	for _, l := range []RateLimiter{o.rateLimiter, domainLimit} {
+		if l == nil {
+			continue
+		}
+		if err := l.Wait(ctx); err != nil {
+			if ctxErr := contextDone(ctx, domain+"."+method+" rate limit"); ctxErr != nil {
+				return ctxErr
+			}
+			// rate.Limiter fails early if the deadline would pass first.
+			return fmt.Errorf("%s.%s: wait for rate limiter: %w", domain, method, err)
+		}
+	}
+	return nil
+}
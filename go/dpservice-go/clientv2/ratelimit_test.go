@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+// fakeLimiter records its waits in a shared log.
+type fakeLimiter struct {
+	name string
+	log  *[]string
+	err  error
+}
+
+func (l *fakeLimiter) Wait(ctx context.Context) error {
+	*l.log = append(*l.log, l.name)
+	if l.err != nil {
+		return l.err
+	}
+	return ctx.Err()
+}
+
+func TestRateLimiters(t *testing.T) {
+	var waits []string
+	fake := &fakeLegacy{}
+	v2 := AsV2(fake,
+		WithRateLimiter(&fakeLimiter{name: "global", log: &waits}),
+		WithDomainRateLimiter(DomainCapture, &fakeLimiter{name: "capture", log: &waits}))
+	ctx := context.Background()
+
+	if _, err := v2.Capture().Status(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := v2.Interfaces().Get(ctx, "vm1"); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"global", "capture", "global"}; !slices.Equal(waits, want) {
+		t.Errorf("expected waits %v, got %v", want, waits)
+	}
+
+	waits = nil
+	if _, err := v2.Capture().Status(ctx, WithDomainRateLimiter(DomainCapture, &fakeLimiter{name: "override", log: &waits})); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"global", "override"}; !slices.Equal(waits, want) {
+		t.Errorf("expected waits %v, got %v", want, waits)
+	}
+}
+
+func TestRateLimiterErrors(t *testing.T) {
+	var waits []string
+	fake := &fakeLegacy{}
+	ifaces := AsV2(fake).Interfaces()
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := ifaces.Get(canceled, "vm1", WithRateLimiter(&fakeLimiter{name: "global", log: &waits}))
+	var canceledErr *CanceledError
+	if !errors.As(err, &canceledErr) {
+		t.Errorf("expected CanceledError, got %v", err)
+	}
+
+	limitErr := errors.New("would exceed context deadline")
+	_, err = ifaces.Get(context.Background(), "vm1", WithDomainRateLimiter(DomainInterfaces, &fakeLimiter{name: "iface", log: &waits, err: limitErr}))
+	if !errors.Is(err, limitErr) {
+		t.Errorf("expected limiter error, got %v", err)
+	}
+	if len(fake.Calls()) != 0 {
+		t.Errorf("expected no RPCs, got %v", fake.Calls())
+	}
+}
@@ -0,0 +1,264 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// BackoffFunc returns the delay before the given retry. attempt is 1 for the
+// first retry.
+type BackoffFunc func(attempt int) time.Duration
+
+// WithRetry retries calls failing with a transient gRPC error (Unavailable,
+// ResourceExhausted or Aborted) up to maxAttempts calls in total, waiting
+// backoff(attempt) between attempts. A nil backoff retries immediately.
+// Every attempt is classified anew: one failing with any other error ends
+// the retries at once and its error is returned. Values of maxAttempts below
+// 2 disable retries.
+func WithRetry(maxAttempts int, backoff BackoffFunc) CallOption {
+	return func(o *callOptions) {
+		o.maxAttempts = maxAttempts
+		o.backoff = backoff
+	}
+}
+
+// WithRetryLogCoalesce controls how retries of WithRetry are logged to the
+// logger of WithLogger. By default every retry is logged at warn level. With
+// coalesce set, a call that needed retries logs a single summary line once it
+// is done instead, with the number of retries, the time they took and the
+// final error, if any.
+func WithRetryLogCoalesce(coalesce bool) CallOption {
+	return func(o *callOptions) {
+		o.coalesceRetryLogs = coalesce
+	}
+}
+
+// WithRetryBudget limits the retries of WithRetry to a share of the calls,
+// like the retry budgets of Finagle and Envoy: within a sliding window of
+// retryBudgetWindow (10s), minRetries retries are always allowed, plus
+// ratio retries for every call made in the window. With a ratio of 0.1 and
+// a minRetries of 5, at most one call in ten is retried when many calls
+// fail, while a client making few calls can still retry five times per
+// window. A retry beyond the budget is not made and the call returns its
+// last error.
+//
+// The budget is created by WithRetryBudget and shared by every call using
+// the returned option, so pass it as a client default to throttle retries
+// client-wide. A negative or NaN ratio is raised to 0 and a negative
+// minRetries to 0; with both at 0 no retry is made.
+func WithRetryBudget(ratio float64, minRetries int) CallOption {
+	if !(ratio >= 0) {
+		ratio = 0
+	}
+	budget := &retryBudget{ratio: ratio, minRetries: max(minRetries, 0), now: time.Now}
+	return func(o *callOptions) {
+		o.retryBudget = budget
+	}
+}
+
+// retryBudgetWindow is the window over which WithRetryBudget counts calls
+// and retries, kept in retryBudgetBuckets buckets of a second.
+const (
+	retryBudgetWindow  = 10 * time.Second
+	retryBudgetBuckets = int(retryBudgetWindow / time.Second)
+)
+
+type retryBudget struct {
+	ratio      float64
+	minRetries int
+	now        func() time.Time
+
+	mu      sync.Mutex
+	buckets [retryBudgetBuckets]retryBudgetBucket
+}
+
+// retryBudgetBucket counts the calls and retries of one second.
+type retryBudgetBucket struct {
+	second         int64
+	calls, retries int
+}
+
+// bucket returns the bucket of the current second, resetting it if it was
+// last used a window ago. b.mu must be held.
+func (b *retryBudget) bucket(second int64) *retryBudgetBucket {
+	bk := &b.buckets[second%int64(retryBudgetBuckets)]
+	if bk.second != second {
+		*bk = retryBudgetBucket{second: second}
+	}
+	return bk
+}
+
+// deposit counts a call.
+func (b *retryBudget) deposit() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.bucket(b.now().Unix()).calls++
+}
+
+// withdraw counts a retry and reports whether the budget allowed it.
+func (b *retryBudget) withdraw() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	second := b.now().Unix()
+	calls, retries := 0, 0
+	for _, bk := range b.buckets {
+		if bk.second > second-int64(retryBudgetBuckets) {
+			calls += bk.calls
+			retries += bk.retries
+		}
+	}
+	if float64(retries+1) > float64(b.minRetries)+b.ratio*float64(calls) {
+		return false
+	}
+	b.bucket(second).retries++
+	return true
+}
+
+// ConstantBackoff waits d before every retry.
+func ConstantBackoff(d time.Duration) BackoffFunc {
+	return func(int) time.Duration {
+		return d
+	}
+}
+
+// ExponentialBackoff waits base before the first retry and doubles the delay
+// for every further retry, up to max.
+func ExponentialBackoff(base, max time.Duration) BackoffFunc {
+	return func(attempt int) time.Duration {
+		d := base
+		for i := 1; i < attempt && d < max; i++ {
+			d *= 2
+		}
+		return min(d, max)
+	}
+}
+
+// JitterSource provides the randomness of jittered backoffs. It is safe for
+// concurrent use.
+type JitterSource struct {
+	mu  sync.Mutex
+	rnd *rand.Rand
+}
+
+// NewJitterSource returns a JitterSource seeded with seed, which makes the
+// delays of jittered backoffs reproducible in tests.
+func NewJitterSource(seed int64) *JitterSource {
+	return &JitterSource{rnd: rand.New(rand.NewSource(seed))}
+}
+
+var defaultJitterSource = NewJitterSource(time.Now().UnixNano())
+
+func (s *JitterSource) int63n(n int64) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rnd.Int63n(n)
+}
+
+// JitteredExponentialBackoff waits a random delay between zero and the delay
+// of ExponentialBackoff(base, max) ("full jitter"), which spreads the retries
+// of many clients failing at the same time. An optional source replaces the
+// default, randomly seeded one.
+func JitteredExponentialBackoff(base, max time.Duration, source ...*JitterSource) BackoffFunc {
+	src := defaultJitterSource
+	if len(source) > 0 && source[0] != nil {
+		src = source[0]
+	}
+	exp := ExponentialBackoff(base, max)
+	return func(attempt int) time.Duration {
+		d := exp(attempt)
+		if d <= 0 {
+			return 0
+		}
+		return time.Duration(src.int63n(int64(d) + 1))
+	}
+}
+
+// isRetryable reports whether err is a transient gRPC error worth retrying.
+func isRetryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted, codes.Aborted:
+		return true
+	}
+	return false
+}
+
+// retry calls fn until it succeeds, fails with a non-retryable error, the
+// attempts configured by WithRetry or the retries allowed by WithRetryBudget
+// are used up or ctx is done.
+func retry[T any](ctx context.Context, o callOptions, domain, method string, fn func() (T, error)) (T, error) {
+	start := time.Now()
+	if o.retryBudget != nil {
+		o.retryBudget.deposit()
+	}
+	res, err := fn()
+	retries := 0
+loop:
+	for attempt := 1; attempt < o.maxAttempts && isRetryable(err); attempt++ {
+		if o.retryBudget != nil && !o.retryBudget.withdraw() {
+			break
+		}
+		var delay time.Duration
+		if o.backoff != nil {
+			delay = o.backoff(attempt)
+		}
+		if !o.coalesceRetryLogs {
+			logRetry(ctx, o.loggerFor(ctx), domain, method, attempt, delay, err)
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			break loop
+		case <-timer.C:
+		}
+		res, err = fn()
+		retries = attempt
+	}
+	if existing, ok := recoverExisting[T](ctx, o, retries, err); ok {
+		res, err = existing, nil
+	}
+	if o.coalesceRetryLogs && retries > 0 {
+		logRetries(ctx, o.loggerFor(ctx), domain, method, retries, time.Since(start), err)
+	}
+	return res, err
+}
+
+func logRetry(ctx context.Context, l *slog.Logger, domain, method string, attempt int, delay time.Duration, err error) {
+	if l == nil {
+		return
+	}
+	l.LogAttrs(ctx, slog.LevelWarn, "dpservice call retrying",
+		slog.String("domain", domain),
+		slog.String("method", method),
+		slog.Int("attempt", attempt),
+		slog.Duration("delay", delay),
+		slog.Any("error", err),
+	)
+}
+
+// logRetries logs the summary of a retry burst for WithRetryLogCoalesce.
+func logRetries(ctx context.Context, l *slog.Logger, domain, method string, retries int, took time.Duration, err error) {
+	if l == nil {
+		return
+	}
+	attrs := []slog.Attr{
+		slog.String("domain", domain),
+		slog.String("method", method),
+		slog.Int("retries", retries),
+		slog.Duration("duration", took),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.Any("error", err))
+	}
+	l.LogAttrs(ctx, slog.LevelWarn, fmt.Sprintf("dpservice call retried %d times over %v", retries, took.Round(time.Millisecond)), attrs...)
+}
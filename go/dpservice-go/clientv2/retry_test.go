@@ -0,0 +1,202 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+)
+
+func TestBackoffs(t *testing.T) {
+	if d := ConstantBackoff(time.Second)(5); d != time.Second {
+		t.Fatalf("expected constant 1s, got %v", d)
+	}
+
+	exp := ExponentialBackoff(100*time.Millisecond, time.Second)
+	var got []time.Duration
+	for attempt := 1; attempt <= 6; attempt++ {
+		got = append(got, exp(attempt))
+	}
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second}
+	if !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if d := exp(1000); d != time.Second {
+		t.Fatalf("expected a large attempt to be capped at 1s, got %v", d)
+	}
+
+	jittered := func(seed int64) []time.Duration {
+		b := JitteredExponentialBackoff(100*time.Millisecond, time.Second, NewJitterSource(seed))
+		var out []time.Duration
+		for attempt := 1; attempt <= 6; attempt++ {
+			d := b(attempt)
+			if d < 0 || d > exp(attempt) {
+				t.Fatalf("jittered delay %v of attempt %d out of range", d, attempt)
+			}
+			out = append(out, d)
+		}
+		return out
+	}
+	if a, b := jittered(42), jittered(42); !slices.Equal(a, b) {
+		t.Fatalf("expected the same seed to produce the same delays, got %v and %v", a, b)
+	}
+}
+
+func TestWithRetry(t *testing.T) {
+	attempts := 0
+	fake := &fakeLegacy{
+		getInterface: func(context.Context, string) (*api.Interface, error) {
+			attempts++
+			if attempts < 3 {
+				return &api.Interface{}, status.Error(codes.Unavailable, "down")
+			}
+			return &api.Interface{}, nil
+		},
+	}
+	c := AsV2(fake)
+
+	if _, err := c.Interfaces().Get(context.Background(), "vm1", WithRetry(3, ConstantBackoff(time.Millisecond))); err != nil {
+		t.Fatalf("expected success on the third attempt, got %v", err)
+	}
+
+	attempts = 0
+	_, err := c.Interfaces().Get(context.Background(), "vm1", WithRetry(2, nil))
+	if status.Code(err) != codes.Unavailable || attempts != 2 {
+		t.Fatalf("expected Unavailable after 2 attempts, got %v after %d", err, attempts)
+	}
+
+	attempts = 0
+	_, err = c.Interfaces().Get(context.Background(), "vm1")
+	if err == nil || attempts != 1 {
+		t.Fatalf("expected no retries without WithRetry, got %d attempts", attempts)
+	}
+}
+
+func TestWithRetryBudget(t *testing.T) {
+	attempts := 0
+	down := true
+	fake := &fakeLegacy{
+		getInterface: func(context.Context, string) (*api.Interface, error) {
+			attempts++
+			if down {
+				return &api.Interface{}, status.Error(codes.Unavailable, "down")
+			}
+			return &api.Interface{}, nil
+		},
+	}
+	budget := WithRetryBudget(0.5, 2)
+	now := time.Unix(1000, 0)
+	buildCallOptions(budget).retryBudget.now = func() time.Time { return now }
+	c := AsV2(fake, WithRetry(5, nil), budget)
+	get := func() int {
+		attempts = 0
+		_, _ = c.Interfaces().Get(context.Background(), "vm1")
+		return attempts
+	}
+
+	// The first call spends the two retries of the floor and half a retry
+	// of its own, later ones what the ratio adds for them.
+	if got := []int{get(), get(), get(), get()}; !slices.Equal(got, []int{3, 2, 1, 2}) {
+		t.Fatalf("expected retries to be throttled by the budget, got attempts %v", got)
+	}
+
+	// Healthy calls raise the budget of the window.
+	down = false
+	for i := 0; i < 4; i++ {
+		get()
+	}
+	down = true
+	if got := get(); got != 3 {
+		t.Fatalf("expected healthy calls to allow 2 retries, got %d attempts", got)
+	}
+
+	// Calls and retries leave the budget with the window.
+	now = now.Add(retryBudgetWindow)
+	if got := get(); got != 3 {
+		t.Fatalf("expected a new window to allow 2 retries, got %d attempts", got)
+	}
+}
+
+func TestWithRetryBudgetFloor(t *testing.T) {
+	attempts := 0
+	fake := &fakeLegacy{
+		getInterface: func(context.Context, string) (*api.Interface, error) {
+			attempts++
+			return &api.Interface{}, status.Error(codes.Unavailable, "down")
+		},
+	}
+	budget := WithRetryBudget(-1, 1)
+	now := time.Unix(1000, 0)
+	buildCallOptions(budget).retryBudget.now = func() time.Time { return now }
+	c := AsV2(fake, WithRetry(5, nil), budget)
+	get := func() int {
+		attempts = 0
+		_, _ = c.Interfaces().Get(context.Background(), "vm1")
+		return attempts
+	}
+
+	// A negative ratio is raised to 0, which leaves one retry per window.
+	got := []int{get(), get()}
+	now = now.Add(retryBudgetWindow)
+	got = append(got, get())
+	if !slices.Equal(got, []int{2, 1, 2}) {
+		t.Fatalf("expected one retry per window, got attempts %v", got)
+	}
+}
+
+func TestWithRetryStopsOnNonRetryable(t *testing.T) {
+	attempts := 0
+	fake := &fakeLegacy{
+		getInterface: func(context.Context, string) (*api.Interface, error) {
+			attempts++
+			if attempts == 1 {
+				return &api.Interface{}, status.Error(codes.Unavailable, "down")
+			}
+			return &api.Interface{}, status.Error(codes.InvalidArgument, "bad interface ID")
+		},
+	}
+
+	_, err := AsV2(fake).Interfaces().Get(context.Background(), "vm1", WithRetry(5, nil))
+	if status.Code(err) != codes.InvalidArgument || attempts != 2 {
+		t.Fatalf("expected InvalidArgument after 2 attempts, got %v after %d", err, attempts)
+	}
+}
+
+func TestWithRetryLogCoalesce(t *testing.T) {
+	fake := &fakeLegacy{
+		getInterface: func(context.Context, string) (*api.Interface, error) {
+			return &api.Interface{}, status.Error(codes.Unavailable, "down")
+		},
+	}
+	c := AsV2(fake)
+
+	logger, buf := bufferLogger()
+	_, _ = c.Interfaces().Get(context.Background(), "vm1", WithLogger(logger), WithRetry(4, nil))
+	if n := strings.Count(buf.String(), "dpservice call retrying"); n != 3 {
+		t.Fatalf("expected a line per retry, got %d in %q", n, buf.String())
+	}
+
+	buf.Reset()
+	_, _ = c.Interfaces().Get(context.Background(), "vm1", WithLogger(logger), WithRetry(4, nil), WithRetryLogCoalesce(true))
+	out := buf.String()
+	if strings.Contains(out, "dpservice call retrying") || strings.Count(out, "dpservice call retried 3 times") != 1 ||
+		!strings.Contains(out, "retries=3") || !strings.Contains(out, "Unavailable") {
+		t.Fatalf("expected a single summary of 3 retries with the final error, got %q", out)
+	}
+
+	buf.Reset()
+	fake.getInterface = nil
+	_, _ = c.Interfaces().Get(context.Background(), "vm1", WithLogger(logger), WithRetry(4, nil), WithRetryLogCoalesce(true))
+	if strings.Contains(buf.String(), "retried") {
+		t.Fatalf("expected no summary without retries, got %q", buf.String())
+	}
+}
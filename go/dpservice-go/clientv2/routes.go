@@ -0,0 +1,210 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"time"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+)
+
+// RouteDiff is the difference between a desired or baseline set of routes
+// and the current one. Routes are identified by their prefix.
+type RouteDiff struct {
+	// Missing holds desired routes that do not exist on the server.
+	Missing []*api.Route `json:"missing,omitempty"`
+	// Extra holds server routes that are not desired.
+	Extra []*api.Route `json:"extra,omitempty"`
+	// Mismatched holds routes whose prefix exists on both sides but whose
+	// next hop differs.
+	Mismatched []RouteMismatch `json:"mismatched,omitempty"`
+}
+
+// RouteMismatch pairs a desired route with the server route of the same
+// prefix.
+type RouteMismatch struct {
+	Desired *api.Route `json:"desired"`
+	Current *api.Route `json:"current"`
+}
+
+// InSync reports whether the server routes match the desired routes.
+func (d *RouteDiff) InSync() bool {
+	return len(d.Missing) == 0 && len(d.Extra) == 0 && len(d.Mismatched) == 0
+}
+
+// DiffRouteLists compares the routes of baseline with those of current, e.g.
+// a snapshot with the routes now on the server: routes only in baseline are
+// Missing, routes only in current are Extra and routes whose next hop
+// changed are Mismatched. The routes are reported in list order and point
+// into the lists. Nil lists are treated as empty, routes without a prefix
+// are ignored and of routes sharing a prefix only the first one counts.
+func DiffRouteLists(baseline, current *api.RouteList) *RouteDiff {
+	return diffRoutes(routePointers(baseline), routePointers(current))
+}
+
+func routePointers(list *api.RouteList) []*api.Route {
+	if list == nil {
+		return nil
+	}
+	routes := make([]*api.Route, len(list.Items))
+	for i := range list.Items {
+		routes[i] = &list.Items[i]
+	}
+	return routes
+}
+
+func (c *routeClient) Verify(ctx context.Context, vni uint32, desired []*api.Route, opts ...CallOption) (*RouteDiff, error) {
+	seen := make(map[netip.Prefix]bool, len(desired))
+	for i, route := range desired {
+		if route == nil || route.Spec.Prefix == nil {
+			return nil, fmt.Errorf("desired route %d has no prefix", i)
+		}
+		if seen[*route.Spec.Prefix] {
+			return nil, fmt.Errorf("desired route %d: duplicate prefix %s", i, route.Spec.Prefix)
+		}
+		seen[*route.Spec.Prefix] = true
+	}
+
+	current, err := c.List(ctx, vni, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return diffRoutes(desired, routePointers(current)), nil
+}
+
+// diffRoutes compares desired with current routes by prefix.
+func diffRoutes(desired, current []*api.Route) *RouteDiff {
+	want := indexRoutes(desired)
+	have := indexRoutes(current)
+
+	diff := &RouteDiff{}
+	for _, route := range current {
+		if route != nil && route.Spec.Prefix != nil && have[*route.Spec.Prefix] == route && want[*route.Spec.Prefix] == nil {
+			diff.Extra = append(diff.Extra, route)
+		}
+	}
+	for _, route := range desired {
+		if route == nil || route.Spec.Prefix == nil || want[*route.Spec.Prefix] != route {
+			continue
+		}
+		cur := have[*route.Spec.Prefix]
+		switch {
+		case cur == nil:
+			diff.Missing = append(diff.Missing, route)
+		case !sameNextHop(route.Spec.NextHop, cur.Spec.NextHop):
+			diff.Mismatched = append(diff.Mismatched, RouteMismatch{Desired: route, Current: cur})
+		}
+	}
+	return diff
+}
+
+// indexRoutes maps prefixes to the first route with that prefix.
+func indexRoutes(routes []*api.Route) map[netip.Prefix]*api.Route {
+	byPrefix := make(map[netip.Prefix]*api.Route, len(routes))
+	for _, route := range routes {
+		if route == nil || route.Spec.Prefix == nil {
+			continue
+		}
+		if _, ok := byPrefix[*route.Spec.Prefix]; !ok {
+			byPrefix[*route.Spec.Prefix] = route
+		}
+	}
+	return byPrefix
+}
+
+func sameNextHop(a, b *api.RouteNextHop) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if a.VNI != b.VNI {
+		return false
+	}
+	if a.IP == nil || b.IP == nil {
+		return a.IP == b.IP
+	}
+	return *a.IP == *b.IP
+}
+
+func (c *routeClient) CreateVia(ctx context.Context, vni uint32, dest netip.Prefix, nextHopVNI uint32, nextHopIP netip.Addr, opts ...CallOption) (*api.Route, error) {
+	if err := checkPrefix(DomainRoutes, "CreateVia", "dest", &dest); err != nil {
+		return nil, err
+	}
+	if err := checkAddr(DomainRoutes, "CreateVia", "nextHopIP", &nextHopIP); err != nil {
+		return nil, err
+	}
+	if !nextHopIP.Is6() || nextHopIP.Is4In6() {
+		return nil, &InvalidArgumentError{Domain: DomainRoutes, Method: "CreateVia", Argument: "nextHopIP", Reason: "next hop is not an IPv6 underlay address"}
+	}
+	return c.Create(ctx, &api.Route{
+		TypeMeta:  api.TypeMeta{Kind: api.RouteKind},
+		RouteMeta: api.RouteMeta{VNI: vni},
+		Spec: api.RouteSpec{
+			Prefix:  &dest,
+			NextHop: &api.RouteNextHop{VNI: nextHopVNI, IP: &nextHopIP},
+		},
+	}, opts...)
+}
+
+func (c *routeClient) Get(ctx context.Context, vni uint32, prefix netip.Prefix, opts ...CallOption) (*api.Route, error) {
+	if err := c.callOptions(opts).requireServerSide(DomainRoutes, "Get"); err != nil {
+		return nil, err
+	}
+	routes, err := c.List(ctx, vni, opts...)
+	if err != nil {
+		return nil, err
+	}
+	for i := range routes.Items {
+		if p := routes.Items[i].Spec.Prefix; p != nil && *p == prefix {
+			return &routes.Items[i], nil
+		}
+	}
+
+	status, err := c.notFound(DomainRoutes, "Get", opts, fmt.Sprintf("route %s not found in VNI %d", prefix, vni))
+	if err != nil {
+		return nil, err
+	}
+	return &api.Route{Status: status}, nil
+}
+
+func (c *routeClient) Exists(ctx context.Context, vni uint32, prefix netip.Prefix, opts ...CallOption) (bool, error) {
+	return exists(c.Get(ctx, vni, prefix, opts...))
+}
+
+func (c *routeClient) WaitForPrefix(ctx context.Context, vni uint32, prefix netip.Prefix, poll time.Duration, opts ...CallOption) (*api.Route, error) {
+	return waitFor(ctx, poll, fmt.Sprintf("route %s in VNI %d", prefix, vni), func(ctx context.Context) (*api.Route, error) {
+		return c.Get(ctx, vni, prefix, opts...)
+	}, func(*api.Route) bool { return true })
+}
+
+func (c *routeClient) ListMany(ctx context.Context, vnis []uint32, opts ...CallOption) (map[uint32]*api.RouteList, *BulkError) {
+	o := c.callOptions(opts)
+	ctx, cancel := o.bulkContext(ctx)
+	defer cancel()
+
+	if err := contextDone(ctx, DomainRoutes+".ListMany"); err != nil {
+		return map[uint32]*api.RouteList{}, failAll(len(vnis), err)
+	}
+
+	itemOpts := bulkItemOptions(opts)
+	lists := make([]*api.RouteList, len(vnis))
+	bulkErr := runBulk(ctx, len(vnis), o, func(ctx context.Context, i int) error {
+		list, err := c.List(ctx, vnis[i], itemOpts...)
+		if err != nil {
+			return err
+		}
+		lists[i] = list
+		return nil
+	})
+
+	byVNI := make(map[uint32]*api.RouteList, len(vnis))
+	for i, vni := range vnis {
+		if lists[i] != nil {
+			byVNI[vni] = lists[i]
+		}
+	}
+	return byVNI, bulkErr
+}
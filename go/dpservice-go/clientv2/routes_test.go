@@ -0,0 +1,179 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/netip"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+	dperrors "github.com/ironcore-dev/dpservice/go/dpservice-go/errors"
+)
+
+func testRoute(prefix string, vni uint32, ip string) api.Route {
+	p := netip.MustParsePrefix(prefix)
+	addr := netip.MustParseAddr(ip)
+	return api.Route{
+		RouteMeta: api.RouteMeta{VNI: 100},
+		Spec:      api.RouteSpec{Prefix: &p, NextHop: &api.RouteNextHop{VNI: vni, IP: &addr}},
+	}
+}
+
+func TestRoutesVerify(t *testing.T) {
+	fake := &fakeLegacy{
+		listRoutes: func(context.Context, uint32) (*api.RouteList, error) {
+			return &api.RouteList{Items: []api.Route{
+				testRoute("10.0.0.0/24", 100, "fc00::1"),
+				testRoute("10.0.1.0/24", 100, "fc00::2"),
+				testRoute("10.0.9.0/24", 100, "fc00::9"),
+			}}, nil
+		},
+	}
+	inSync := testRoute("10.0.0.0/24", 100, "fc00::1")
+	changed := testRoute("10.0.1.0/24", 200, "fc00::2")
+	missing := testRoute("10.0.2.0/24", 100, "fc00::3")
+
+	diff, err := AsV2(fake).Routes().Verify(context.Background(), 100, []*api.Route{&inSync, &changed, &missing})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff.InSync() {
+		t.Fatalf("expected drift")
+	}
+	if len(diff.Missing) != 1 || diff.Missing[0] != &missing {
+		t.Fatalf("expected 10.0.2.0/24 to be missing, got %+v", diff.Missing)
+	}
+	if len(diff.Extra) != 1 || diff.Extra[0].Spec.Prefix.String() != "10.0.9.0/24" {
+		t.Fatalf("expected 10.0.9.0/24 to be extra, got %+v", diff.Extra)
+	}
+	if len(diff.Mismatched) != 1 || diff.Mismatched[0].Desired != &changed || diff.Mismatched[0].Current.Spec.NextHop.VNI != 100 {
+		t.Fatalf("expected 10.0.1.0/24 to mismatch, got %+v", diff.Mismatched)
+	}
+
+	if _, err := AsV2(fake).Routes().Verify(context.Background(), 100, []*api.Route{&inSync, &inSync}); err == nil {
+		t.Fatalf("expected an error for duplicate desired prefixes")
+	}
+}
+
+func TestDiffRouteLists(t *testing.T) {
+	baseline := &api.RouteList{Items: []api.Route{
+		testRoute("10.0.0.0/24", 100, "fc00::1"),
+		testRoute("10.0.1.0/24", 100, "fc00::2"),
+		testRoute("10.0.2.0/24", 100, "fc00::3"),
+	}}
+	current := &api.RouteList{Items: []api.Route{
+		testRoute("10.0.9.0/24", 100, "fc00::9"),
+		testRoute("10.0.1.0/24", 100, "fc00::4"),
+		testRoute("10.0.0.0/24", 100, "fc00::1"),
+	}}
+
+	diff := DiffRouteLists(baseline, current)
+	if len(diff.Missing) != 1 || diff.Missing[0] != &baseline.Items[2] {
+		t.Fatalf("expected 10.0.2.0/24 to be missing, got %+v", diff.Missing)
+	}
+	if len(diff.Extra) != 1 || diff.Extra[0] != &current.Items[0] {
+		t.Fatalf("expected 10.0.9.0/24 to be extra, got %+v", diff.Extra)
+	}
+	if len(diff.Mismatched) != 1 || diff.Mismatched[0].Desired != &baseline.Items[1] || diff.Mismatched[0].Current != &current.Items[1] {
+		t.Fatalf("expected 10.0.1.0/24 to mismatch, got %+v", diff.Mismatched)
+	}
+
+	b, err := json.Marshal(diff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded RouteDiff
+	if err := json.Unmarshal(b, &decoded); err != nil || !reflect.DeepEqual(&decoded, diff) {
+		t.Fatalf("expected the diff to survive a JSON round trip, got %v, %s", err, b)
+	}
+
+	if diff := DiffRouteLists(nil, current); len(diff.Extra) != 3 || len(diff.Missing) != 0 {
+		t.Fatalf("expected every current route to be extra against a nil baseline, got %+v", diff)
+	}
+	if diff := DiffRouteLists(nil, nil); !diff.InSync() {
+		t.Fatalf("expected two nil lists to be in sync, got %+v", diff)
+	}
+}
+
+func TestRoutesWaitForPrefix(t *testing.T) {
+	polls := 0
+	fake := &fakeLegacy{
+		listRoutes: func(context.Context, uint32) (*api.RouteList, error) {
+			polls++
+			list := &api.RouteList{Items: []api.Route{testRoute("10.0.0.0/24", 1, "fc00::1")}}
+			if polls == 3 {
+				list.Items = append(list.Items, testRoute("10.0.1.0/24", 1, "fc00::2"))
+			}
+			return list, nil
+		},
+	}
+
+	route, err := AsV2(fake).Routes().WaitForPrefix(context.Background(), 1, netip.MustParsePrefix("10.0.1.0/24"), time.Millisecond)
+	if err != nil || route.Spec.NextHop.IP.String() != "fc00::2" || polls != 3 {
+		t.Fatalf("expected the route after 3 polls, got %v, %v after %d", route, err, polls)
+	}
+}
+
+func TestRoutesListMany(t *testing.T) {
+	fake := &fakeLegacy{
+		listRoutes: func(_ context.Context, vni uint32) (*api.RouteList, error) {
+			if vni == 300 {
+				return &api.RouteList{}, dperrors.NewStatusError(dperrors.OUT_OF_MEMORY, "oom")
+			}
+			return &api.RouteList{Items: []api.Route{testRoute("10.0.0.0/24", vni, "fc00::1")}}, nil
+		},
+	}
+
+	byVNI, bulkErr := AsV2(fake).Routes().ListMany(context.Background(), []uint32{100, 300, 200}, WithConcurrency(2))
+	if len(byVNI) != 2 || byVNI[100] == nil || byVNI[200].Items[0].Spec.NextHop.VNI != 200 {
+		t.Fatalf("expected the routes of VNIs 100 and 200, got %+v", byVNI)
+	}
+	if failed := bulkErr.Failed(); len(failed) != 1 || failed[0] != 1 {
+		t.Fatalf("expected VNI 300 at index 1 to fail, got %v", bulkErr)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if byVNI, bulkErr := AsV2(fake).Routes().ListMany(ctx, []uint32{100}); len(byVNI) != 0 || bulkErr == nil {
+		t.Fatalf("expected a canceled context to fail every VNI, got %+v, %v", byVNI, bulkErr)
+	}
+}
+
+func TestRoutesCreateVia(t *testing.T) {
+	var got *api.Route
+	fake := &fakeLegacy{
+		createRoute: func(_ context.Context, route *api.Route) (*api.Route, error) {
+			got = route
+			return route, nil
+		},
+	}
+	routes := AsV2(fake).Routes()
+
+	want := testRoute("10.0.0.0/24", 200, "fc00::1")
+	if _, err := routes.CreateVia(context.Background(), 100, *want.Spec.Prefix, 200, *want.Spec.NextHop.IP); err != nil {
+		t.Fatalf("CreateVia: %v", err)
+	}
+	want.Kind = api.RouteKind
+	if !reflect.DeepEqual(got, &want) {
+		t.Fatalf("expected route %+v, got %+v", want, got)
+	}
+
+	for name, nextHop := range map[string]netip.Addr{
+		"ipv4":        netip.MustParseAddr("10.0.0.1"),
+		"ipv4-mapped": netip.MustParseAddr("::ffff:10.0.0.1"),
+		"invalid":     {},
+	} {
+		got = nil
+		_, err := routes.CreateVia(context.Background(), 100, *want.Spec.Prefix, 200, nextHop)
+		var argErr *InvalidArgumentError
+		if !errors.As(err, &argErr) || argErr.Argument != "nextHopIP" || got != nil {
+			t.Errorf("%s: expected an *InvalidArgumentError before calling the server, got %v", name, err)
+		}
+	}
+}
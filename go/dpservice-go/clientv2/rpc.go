@@ -0,0 +1,165 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	dpdkproto "github.com/ironcore-dev/dpservice/go/dpservice-go/proto"
+)
+
+type grpcCallOptionsKey struct{}
+
+// withGRPCCallOptions returns a copy of ctx carrying additional gRPC call
+// options for the RPCs issued under it. The legacy client does not accept
+// gRPC call options, so they travel through the context to rpcClient.
+func withGRPCCallOptions(ctx context.Context, opts ...grpc.CallOption) context.Context {
+	prev := grpcCallOptionsFrom(ctx)
+	return context.WithValue(ctx, grpcCallOptionsKey{}, append(prev[:len(prev):len(prev)], opts...))
+}
+
+func grpcCallOptionsFrom(ctx context.Context) []grpc.CallOption {
+	opts, _ := ctx.Value(grpcCallOptionsKey{}).([]grpc.CallOption)
+	return opts
+}
+
+// rpcClient wraps the generated client used by NewFromProto and adds the
+// gRPC call options carried by the context to every RPC. Clients adapted
+// with AsV2 bypass it, so options relying on it have no effect for them.
+type rpcClient struct {
+	dpdkproto.DPDKironcoreClient
+}
+
+func (c *rpcClient) opts(ctx context.Context, opts []grpc.CallOption) []grpc.CallOption {
+	return append(append([]grpc.CallOption(nil), grpcCallOptionsFrom(ctx)...), opts...)
+}
+
+func (c *rpcClient) CheckInitialized(ctx context.Context, in *dpdkproto.CheckInitializedRequest, opts ...grpc.CallOption) (*dpdkproto.CheckInitializedResponse, error) {
+	return c.DPDKironcoreClient.CheckInitialized(ctx, in, c.opts(ctx, opts)...)
+}
+func (c *rpcClient) Initialize(ctx context.Context, in *dpdkproto.InitializeRequest, opts ...grpc.CallOption) (*dpdkproto.InitializeResponse, error) {
+	return c.DPDKironcoreClient.Initialize(ctx, in, c.opts(ctx, opts)...)
+}
+func (c *rpcClient) GetVersion(ctx context.Context, in *dpdkproto.GetVersionRequest, opts ...grpc.CallOption) (*dpdkproto.GetVersionResponse, error) {
+	return c.DPDKironcoreClient.GetVersion(ctx, in, c.opts(ctx, opts)...)
+}
+func (c *rpcClient) ListInterfaces(ctx context.Context, in *dpdkproto.ListInterfacesRequest, opts ...grpc.CallOption) (*dpdkproto.ListInterfacesResponse, error) {
+	return c.DPDKironcoreClient.ListInterfaces(ctx, in, c.opts(ctx, opts)...)
+}
+func (c *rpcClient) GetInterface(ctx context.Context, in *dpdkproto.GetInterfaceRequest, opts ...grpc.CallOption) (*dpdkproto.GetInterfaceResponse, error) {
+	return c.DPDKironcoreClient.GetInterface(ctx, in, c.opts(ctx, opts)...)
+}
+func (c *rpcClient) CreateInterface(ctx context.Context, in *dpdkproto.CreateInterfaceRequest, opts ...grpc.CallOption) (*dpdkproto.CreateInterfaceResponse, error) {
+	return c.DPDKironcoreClient.CreateInterface(ctx, in, c.opts(ctx, opts)...)
+}
+func (c *rpcClient) DeleteInterface(ctx context.Context, in *dpdkproto.DeleteInterfaceRequest, opts ...grpc.CallOption) (*dpdkproto.DeleteInterfaceResponse, error) {
+	return c.DPDKironcoreClient.DeleteInterface(ctx, in, c.opts(ctx, opts)...)
+}
+func (c *rpcClient) ListPrefixes(ctx context.Context, in *dpdkproto.ListPrefixesRequest, opts ...grpc.CallOption) (*dpdkproto.ListPrefixesResponse, error) {
+	return c.DPDKironcoreClient.ListPrefixes(ctx, in, c.opts(ctx, opts)...)
+}
+func (c *rpcClient) CreatePrefix(ctx context.Context, in *dpdkproto.CreatePrefixRequest, opts ...grpc.CallOption) (*dpdkproto.CreatePrefixResponse, error) {
+	return c.DPDKironcoreClient.CreatePrefix(ctx, in, c.opts(ctx, opts)...)
+}
+func (c *rpcClient) DeletePrefix(ctx context.Context, in *dpdkproto.DeletePrefixRequest, opts ...grpc.CallOption) (*dpdkproto.DeletePrefixResponse, error) {
+	return c.DPDKironcoreClient.DeletePrefix(ctx, in, c.opts(ctx, opts)...)
+}
+func (c *rpcClient) ListLoadBalancerPrefixes(ctx context.Context, in *dpdkproto.ListLoadBalancerPrefixesRequest, opts ...grpc.CallOption) (*dpdkproto.ListLoadBalancerPrefixesResponse, error) {
+	return c.DPDKironcoreClient.ListLoadBalancerPrefixes(ctx, in, c.opts(ctx, opts)...)
+}
+func (c *rpcClient) CreateLoadBalancerPrefix(ctx context.Context, in *dpdkproto.CreateLoadBalancerPrefixRequest, opts ...grpc.CallOption) (*dpdkproto.CreateLoadBalancerPrefixResponse, error) {
+	return c.DPDKironcoreClient.CreateLoadBalancerPrefix(ctx, in, c.opts(ctx, opts)...)
+}
+func (c *rpcClient) DeleteLoadBalancerPrefix(ctx context.Context, in *dpdkproto.DeleteLoadBalancerPrefixRequest, opts ...grpc.CallOption) (*dpdkproto.DeleteLoadBalancerPrefixResponse, error) {
+	return c.DPDKironcoreClient.DeleteLoadBalancerPrefix(ctx, in, c.opts(ctx, opts)...)
+}
+func (c *rpcClient) CreateVip(ctx context.Context, in *dpdkproto.CreateVipRequest, opts ...grpc.CallOption) (*dpdkproto.CreateVipResponse, error) {
+	return c.DPDKironcoreClient.CreateVip(ctx, in, c.opts(ctx, opts)...)
+}
+func (c *rpcClient) GetVip(ctx context.Context, in *dpdkproto.GetVipRequest, opts ...grpc.CallOption) (*dpdkproto.GetVipResponse, error) {
+	return c.DPDKironcoreClient.GetVip(ctx, in, c.opts(ctx, opts)...)
+}
+func (c *rpcClient) DeleteVip(ctx context.Context, in *dpdkproto.DeleteVipRequest, opts ...grpc.CallOption) (*dpdkproto.DeleteVipResponse, error) {
+	return c.DPDKironcoreClient.DeleteVip(ctx, in, c.opts(ctx, opts)...)
+}
+func (c *rpcClient) CreateLoadBalancer(ctx context.Context, in *dpdkproto.CreateLoadBalancerRequest, opts ...grpc.CallOption) (*dpdkproto.CreateLoadBalancerResponse, error) {
+	return c.DPDKironcoreClient.CreateLoadBalancer(ctx, in, c.opts(ctx, opts)...)
+}
+func (c *rpcClient) GetLoadBalancer(ctx context.Context, in *dpdkproto.GetLoadBalancerRequest, opts ...grpc.CallOption) (*dpdkproto.GetLoadBalancerResponse, error) {
+	return c.DPDKironcoreClient.GetLoadBalancer(ctx, in, c.opts(ctx, opts)...)
+}
+func (c *rpcClient) DeleteLoadBalancer(ctx context.Context, in *dpdkproto.DeleteLoadBalancerRequest, opts ...grpc.CallOption) (*dpdkproto.DeleteLoadBalancerResponse, error) {
+	return c.DPDKironcoreClient.DeleteLoadBalancer(ctx, in, c.opts(ctx, opts)...)
+}
+func (c *rpcClient) ListLoadBalancers(ctx context.Context, in *dpdkproto.ListLoadBalancersRequest, opts ...grpc.CallOption) (*dpdkproto.ListLoadBalancersResponse, error) {
+	return c.DPDKironcoreClient.ListLoadBalancers(ctx, in, c.opts(ctx, opts)...)
+}
+func (c *rpcClient) CreateLoadBalancerTarget(ctx context.Context, in *dpdkproto.CreateLoadBalancerTargetRequest, opts ...grpc.CallOption) (*dpdkproto.CreateLoadBalancerTargetResponse, error) {
+	return c.DPDKironcoreClient.CreateLoadBalancerTarget(ctx, in, c.opts(ctx, opts)...)
+}
+func (c *rpcClient) ListLoadBalancerTargets(ctx context.Context, in *dpdkproto.ListLoadBalancerTargetsRequest, opts ...grpc.CallOption) (*dpdkproto.ListLoadBalancerTargetsResponse, error) {
+	return c.DPDKironcoreClient.ListLoadBalancerTargets(ctx, in, c.opts(ctx, opts)...)
+}
+func (c *rpcClient) DeleteLoadBalancerTarget(ctx context.Context, in *dpdkproto.DeleteLoadBalancerTargetRequest, opts ...grpc.CallOption) (*dpdkproto.DeleteLoadBalancerTargetResponse, error) {
+	return c.DPDKironcoreClient.DeleteLoadBalancerTarget(ctx, in, c.opts(ctx, opts)...)
+}
+func (c *rpcClient) CreateNat(ctx context.Context, in *dpdkproto.CreateNatRequest, opts ...grpc.CallOption) (*dpdkproto.CreateNatResponse, error) {
+	return c.DPDKironcoreClient.CreateNat(ctx, in, c.opts(ctx, opts)...)
+}
+func (c *rpcClient) GetNat(ctx context.Context, in *dpdkproto.GetNatRequest, opts ...grpc.CallOption) (*dpdkproto.GetNatResponse, error) {
+	return c.DPDKironcoreClient.GetNat(ctx, in, c.opts(ctx, opts)...)
+}
+func (c *rpcClient) DeleteNat(ctx context.Context, in *dpdkproto.DeleteNatRequest, opts ...grpc.CallOption) (*dpdkproto.DeleteNatResponse, error) {
+	return c.DPDKironcoreClient.DeleteNat(ctx, in, c.opts(ctx, opts)...)
+}
+func (c *rpcClient) ListLocalNats(ctx context.Context, in *dpdkproto.ListLocalNatsRequest, opts ...grpc.CallOption) (*dpdkproto.ListLocalNatsResponse, error) {
+	return c.DPDKironcoreClient.ListLocalNats(ctx, in, c.opts(ctx, opts)...)
+}
+func (c *rpcClient) CreateNeighborNat(ctx context.Context, in *dpdkproto.CreateNeighborNatRequest, opts ...grpc.CallOption) (*dpdkproto.CreateNeighborNatResponse, error) {
+	return c.DPDKironcoreClient.CreateNeighborNat(ctx, in, c.opts(ctx, opts)...)
+}
+func (c *rpcClient) DeleteNeighborNat(ctx context.Context, in *dpdkproto.DeleteNeighborNatRequest, opts ...grpc.CallOption) (*dpdkproto.DeleteNeighborNatResponse, error) {
+	return c.DPDKironcoreClient.DeleteNeighborNat(ctx, in, c.opts(ctx, opts)...)
+}
+func (c *rpcClient) ListNeighborNats(ctx context.Context, in *dpdkproto.ListNeighborNatsRequest, opts ...grpc.CallOption) (*dpdkproto.ListNeighborNatsResponse, error) {
+	return c.DPDKironcoreClient.ListNeighborNats(ctx, in, c.opts(ctx, opts)...)
+}
+func (c *rpcClient) ListRoutes(ctx context.Context, in *dpdkproto.ListRoutesRequest, opts ...grpc.CallOption) (*dpdkproto.ListRoutesResponse, error) {
+	return c.DPDKironcoreClient.ListRoutes(ctx, in, c.opts(ctx, opts)...)
+}
+func (c *rpcClient) CreateRoute(ctx context.Context, in *dpdkproto.CreateRouteRequest, opts ...grpc.CallOption) (*dpdkproto.CreateRouteResponse, error) {
+	return c.DPDKironcoreClient.CreateRoute(ctx, in, c.opts(ctx, opts)...)
+}
+func (c *rpcClient) DeleteRoute(ctx context.Context, in *dpdkproto.DeleteRouteRequest, opts ...grpc.CallOption) (*dpdkproto.DeleteRouteResponse, error) {
+	return c.DPDKironcoreClient.DeleteRoute(ctx, in, c.opts(ctx, opts)...)
+}
+func (c *rpcClient) CheckVniInUse(ctx context.Context, in *dpdkproto.CheckVniInUseRequest, opts ...grpc.CallOption) (*dpdkproto.CheckVniInUseResponse, error) {
+	return c.DPDKironcoreClient.CheckVniInUse(ctx, in, c.opts(ctx, opts)...)
+}
+func (c *rpcClient) ResetVni(ctx context.Context, in *dpdkproto.ResetVniRequest, opts ...grpc.CallOption) (*dpdkproto.ResetVniResponse, error) {
+	return c.DPDKironcoreClient.ResetVni(ctx, in, c.opts(ctx, opts)...)
+}
+func (c *rpcClient) ListFirewallRules(ctx context.Context, in *dpdkproto.ListFirewallRulesRequest, opts ...grpc.CallOption) (*dpdkproto.ListFirewallRulesResponse, error) {
+	return c.DPDKironcoreClient.ListFirewallRules(ctx, in, c.opts(ctx, opts)...)
+}
+func (c *rpcClient) CreateFirewallRule(ctx context.Context, in *dpdkproto.CreateFirewallRuleRequest, opts ...grpc.CallOption) (*dpdkproto.CreateFirewallRuleResponse, error) {
+	return c.DPDKironcoreClient.CreateFirewallRule(ctx, in, c.opts(ctx, opts)...)
+}
+func (c *rpcClient) GetFirewallRule(ctx context.Context, in *dpdkproto.GetFirewallRuleRequest, opts ...grpc.CallOption) (*dpdkproto.GetFirewallRuleResponse, error) {
+	return c.DPDKironcoreClient.GetFirewallRule(ctx, in, c.opts(ctx, opts)...)
+}
+func (c *rpcClient) DeleteFirewallRule(ctx context.Context, in *dpdkproto.DeleteFirewallRuleRequest, opts ...grpc.CallOption) (*dpdkproto.DeleteFirewallRuleResponse, error) {
+	return c.DPDKironcoreClient.DeleteFirewallRule(ctx, in, c.opts(ctx, opts)...)
+}
+func (c *rpcClient) CaptureStart(ctx context.Context, in *dpdkproto.CaptureStartRequest, opts ...grpc.CallOption) (*dpdkproto.CaptureStartResponse, error) {
+	return c.DPDKironcoreClient.CaptureStart(ctx, in, c.opts(ctx, opts)...)
+}
+func (c *rpcClient) CaptureStop(ctx context.Context, in *dpdkproto.CaptureStopRequest, opts ...grpc.CallOption) (*dpdkproto.CaptureStopResponse, error) {
+	return c.DPDKironcoreClient.CaptureStop(ctx, in, c.opts(ctx, opts)...)
+}
+func (c *rpcClient) CaptureStatus(ctx context.Context, in *dpdkproto.CaptureStatusRequest, opts ...grpc.CallOption) (*dpdkproto.CaptureStatusResponse, error) {
+	return c.DPDKironcoreClient.CaptureStatus(ctx, in, c.opts(ctx, opts)...)
+}
@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"sync"
+)
+
+// SerializeKeyFunc maps a call to the key it is serialized under. args are
+// the arguments of the single-resource method, without the context and the
+// call options, e.g. the ID for Interfaces().Get or the *api.Interface for
+// Interfaces().Create. Composite methods such as Ensure are serialized per
+// RPC they make. An empty key leaves the call unserialized.
+type SerializeKeyFunc func(domain, method string, args ...any) string
+
+// WithSerializeByKey runs calls with equal keys one at a time, in the order
+// they acquire their key, while calls with different keys run in parallel.
+// It works around dpservice builds that mishandle concurrent mutations of
+// one resource; key typically returns the interface ID for mutations and ""
+// for reads, which opts them out.
+//
+// A call holds its key for all its attempts, including retries. Calls only
+// serialize against calls resolved from the same WithSerializeByKey option,
+// so pass it to the client constructor rather than per call. Waiting for a
+// key counts against the timeout of the call and fails with a *TimeoutError
+// or *CanceledError when ctx ends first.
+func WithSerializeByKey(key SerializeKeyFunc) CallOption {
+	s := &serializer{key: key, locks: make(map[string]*keyLock)}
+	return func(o *callOptions) {
+		o.serializer = s
+	}
+}
+
+// withCallArgs records the arguments of a call for WithSerializeByKey.
+func withCallArgs(opts []CallOption, args ...any) []CallOption {
+	return append(opts[:len(opts):len(opts)], func(o *callOptions) {
+		o.callArgs = args
+	})
+}
+
+type serializer struct {
+	key SerializeKeyFunc
+
+	mu    sync.Mutex
+	locks map[string]*keyLock
+}
+
+// keyLock is a mutex that can be waited on with a context. It is removed
+// from its serializer when no call holds or waits for it anymore.
+type keyLock struct {
+	ch   chan struct{}
+	refs int
+}
+
+// heldKeysKey marks the keys held by the call of a context, so that nested
+// calls, such as the lookup of a retried Create, do not wait for their own
+// caller.
+type heldKeysKey struct{}
+
+// serialized runs fn while holding the key of the call, if any.
+func serialized[T any](ctx context.Context, o callOptions, domain, method string, fn func(ctx context.Context) (T, error)) (T, error) {
+	if o.serializer == nil || o.serializer.key == nil {
+		return fn(ctx)
+	}
+	s := o.serializer
+	key := s.key(domain, method, o.callArgs...)
+	held, _ := ctx.Value(heldKeysKey{}).(map[string]bool)
+	if key == "" || held[key] {
+		return fn(ctx)
+	}
+
+	s.mu.Lock()
+	l := s.locks[key]
+	if l == nil {
+		l = &keyLock{ch: make(chan struct{}, 1)}
+		s.locks[key] = l
+	}
+	l.refs++
+	s.mu.Unlock()
+	defer s.release(key, l)
+
+	select {
+	case l.ch <- struct{}{}:
+	case <-ctx.Done():
+		var zero T
+		return zero, waitError(ctx, domain+"."+method+" serialization", nil)
+	}
+	defer func() { <-l.ch }()
+
+	nested := make(map[string]bool, len(held)+1)
+	for k := range held {
+		nested[k] = true
+	}
+	nested[key] = true
+	return fn(context.WithValue(ctx, heldKeysKey{}, nested))
+}
+
+func (s *serializer) release(key string, l *keyLock) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if l.refs--; l.refs == 0 {
+		delete(s.locks, key)
+	}
+}
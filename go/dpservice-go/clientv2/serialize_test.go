@@ -0,0 +1,151 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+	dperrors "github.com/ironcore-dev/dpservice/go/dpservice-go/errors"
+)
+
+// serializeByInterface serializes interface mutations by interface ID.
+func serializeByInterface(_, method string, args ...any) string {
+	switch method {
+	case "Create":
+		return args[0].(*api.Interface).ID
+	case "Delete":
+		return args[0].(string)
+	}
+	return ""
+}
+
+func TestWithSerializeByKey(t *testing.T) {
+	var mu sync.Mutex
+	running := map[string]int{}
+	maxRunning := map[string]int{}
+	var parallel, maxParallel int
+	track := func(id string) func() {
+		mu.Lock()
+		running[id]++
+		maxRunning[id] = max(maxRunning[id], running[id])
+		parallel++
+		maxParallel = max(maxParallel, parallel)
+		mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+		return func() {
+			mu.Lock()
+			running[id]--
+			parallel--
+			mu.Unlock()
+		}
+	}
+	fake := &fakeLegacy{
+		createInterface: func(_ context.Context, iface *api.Interface) (*api.Interface, error) {
+			defer track(iface.ID)()
+			return iface, nil
+		},
+		deleteInterface: func(_ context.Context, id string) (*api.Interface, error) {
+			defer track(id)()
+			return &api.Interface{}, nil
+		},
+	}
+	opt := WithSerializeByKey(serializeByInterface)
+	ifaces := AsV2(fake, opt).Interfaces()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		for _, id := range []string{"vm1", "vm2"} {
+			wg.Add(2)
+			go func(id string) {
+				defer wg.Done()
+				_, _ = ifaces.Create(context.Background(), &api.Interface{InterfaceMeta: api.InterfaceMeta{ID: id}})
+			}(id)
+			go func(id string) {
+				defer wg.Done()
+				_, _ = ifaces.Delete(context.Background(), id)
+			}(id)
+		}
+	}
+	wg.Wait()
+
+	if maxRunning["vm1"] != 1 || maxRunning["vm2"] != 1 {
+		t.Errorf("expected mutations of one interface to be serialized, got %v", maxRunning)
+	}
+	if maxParallel < 2 {
+		t.Errorf("expected mutations of different interfaces to run in parallel")
+	}
+}
+
+func TestSerializeByKeyContext(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{})
+	fake := &fakeLegacy{
+		deleteInterface: func(context.Context, string) (*api.Interface, error) {
+			close(started)
+			<-release
+			return &api.Interface{}, nil
+		},
+	}
+	ifaces := AsV2(fake, WithSerializeByKey(serializeByInterface)).Interfaces()
+
+	done := make(chan error)
+	go func() {
+		_, err := ifaces.Delete(context.Background(), "vm1")
+		done <- err
+	}()
+	<-started
+
+	// Reads are not serialized.
+	if _, err := ifaces.Get(context.Background(), "vm1"); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := ifaces.Delete(ctx, "vm1")
+	var timeout *TimeoutError
+	if !errors.As(err, &timeout) {
+		t.Errorf("expected TimeoutError while waiting for the key, got %v", err)
+	}
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSerializeByKeyNestedLookup(t *testing.T) {
+	attempts := 0
+	fake := &fakeLegacy{
+		createInterface: func(context.Context, *api.Interface) (*api.Interface, error) {
+			attempts++
+			if attempts == 1 {
+				return &api.Interface{}, status.Error(codes.Unavailable, "connection reset")
+			}
+			return &api.Interface{}, dperrors.NewStatusError(dperrors.ALREADY_EXISTS, "exists")
+		},
+		getInterface: func(_ context.Context, id string) (*api.Interface, error) {
+			return &api.Interface{InterfaceMeta: api.InterfaceMeta{ID: id}}, nil
+		},
+	}
+	// Serializing reads under the same key must not deadlock the lookup of
+	// the existing interface made while Create holds the key.
+	byID := WithSerializeByKey(func(_, _ string, _ ...any) string { return "vm1" })
+	ifaces := AsV2(fake, byID).Interfaces()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	iface := &api.Interface{InterfaceMeta: api.InterfaceMeta{ID: "vm1"}}
+	if _, err := ifaces.Create(ctx, iface, WithRetry(2, nil), WithIdempotencyKey("op-1")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,31 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import "errors"
+
+// ErrClientSideScan is the cause of the *NotSupportedError returned under
+// WithRequireServerSide.
+var ErrClientSideScan = errors.New("the server cannot filter, a client-side scan of the full list is required")
+
+// WithRequireServerSide makes methods that dpservice cannot serve directly
+// fail with a *NotSupportedError wrapping ErrClientSideScan instead of
+// transferring a whole list and filtering it client-side. These are Get and
+// Exists of routes, interface prefixes, loadbalancer prefixes and
+// loadbalancer targets, Interfaces().GetByDevice, NATs().GetNeighborByRoute,
+// and list calls with WithAddressFamily. The check is made before any RPC.
+func WithRequireServerSide() CallOption {
+	return func(o *callOptions) {
+		o.serverSideOnly = true
+	}
+}
+
+// requireServerSide returns the error of domain.method, which filters
+// client-side, under WithRequireServerSide.
+func (o callOptions) requireServerSide(domain, method string) error {
+	if !o.serverSideOnly {
+		return nil
+	}
+	return &NotSupportedError{Domain: domain, Method: method, Err: ErrClientSideScan}
+}
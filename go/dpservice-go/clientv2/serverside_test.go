@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"testing"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+)
+
+func TestWithRequireServerSide(t *testing.T) {
+	fake := &fakeLegacy{}
+	c := AsV2(fake, WithRequireServerSide())
+	ctx := context.Background()
+	prefix := netip.MustParsePrefix("10.0.0.0/24")
+
+	calls := map[string]error{
+		"Routes.Get":                    func() error { _, err := c.Routes().Get(ctx, 100, prefix); return err }(),
+		"Interfaces.Prefixes.Get":       func() error { _, err := c.Interfaces().Prefixes().Get(ctx, "vm1", prefix); return err }(),
+		"LoadBalancers.Prefixes.Exists": func() error { _, err := c.LoadBalancers().Prefixes().Exists(ctx, "vm1", prefix); return err }(),
+		"LoadBalancers.Targets.Get": func() error {
+			_, err := c.LoadBalancers().Targets().Get(ctx, "lb1", netip.MustParseAddr("fc00::1"))
+			return err
+		}(),
+		"Interfaces.List": func() error {
+			_, err := c.Interfaces().List(ctx, WithAddressFamily(AddressFamilyIPv4))
+			return err
+		}(),
+	}
+	for name, err := range calls {
+		var notSupported *NotSupportedError
+		if !errors.As(err, &notSupported) || !errors.Is(err, ErrClientSideScan) {
+			t.Errorf("%s: expected NotSupportedError wrapping ErrClientSideScan, got %v", name, err)
+		}
+	}
+	if len(fake.Calls()) != 0 {
+		t.Fatalf("expected no RPC, got %v", fake.Calls())
+	}
+
+	if _, err := c.Interfaces().List(ctx); err != nil {
+		t.Fatalf("expected plain lists to be allowed, got %v", err)
+	}
+	fake.createInterface = func(_ context.Context, iface *api.Interface) (*api.Interface, error) { return iface, nil }
+	iface := &api.Interface{InterfaceMeta: api.InterfaceMeta{ID: "vm1"}}
+	if _, err := c.Interfaces().Create(ctx, iface, WithAddressFamily(AddressFamilyIPv4)); err != nil {
+		t.Fatalf("expected WithAddressFamily not to affect calls that do not list, got %v", err)
+	}
+}
@@ -0,0 +1,30 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"strconv"
+	"time"
+)
+
+// ServerTimeoutMetadata is the gRPC metadata key carrying the budget of
+// WithServerTimeout in whole milliseconds.
+const ServerTimeoutMetadata = "x-dpservice-timeout-ms"
+
+// WithServerTimeout sends d as ServerTimeoutMetadata with every attempt of
+// the call, telling dpservice how long it may work on the request so that it
+// can abort expensive work. Budgets below a millisecond are rounded up and a
+// non-positive d sends nothing. The header is always sent, but whether it
+// has an effect depends on the server; the client deadline of the call is
+// not affected, see WithTimeout for that.
+func WithServerTimeout(d time.Duration) CallOption {
+	return func(o *callOptions) {
+		o.serverTimeout = d
+	}
+}
+
+// serverTimeoutValue formats d as the value of ServerTimeoutMetadata.
+func serverTimeoutValue(d time.Duration) string {
+	return strconv.FormatInt(int64((d+time.Millisecond-1)/time.Millisecond), 10)
+}
@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+)
+
+func TestWithServerTimeout(t *testing.T) {
+	var got []string
+	fake := &fakeLegacy{
+		getInterface: func(ctx context.Context, _ string) (*api.Interface, error) {
+			md, _ := metadata.FromOutgoingContext(ctx)
+			got = md.Get(ServerTimeoutMetadata)
+			return &api.Interface{}, nil
+		},
+	}
+	ifaces := AsV2(fake).Interfaces()
+
+	tests := []struct {
+		timeout time.Duration
+		want    string
+	}{
+		{1500 * time.Millisecond, "1500"},
+		{time.Microsecond, "1"},
+		{0, ""},
+	}
+	for _, tt := range tests {
+		if _, err := ifaces.Get(context.Background(), "vm1", WithServerTimeout(tt.timeout)); err != nil {
+			t.Fatal(err)
+		}
+		if tt.want == "" && len(got) != 0 || tt.want != "" && (len(got) != 1 || got[0] != tt.want) {
+			t.Errorf("%v: expected %q, got %v", tt.timeout, tt.want, got)
+		}
+	}
+}
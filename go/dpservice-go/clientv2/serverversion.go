@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+)
+
+// IncompatibleServerError is returned under WithRequireServerVersion when
+// the server is older than required. No RPC of the call is made.
+type IncompatibleServerError struct {
+	Domain string
+	Method string
+	// Required is the minimum version of WithRequireServerVersion.
+	Required string
+	// Actual is the version the server reports.
+	Actual string
+}
+
+func (e *IncompatibleServerError) Error() string {
+	return fmt.Sprintf("%s.%s requires dpservice %s or newer, the server runs %s", e.Domain, e.Method, e.Required, e.Actual)
+}
+
+// WithRequireServerVersion fails the call with an *IncompatibleServerError
+// if the server is older than min, e.g. "v0.3.1", for methods that only
+// behave correctly on newer servers. The server version is fetched with
+// GetVersion on first use and cached for the lifetime of the client.
+// Versions are compared by their numeric components; a leading "v" and any
+// suffix starting with "-" or "+", such as a pre-release or build, are
+// ignored.
+func WithRequireServerVersion(min string) CallOption {
+	return func(o *callOptions) {
+		o.minServerVersion = min
+	}
+}
+
+// versionCache holds the server version once it was fetched successfully.
+type versionCache struct {
+	mu      sync.Mutex
+	version string
+}
+
+// serverVersion returns the cached server version, fetching it first if
+// needed. Failed fetches are not cached.
+func (c *core) serverVersion(ctx context.Context) (string, error) {
+	c.version.mu.Lock()
+	defer c.version.mu.Unlock()
+	if c.version.version != "" {
+		return c.version.version, nil
+	}
+	v, err := c.legacy.GetVersion(ctx, &api.Version{})
+	if err != nil {
+		return "", fmt.Errorf("get server version: %w", err)
+	}
+	c.version.version = v.Spec.ServiceVersion
+	return c.version.version, nil
+}
+
+// checkServerVersion enforces WithRequireServerVersion for domain.method.
+func (c *core) checkServerVersion(ctx context.Context, o callOptions, domain, method string) error {
+	if o.minServerVersion == "" {
+		return nil
+	}
+	required, err := parseVersion(o.minServerVersion)
+	if err != nil {
+		return &InvalidArgumentError{Domain: domain, Method: method, Argument: "required server version", Reason: err.Error()}
+	}
+	actual, err := c.serverVersion(ctx)
+	if err != nil {
+		return err
+	}
+	parsed, err := parseVersion(actual)
+	if err != nil {
+		return fmt.Errorf("server version: %w", err)
+	}
+	if compareVersions(parsed, required) < 0 {
+		return &IncompatibleServerError{Domain: domain, Method: method, Required: o.minServerVersion, Actual: actual}
+	}
+	return nil
+}
+
+// parseVersion returns the numeric components of a version like "v0.3.1".
+func parseVersion(v string) ([]int, error) {
+	core := strings.TrimPrefix(v, "v")
+	if i := strings.IndexAny(core, "-+"); i >= 0 {
+		core = core[:i]
+	}
+	var parts []int
+	for _, s := range strings.Split(core, ".") {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("cannot parse version %q", v)
+		}
+		parts = append(parts, n)
+	}
+	return parts, nil
+}
+
+// compareVersions compares parsed versions, missing components being zero.
+func compareVersions(a, b []int) int {
+	for i := 0; i < max(len(a), len(b)); i++ {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+)
+
+func TestWithRequireServerVersion(t *testing.T) {
+	lookups := 0
+	fake := &fakeLegacy{
+		getVersion: func(_ context.Context, _ *api.Version) (*api.Version, error) {
+			lookups++
+			return &api.Version{Spec: api.VersionSpec{ServiceVersion: "v0.3.1-rc1"}}, nil
+		},
+	}
+	v2 := AsV2(fake)
+	ctx := context.Background()
+
+	for _, min := range []string{"v0.3", "0.3.1", "v0.2.9"} {
+		if _, err := v2.Interfaces().Get(ctx, "vm1", WithRequireServerVersion(min)); err != nil {
+			t.Errorf("%s: unexpected error %v", min, err)
+		}
+	}
+	_, err := v2.Routes().WithDefaults(WithRequireServerVersion("v0.4.0")).List(ctx, 42)
+	var incompatible *IncompatibleServerError
+	if !errors.As(err, &incompatible) {
+		t.Fatalf("expected IncompatibleServerError, got %v", err)
+	}
+	if incompatible.Required != "v0.4.0" || incompatible.Actual != "v0.3.1-rc1" || incompatible.Method != "List" {
+		t.Errorf("unexpected error %+v", incompatible)
+	}
+	if lookups != 1 {
+		t.Errorf("expected 1 version lookup, got %d", lookups)
+	}
+	for _, call := range fake.Calls() {
+		if call == "ListRoutes" {
+			t.Error("incompatible call reached the server")
+		}
+	}
+
+	var invalid *InvalidArgumentError
+	if _, err := v2.Interfaces().Get(ctx, "vm1", WithRequireServerVersion("latest")); !errors.As(err, &invalid) {
+		t.Errorf("expected InvalidArgumentError, got %v", err)
+	}
+}
+
+func TestServerVersionNotCachedOnError(t *testing.T) {
+	lookups := 0
+	fake := &fakeLegacy{
+		getVersion: func(_ context.Context, _ *api.Version) (*api.Version, error) {
+			lookups++
+			if lookups == 1 {
+				return nil, errors.New("unavailable")
+			}
+			return &api.Version{Spec: api.VersionSpec{ServiceVersion: "v1.0.0"}}, nil
+		},
+	}
+	ifaces := AsV2(fake).Interfaces()
+	if _, err := ifaces.Get(context.Background(), "vm1", WithRequireServerVersion("v1")); err == nil {
+		t.Fatal("expected error of the version lookup")
+	}
+	if _, err := ifaces.Get(context.Background(), "vm1", WithRequireServerVersion("v1")); err != nil {
+		t.Fatal(err)
+	}
+	if lookups != 2 {
+		t.Errorf("expected 2 version lookups, got %d", lookups)
+	}
+}
@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// WithSingleFlight makes concurrent Get calls for the same resource, e.g.
+// Interfaces().Get of the same ID, share a single RPC and its result. It
+// covers the Get methods of load balancers, interfaces, virtual IPs, NATs
+// and firewall rules; Create, Delete and List calls are never coalesced.
+//
+// Calls share an RPC only if they were resolved from the same WithSingleFlight
+// option, so pass it to the client constructor rather than per call. The
+// shared RPC runs under the context of the call that started it, and all
+// callers receive the same object, which must therefore not be modified.
+// Calls with WithFields, which rewrites the result, are not coalesced.
+func WithSingleFlight() CallOption {
+	group := new(singleflight.Group)
+	return func(o *callOptions) {
+		o.flights = group
+	}
+}
+
+// withFlightKey marks a call as coalescible under WithSingleFlight. Calls
+// with equal keys and ignored codes share their RPC.
+func withFlightKey(opts []CallOption, key string) []CallOption {
+	return append(opts[:len(opts):len(opts)], func(o *callOptions) {
+		o.flightKey = key
+	})
+}
+
+// callShared runs fn, through the group of WithSingleFlight if the call is
+// coalescible.
+func callShared[T any](ctx context.Context, o callOptions, domain, method string, fn func(ctx context.Context, ignored ...[]uint32) (T, error)) (T, error) {
+	if o.flights == nil || o.flightKey == "" || len(o.fields) > 0 {
+		return fn(ctx, o.legacyIgnored()...)
+	}
+	key := fmt.Sprintf("%s.%s %s %v", domain, method, o.flightKey, o.ignoredCodes)
+	res, err, _ := o.flights.Do(key, func() (any, error) {
+		return fn(ctx, o.legacyIgnored()...)
+	})
+	return res.(T), err
+}
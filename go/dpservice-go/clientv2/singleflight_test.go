@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+)
+
+func TestWithSingleFlight(t *testing.T) {
+	var rpcs atomic.Int32
+	release := make(chan struct{})
+	fake := &fakeLegacy{
+		getInterface: func(_ context.Context, id string) (*api.Interface, error) {
+			rpcs.Add(1)
+			<-release
+			return &api.Interface{InterfaceMeta: api.InterfaceMeta{ID: id}}, nil
+		},
+	}
+	ifaces := AsV2(fake, WithSingleFlight()).Interfaces()
+
+	const callers = 8
+	results := make([]*api.Interface, callers)
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			id := "vm1"
+			if i == 0 {
+				id = "vm2"
+			}
+			iface, err := ifaces.Get(context.Background(), id)
+			if err != nil {
+				t.Error(err)
+			}
+			results[i] = iface
+		}(i)
+	}
+	// Give the callers time to join the in-flight calls.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := rpcs.Load(); n != 2 {
+		t.Fatalf("expected one RPC per interface ID, got %d", n)
+	}
+	for i := 2; i < callers; i++ {
+		if results[i] != results[1] || results[i].ID != "vm1" {
+			t.Fatalf("expected the callers of vm1 to share the result, got %+v and %+v", results[i], results[1])
+		}
+	}
+	if results[0].ID != "vm2" {
+		t.Fatalf("expected vm2, got %+v", results[0])
+	}
+
+	// Sequential calls do not share anything.
+	if _, err := ifaces.Get(context.Background(), "vm1"); err != nil || rpcs.Load() != 3 {
+		t.Fatalf("expected a new RPC after the flight ended, got %v, %d RPCs", err, rpcs.Load())
+	}
+}
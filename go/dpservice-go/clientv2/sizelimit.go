@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// WithResponseSizeLimit fails list calls returning more than maxItems items
+// with a *ResponseTooLargeError and drops the items of the result. It guards
+// against misbehaving servers; the gRPC max receive message size still bounds
+// what is decoded in the first place. Values below 1 disable the limit.
+func WithResponseSizeLimit(maxItems int) CallOption {
+	return func(o *callOptions) {
+		o.maxItems = maxItems
+	}
+}
+
+// WithTruncateOversized makes list calls exceeding WithResponseSizeLimit
+// return the first maxItems items instead of failing. If truncated is not
+// nil, it reports whether items were dropped; the call writes it without
+// synchronization, so it must not be shared between concurrent calls.
+func WithTruncateOversized(truncated *bool) CallOption {
+	return func(o *callOptions) {
+		o.truncate = true
+		o.truncated = truncated
+	}
+}
+
+// ResponseTooLargeError is returned when a list result exceeds the limit set
+// with WithResponseSizeLimit.
+type ResponseTooLargeError struct {
+	Domain string
+	Method string
+	// Items is the number of items the server returned.
+	Items int
+	Limit int
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("%s.%s returned %d items, exceeding the limit of %d", e.Domain, e.Method, e.Items, e.Limit)
+}
+
+// limitItems enforces the size limit of o on the Items of a list result.
+func limitItems(domain, method string, res any, o callOptions) error {
+	if o.truncated != nil {
+		*o.truncated = false
+	}
+	v := reflect.ValueOf(res)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	items := v.Elem().FieldByName("Items")
+	if !items.IsValid() || items.Kind() != reflect.Slice || items.Len() <= o.maxItems {
+		return nil
+	}
+
+	if o.truncate {
+		items.Set(items.Slice(0, o.maxItems))
+		if o.truncated != nil {
+			*o.truncated = true
+		}
+		return nil
+	}
+	n := items.Len()
+	items.Set(items.Slice(0, 0))
+	return &ResponseTooLargeError{Domain: domain, Method: method, Items: n, Limit: o.maxItems}
+}
@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestWithResponseSizeLimit(t *testing.T) {
+	fake := &fakeLegacy{listInterfaces: interfaceList("vm1", "vm2", "vm3")}
+	ifaces := AsV2(fake).Interfaces()
+
+	list, err := ifaces.List(context.Background(), WithResponseSizeLimit(2))
+	var tooLarge *ResponseTooLargeError
+	if !errors.As(err, &tooLarge) || tooLarge.Items != 3 || tooLarge.Limit != 2 {
+		t.Fatalf("expected a ResponseTooLargeError, got %v", err)
+	}
+	if len(list.Items) != 0 {
+		t.Fatalf("expected the items to be dropped, got %d", len(list.Items))
+	}
+
+	var truncated bool
+	list, err = ifaces.List(context.Background(), WithResponseSizeLimit(2), WithTruncateOversized(&truncated))
+	if err != nil || !truncated || len(list.Items) != 2 || list.Items[1].ID != "vm2" {
+		t.Fatalf("expected the first 2 items, got %+v, truncated %v, %v", list.Items, truncated, err)
+	}
+
+	list, err = ifaces.List(context.Background(), WithResponseSizeLimit(3), WithTruncateOversized(&truncated))
+	if err != nil || truncated || len(list.Items) != 3 {
+		t.Fatalf("expected all 3 items untruncated, got %d, truncated %v, %v", len(list.Items), truncated, err)
+	}
+
+	// Non-list results are not affected.
+	if _, err := ifaces.Get(context.Background(), "vm1", WithResponseSizeLimit(1)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
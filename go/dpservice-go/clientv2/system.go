@@ -0,0 +1,153 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+	dperrors "github.com/ironcore-dev/dpservice/go/dpservice-go/errors"
+)
+
+func (c *systemClient) InitializeAndID(ctx context.Context, opts ...CallOption) (uuid.UUID, error) {
+	init, err := c.Initialize(ctx, opts...)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return InitializedID(init)
+}
+
+func (c *systemClient) CheckInitializedID(ctx context.Context, opts ...CallOption) (uuid.UUID, error) {
+	init, err := c.CheckInitialized(ctx, opts...)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return InitializedID(init)
+}
+
+// InitializedID parses the instance UUID of an Initialize or CheckInitialized
+// result. The UUID changes whenever dpservice restarts.
+func InitializedID(init *api.Initialized) (uuid.UUID, error) {
+	if init == nil || init.Spec.UUID == "" {
+		return uuid.Nil, fmt.Errorf("server returned no instance UUID")
+	}
+	id, err := uuid.Parse(init.Spec.UUID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("server returned malformed instance UUID %q: %w", init.Spec.UUID, err)
+	}
+	return id, nil
+}
+
+// ResourceSummary holds the number of resources on a dpservice instance. A
+// count is zero if its section failed, see the matching error field.
+type ResourceSummary struct {
+	Interfaces    int
+	LoadBalancers int
+	// NATs is the number of interfaces with a NAT.
+	NATs int
+	// Routes is the number of routes of the VNIs given with
+	// WithSummaryVNIs. Routes are not counted without them.
+	Routes int
+
+	InterfacesErr    error
+	LoadBalancersErr error
+	NATsErr          error
+	RoutesErr        error
+}
+
+// WithSummaryVNIs makes System().Summary count the routes of vnis. dpservice
+// cannot list its VNIs, so they have to be given.
+func WithSummaryVNIs(vnis ...uint32) CallOption {
+	return func(o *callOptions) {
+		o.summaryVNIs = append(o.summaryVNIs, vnis...)
+	}
+}
+
+func (c *systemClient) Summary(ctx context.Context, opts ...CallOption) (*ResourceSummary, error) {
+	if !c.callOptions(opts).detached {
+		if err := contextDone(ctx, DomainSystem+".Summary"); err != nil {
+			return nil, err
+		}
+	}
+	summary := &ResourceSummary{}
+	var wg sync.WaitGroup
+	section := func(count *int, errp *error, fn func() (int, error)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			*count, *errp = fn()
+		}()
+	}
+
+	section(&summary.Interfaces, &summary.InterfacesErr, func() (int, error) {
+		list, err := (&ifaceClient{core: c.core}).List(ctx, opts...)
+		if err != nil {
+			return 0, err
+		}
+		return len(list.Items), nil
+	})
+	section(&summary.LoadBalancers, &summary.LoadBalancersErr, func() (int, error) {
+		list, err := (&lbClient{core: c.core}).List(ctx, opts...)
+		if err != nil {
+			return 0, err
+		}
+		return len(list.Items), nil
+	})
+	section(&summary.NATs, &summary.NATsErr, func() (int, error) {
+		// Partial results are counted along with the error.
+		nats, err := (&natClient{core: c.core}).ListByInterface(ctx, opts...)
+		n := 0
+		for _, nat := range nats {
+			if nat != nil {
+				n++
+			}
+		}
+		return n, err
+	})
+	vnis := c.callOptions(opts).summaryVNIs
+	if len(vnis) > 0 {
+		section(&summary.Routes, &summary.RoutesErr, func() (int, error) {
+			routes := &routeClient{core: c.core}
+			n := 0
+			var errs []error
+			for _, vni := range vnis {
+				list, err := routes.List(ctx, vni, opts...)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("VNI %d: %w", vni, err))
+					continue
+				}
+				n += len(list.Items)
+			}
+			return n, errors.Join(errs...)
+		})
+	}
+	wg.Wait()
+
+	if summary.InterfacesErr != nil && summary.LoadBalancersErr != nil && summary.NATsErr != nil &&
+		(len(vnis) == 0 || summary.RoutesErr != nil) {
+		return summary, fmt.Errorf("summary: every section failed: %w", errors.Join(summary.InterfacesErr, summary.LoadBalancersErr, summary.NATsErr, summary.RoutesErr))
+	}
+	return summary, nil
+}
+
+func (c *systemClient) ResetAllVnis(ctx context.Context, vniType uint8, vnis []uint32, opts ...CallOption) *BulkError {
+	o := c.callOptions(opts)
+	ctx, cancel := o.bulkContext(ctx)
+	defer cancel()
+
+	if err := contextDone(ctx, DomainSystem+".ResetAllVnis"); err != nil {
+		return failAll(len(vnis), err)
+	}
+
+	itemOpts := append(bulkItemOptions(opts), WithIgnoredCodes(dperrors.NO_VNI))
+	return runBulk(ctx, len(vnis), o, func(ctx context.Context, i int) error {
+		_, err := c.ResetVni(ctx, vnis[i], vniType, itemOpts...)
+		return err
+	})
+}
@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+	dperrors "github.com/ironcore-dev/dpservice/go/dpservice-go/errors"
+)
+
+func TestInitializedID(t *testing.T) {
+	id := uuid.New()
+	reply := id.String()
+	fake := &fakeLegacy{
+		initialize: func(context.Context) (*api.Initialized, error) {
+			return &api.Initialized{Spec: api.InitializedSpec{UUID: reply}}, nil
+		},
+		checkInitialized: func(context.Context) (*api.Initialized, error) {
+			return &api.Initialized{Spec: api.InitializedSpec{UUID: reply}}, nil
+		},
+	}
+	system := AsV2(fake).System()
+
+	if got, err := system.InitializeAndID(context.Background()); err != nil || got != id {
+		t.Fatalf("expected %s, got %s, %v", id, got, err)
+	}
+	if got, err := system.CheckInitializedID(context.Background()); err != nil || got != id {
+		t.Fatalf("expected %s, got %s, %v", id, got, err)
+	}
+
+	for _, bad := range []string{"", "not-a-uuid"} {
+		reply = bad
+		if got, err := system.CheckInitializedID(context.Background()); err == nil || got != uuid.Nil {
+			t.Fatalf("expected an error for %q, got %s", bad, got)
+		}
+	}
+}
+
+func TestSystemSummary(t *testing.T) {
+	fake := &fakeLegacy{
+		listInterfaces: interfaceList("vm1", "vm2"),
+		listLoadBalancers: func(context.Context) (*api.LoadBalancerList, error) {
+			return nil, dperrors.NewStatusError(dperrors.OUT_OF_MEMORY, "oom")
+		},
+		getNat: func(_ context.Context, id string) (*api.Nat, error) {
+			if id == "vm2" {
+				return &api.Nat{}, dperrors.NewStatusError(dperrors.SNAT_NO_DATA, "no nat")
+			}
+			return &api.Nat{NatMeta: api.NatMeta{InterfaceID: id}}, nil
+		},
+		listRoutes: func(context.Context, uint32) (*api.RouteList, error) {
+			return &api.RouteList{Items: []api.Route{testRoute("10.0.0.0/24", 1, "fc00::1"), testRoute("10.0.1.0/24", 1, "fc00::1")}}, nil
+		},
+	}
+	system := AsV2(fake).System()
+
+	summary, err := system.Summary(context.Background(), WithSummaryVNIs(1, 2))
+	if err != nil {
+		t.Fatalf("expected no error with partial results, got %v", err)
+	}
+	if summary.Interfaces != 2 || summary.NATs != 1 || summary.Routes != 4 || summary.LoadBalancers != 0 {
+		t.Fatalf("unexpected counts %+v", summary)
+	}
+	if !dperrors.IsStatusErrorCode(summary.LoadBalancersErr, dperrors.OUT_OF_MEMORY) || summary.InterfacesErr != nil {
+		t.Fatalf("expected only the load balancer section to fail, got %+v", summary)
+	}
+
+	summary, err = system.Summary(context.Background())
+	if err != nil || summary.Routes != 0 || summary.RoutesErr != nil {
+		t.Fatalf("expected routes to be skipped without VNIs, got %+v, %v", summary, err)
+	}
+}
+
+func TestSystemResetAllVnis(t *testing.T) {
+	var mu sync.Mutex
+	var reset []uint32
+	fake := &fakeLegacy{
+		resetVni: func(_ context.Context, vni uint32, _ uint8) (*api.Vni, error) {
+			switch vni {
+			case 2:
+				return &api.Vni{}, dperrors.NewStatusError(dperrors.NO_VNI, "not in use")
+			case 3:
+				return &api.Vni{}, dperrors.NewStatusError(dperrors.WRONG_TYPE, "wrong type")
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			reset = append(reset, vni)
+			return &api.Vni{}, nil
+		},
+	}
+
+	bulkErr := AsV2(fake).System().ResetAllVnis(context.Background(), 2, []uint32{1, 2, 3, 4}, WithConcurrency(2))
+	if bulkErr == nil || len(bulkErr.Items) != 1 || bulkErr.Items[0].Index != 2 {
+		t.Fatalf("expected only VNI 3 to fail, got %v", bulkErr)
+	}
+	slices.Sort(reset)
+	if !slices.Equal(reset, []uint32{1, 4}) {
+		t.Fatalf("expected VNIs 1 and 4 to be reset, got %v", reset)
+	}
+}
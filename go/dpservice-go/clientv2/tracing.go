@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the instrumentation scope of the spans started by the client.
+const tracerName = "github.com/ironcore-dev/dpservice/go/dpservice-go/clientv2"
+
+// WithTracerProvider starts a client span named "Domain.Method" for every
+// call, ended when the call returns and marked as failed if it fails. The
+// span is a child of the span of the call context and is what gets
+// propagated (see WithPropagators). Without it, the client starts no spans.
+func WithTracerProvider(tp trace.TracerProvider) CallOption {
+	return func(o *callOptions) {
+		o.tracerProvider = tp
+	}
+}
+
+// WithSpanFilter decides per call whether WithTracerProvider starts a span,
+// for example to skip high-frequency calls such as System.Ping. Calls without
+// a span still propagate the span of their context. By default every call
+// is traced.
+func WithSpanFilter(filter func(domain, method string) bool) CallOption {
+	return func(o *callOptions) {
+		o.spanFilter = filter
+	}
+}
+
+// startSpan starts the span of domain.method if tracing is enabled and the
+// call passes the span filter. The returned function ends the span with the
+// outcome of the call.
+func startSpan(ctx context.Context, o callOptions, domain, method string) (context.Context, func(error)) {
+	if o.tracerProvider == nil || (o.spanFilter != nil && !o.spanFilter(domain, method)) {
+		return ctx, func(error) {}
+	}
+	ctx, span := o.tracerProvider.Tracer(tracerName).Start(ctx, domain+"."+method, trace.WithSpanKind(trace.SpanKindClient))
+	return ctx, func(err error) {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
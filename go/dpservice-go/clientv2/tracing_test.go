@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+	dperrors "github.com/ironcore-dev/dpservice/go/dpservice-go/errors"
+)
+
+// fakeTracerProvider records the spans started through it.
+type fakeTracerProvider struct {
+	noop.TracerProvider
+	mu    sync.Mutex
+	spans []*fakeSpan
+}
+
+func (p *fakeTracerProvider) Tracer(string, ...trace.TracerOption) trace.Tracer {
+	return fakeTracer{provider: p}
+}
+
+type fakeTracer struct {
+	noop.Tracer
+	provider *fakeTracerProvider
+}
+
+func (t fakeTracer) Start(ctx context.Context, name string, _ ...trace.SpanStartOption) (context.Context, trace.Span) {
+	span := &fakeSpan{name: name}
+	t.provider.mu.Lock()
+	t.provider.spans = append(t.provider.spans, span)
+	t.provider.mu.Unlock()
+	return trace.ContextWithSpan(ctx, span), span
+}
+
+type fakeSpan struct {
+	noop.Span
+	name   string
+	status codes.Code
+	ended  bool
+}
+
+func (s *fakeSpan) SetStatus(code codes.Code, _ string) { s.status = code }
+func (s *fakeSpan) End(...trace.SpanEndOption)          { s.ended = true }
+
+func TestWithTracerProvider(t *testing.T) {
+	var inCall trace.Span
+	fake := &fakeLegacy{
+		getInterface: func(ctx context.Context, _ string) (*api.Interface, error) {
+			inCall = trace.SpanFromContext(ctx)
+			return &api.Interface{}, dperrors.NewStatusError(dperrors.OUT_OF_MEMORY, "oom")
+		},
+	}
+	tp := &fakeTracerProvider{}
+	c := AsV2(fake, WithTracerProvider(tp))
+
+	if _, err := c.Interfaces().Get(context.Background(), "vm1"); err == nil {
+		t.Fatal("expected the call to fail")
+	}
+	if len(tp.spans) != 1 {
+		t.Fatalf("expected one span, got %d", len(tp.spans))
+	}
+	span := tp.spans[0]
+	if span.name != DomainInterfaces+".Get" || !span.ended || span.status != codes.Error || inCall != span {
+		t.Fatalf("expected an ended, failed span visible to the call, got %+v", span)
+	}
+}
+
+func TestWithSpanFilter(t *testing.T) {
+	tp := &fakeTracerProvider{}
+	c := AsV2(&fakeLegacy{}, WithTracerProvider(tp), WithSpanFilter(func(domain, method string) bool {
+		return domain != DomainSystem
+	}))
+
+	if _, err := c.System().GetVersion(context.Background(), &api.Version{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Interfaces().Get(context.Background(), "vm1"); err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, span := range tp.spans {
+		names = append(names, span.name)
+	}
+	if want := []string{DomainInterfaces + ".Get"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("expected spans %v, got %v", want, names)
+	}
+
+	if _, err := AsV2(&fakeLegacy{}, WithSpanFilter(func(string, string) bool { return true })).Interfaces().Get(context.Background(), "vm1"); err != nil || len(tp.spans) != 1 {
+		t.Fatalf("expected no spans without a tracer provider, got %v, %d spans", err, len(tp.spans))
+	}
+}
@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"google.golang.org/grpc/metadata"
+)
+
+// WithCaptureTrailers stores the gRPC response trailers of the call in md.
+// If the call fails, the returned error is additionally wrapped in a
+// *TrailerError carrying them. With WithRetry, md holds the trailers of the
+// last attempt.
+//
+// Trailers are only captured for clients created with NewFromProto. The
+// legacy client does not accept gRPC call options, so for clients adapted
+// with AsV2 md stays empty. The call writes md without synchronization, so
+// it must not be shared between concurrent calls.
+func WithCaptureTrailers(md *metadata.MD) CallOption {
+	return func(o *callOptions) {
+		if md != nil {
+			o.trailer = md
+		}
+	}
+}
+
+// TrailerError wraps the error of a call made with WithCaptureTrailers
+// together with the trailers the server sent.
+type TrailerError struct {
+	Err     error
+	Trailer metadata.MD
+}
+
+func (e *TrailerError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *TrailerError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	dperrors "github.com/ironcore-dev/dpservice/go/dpservice-go/errors"
+	dpdkproto "github.com/ironcore-dev/dpservice/go/dpservice-go/proto"
+)
+
+// trailerRPC answers GetInterface with the given status code and sets a
+// diagnostic trailer on every call that asks for it.
+type trailerRPC struct {
+	dpdkproto.DPDKironcoreClient
+	code uint32
+}
+
+func (r *trailerRPC) GetInterface(_ context.Context, _ *dpdkproto.GetInterfaceRequest, opts ...grpc.CallOption) (*dpdkproto.GetInterfaceResponse, error) {
+	for _, opt := range opts {
+		if t, ok := opt.(grpc.TrailerCallOption); ok {
+			*t.TrailerAddr = metadata.Pairs("x-dp-diag", "lookup took 3us")
+		}
+	}
+	return &dpdkproto.GetInterfaceResponse{
+		Status:    &dpdkproto.Status{Code: r.code},
+		Interface: &dpdkproto.Interface{Id: []byte("vm1"), PrimaryIpv4: []byte("10.0.0.1"), PrimaryIpv6: []byte("fc00::1"), MeteringParams: &dpdkproto.MeteringParams{}},
+	}, nil
+}
+
+func TestWithCaptureTrailers(t *testing.T) {
+	rpc := &trailerRPC{}
+	c := NewFromProto(rpc)
+
+	var md metadata.MD
+	if _, err := c.Interfaces().Get(context.Background(), "vm1", WithCaptureTrailers(&md)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := md.Get("x-dp-diag"); len(got) != 1 {
+		t.Fatalf("expected the diagnostic trailer, got %v", md)
+	}
+
+	rpc.code = dperrors.NOT_FOUND
+	md = nil
+	_, err := c.Interfaces().Get(context.Background(), "vm1", WithCaptureTrailers(&md))
+	var trailerErr *TrailerError
+	if !errors.As(err, &trailerErr) || len(trailerErr.Trailer.Get("x-dp-diag")) != 1 {
+		t.Fatalf("expected a TrailerError with the diagnostic trailer, got %v", err)
+	}
+	if !IsNotFound(err) {
+		t.Fatalf("expected the status error to stay visible, got %v", err)
+	}
+}
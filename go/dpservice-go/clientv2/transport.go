@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"fmt"
+)
+
+// Invoker performs a single attempt of a call and returns its result, a
+// pointer to an api type such as *api.Interface.
+type Invoker func(ctx context.Context) (any, error)
+
+// Transport wraps every attempt of a call. It may delay the call, fail it
+// without calling next, or alter the result, which makes it a slot for fault
+// injection in tests; see package faultinject. A returned result must have
+// the type of the result of next.
+type Transport func(ctx context.Context, domain, method string, next Invoker) (any, error)
+
+// WithTransport routes calls through t. It is usually passed to a
+// constructor so that it applies to every call of the client.
+func WithTransport(t Transport) CallOption {
+	return func(o *callOptions) {
+		o.transport = t
+	}
+}
+
+// viaTransport runs attempt through the transport of o, if any.
+func viaTransport[T any](ctx context.Context, o callOptions, domain, method string, attempt func(ctx context.Context) (T, error)) (T, error) {
+	if o.transport == nil {
+		return attempt(ctx)
+	}
+	res, err := o.transport(ctx, domain, method, func(ctx context.Context) (any, error) {
+		return attempt(ctx)
+	})
+	typed, ok := res.(T)
+	if !ok && res != nil {
+		return typed, fmt.Errorf("%s.%s: transport returned %T, want %T", domain, method, res, typed)
+	}
+	return typed, err
+}
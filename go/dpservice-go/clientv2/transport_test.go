@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+)
+
+func TestWithTransport(t *testing.T) {
+	fake := &fakeLegacy{
+		getInterface: func(_ context.Context, id string) (*api.Interface, error) {
+			return &api.Interface{InterfaceMeta: api.InterfaceMeta{ID: id}, Spec: api.InterfaceSpec{VNI: 100}}, nil
+		},
+	}
+	var seen []string
+	corrupt := WithTransport(func(ctx context.Context, domain, method string, next Invoker) (any, error) {
+		seen = append(seen, domain+"."+method)
+		res, err := next(ctx)
+		res.(*api.Interface).Spec.VNI = 0
+		return res, err
+	})
+
+	iface, err := AsV2(fake, corrupt).Interfaces().Get(context.Background(), "vm1")
+	if err != nil || iface.Spec.VNI != 0 {
+		t.Fatalf("expected the transport to alter the result, got %+v, %v", iface, err)
+	}
+	if len(seen) != 1 || seen[0] != "Interfaces.Get" {
+		t.Fatalf("expected the transport to see Interfaces.Get, got %v", seen)
+	}
+
+	wrongType := WithTransport(func(context.Context, string, string, Invoker) (any, error) {
+		return &api.Route{}, nil
+	})
+	if _, err := AsV2(fake, wrongType).Interfaces().Get(context.Background(), "vm1"); err == nil || !strings.Contains(err.Error(), "*api.Route") {
+		t.Fatalf("expected a type mismatch error, got %v", err)
+	}
+}
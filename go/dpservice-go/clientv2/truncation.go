@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"fmt"
+	"strconv"
+
+	"google.golang.org/grpc/metadata"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+)
+
+// TruncatedMetadata is the trailer key with which a server reports that it
+// left items out of a list response, e.g. "true". dpservice does not send it
+// yet, so list calls never fail with a *TruncatedError today; the check is in
+// place for servers that do.
+const TruncatedMetadata = "x-dpservice-truncated"
+
+// TruncatedError is returned along with the partial result when the server
+// reports that a list response is incomplete. Callers needing every item
+// have to narrow the query or retry later. The trailer is only seen by
+// clients that make the RPCs themselves, i.e. not by clients adapted with
+// AsV2.
+type TruncatedError struct {
+	Domain string
+	Method string
+	// Items is the number of items the server did return.
+	Items int
+}
+
+func (e *TruncatedError) Error() string {
+	return fmt.Sprintf("%s.%s: the server truncated the list after %d items", e.Domain, e.Method, e.Items)
+}
+
+// truncationTrailer returns the trailer to receive for calls returning a T,
+// or nil if T is not a list.
+func truncationTrailer[T any]() *metadata.MD {
+	var zero T
+	if _, ok := any(zero).(api.List); !ok {
+		return nil
+	}
+	return &metadata.MD{}
+}
+
+// serverTruncated checks the trailer of a list call for TruncatedMetadata.
+func serverTruncated(domain, method string, res any, trailer *metadata.MD) error {
+	if trailer == nil {
+		return nil
+	}
+	values := trailer.Get(TruncatedMetadata)
+	if len(values) == 0 {
+		return nil
+	}
+	if truncated, err := strconv.ParseBool(values[len(values)-1]); err != nil || !truncated {
+		return nil
+	}
+	items := 0
+	if list, ok := res.(api.List); ok {
+		items = len(list.GetItems())
+	}
+	return &TruncatedError{Domain: domain, Method: method, Items: items}
+}
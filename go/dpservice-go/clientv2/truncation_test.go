@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	dpdkproto "github.com/ironcore-dev/dpservice/go/dpservice-go/proto"
+)
+
+// truncatingRPC answers ListInterfaces with one interface and the given
+// truncation trailer.
+type truncatingRPC struct {
+	dpdkproto.DPDKironcoreClient
+	truncated string
+}
+
+func (r *truncatingRPC) ListInterfaces(_ context.Context, _ *dpdkproto.ListInterfacesRequest, opts ...grpc.CallOption) (*dpdkproto.ListInterfacesResponse, error) {
+	for _, opt := range opts {
+		if t, ok := opt.(grpc.TrailerCallOption); ok && r.truncated != "" {
+			*t.TrailerAddr = metadata.Pairs(TruncatedMetadata, r.truncated)
+		}
+	}
+	return &dpdkproto.ListInterfacesResponse{
+		Status:     &dpdkproto.Status{},
+		Interfaces: []*dpdkproto.Interface{{Id: []byte("vm1"), PrimaryIpv4: []byte("10.0.0.1"), PrimaryIpv6: []byte("fc00::1"), MeteringParams: &dpdkproto.MeteringParams{}}},
+	}, nil
+}
+
+func TestServerTruncatedList(t *testing.T) {
+	rpc := &truncatingRPC{truncated: "true"}
+	ifaces := NewFromProto(rpc).Interfaces()
+
+	list, err := ifaces.List(context.Background())
+	var truncated *TruncatedError
+	if !errors.As(err, &truncated) || truncated.Items != 1 || truncated.Method != "List" {
+		t.Fatalf("expected TruncatedError, got %v", err)
+	}
+	if list == nil || len(list.Items) != 1 {
+		t.Fatalf("expected the partial result, got %v", list)
+	}
+
+	for _, value := range []string{"", "false", "bogus"} {
+		rpc.truncated = value
+		if _, err := ifaces.List(context.Background()); err != nil {
+			t.Errorf("trailer %q: unexpected error %v", value, err)
+		}
+	}
+}
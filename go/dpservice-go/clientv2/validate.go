@@ -0,0 +1,138 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+	dpdkproto "github.com/ironcore-dev/dpservice/go/dpservice-go/proto"
+)
+
+// WithValidate makes Firewall().Create check the rule with
+// ValidateFirewallRule and return its error before any RPC is made.
+func WithValidate() CallOption {
+	return func(o *callOptions) {
+		o.validate = true
+	}
+}
+
+// FirewallRuleError lists every problem ValidateFirewallRule found in a rule.
+type FirewallRuleError struct {
+	RuleID   string
+	Problems []string
+}
+
+func (e *FirewallRuleError) Error() string {
+	return fmt.Sprintf("invalid firewall rule %q: %s", e.RuleID, strings.Join(e.Problems, "; "))
+}
+
+// firewallRuleIDMaxLen is the size of the rule ID buffer of dpservice
+// (DP_FIREWALL_ID_MAX_LEN), including the terminating NUL.
+const firewallRuleIDMaxLen = 64
+
+// ValidateFirewallRule checks that the fields of a rule are coherent, mirroring
+// what dpservice accepts or silently ignores:
+//
+//   - the rule ID is set and shorter than 64 bytes, as longer IDs are truncated;
+//   - the direction is Ingress or Egress and the action Accept or Drop, in the
+//     spellings the legacy client accepts;
+//   - the priority fits 16 bits;
+//   - source and destination prefixes are set, valid and of the same family,
+//     as a rule mixing families never matches a packet;
+//   - TCP and UDP port bounds are -1 (any port) or 0-65535, an upper bound is
+//     not below its lower bound and is not set when the lower bound is -1,
+//     where the server ignores it;
+//   - ICMP type and code are -1 (any) or 0-255.
+//
+// An ICMP filter on IPv6 prefixes matches ICMPv6 on the server and is not
+// reported. The rules track dpservice and may change with it.
+func ValidateFirewallRule(rule *api.FirewallRule) error {
+	if rule == nil {
+		return &FirewallRuleError{Problems: []string{"rule is nil"}}
+	}
+	spec := &rule.Spec
+	var problems []string
+	problemf := func(format string, args ...any) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	switch {
+	case spec.RuleID == "":
+		problemf("rule ID is empty")
+	case len(spec.RuleID) >= firewallRuleIDMaxLen:
+		problemf("rule ID is %d bytes long, the maximum is %d", len(spec.RuleID), firewallRuleIDMaxLen-1)
+	}
+	switch strings.ToLower(spec.TrafficDirection) {
+	case "ingress", "egress", "0", "1":
+	default:
+		problemf("direction %q is not Ingress or Egress", spec.TrafficDirection)
+	}
+	switch strings.ToLower(spec.FirewallAction) {
+	case "accept", "allow", "1", "drop", "deny", "0":
+	default:
+		problemf("action %q is not Accept or Drop", spec.FirewallAction)
+	}
+	if spec.Priority > 0xffff {
+		problemf("priority %d exceeds 65535", spec.Priority)
+	}
+
+	src, dst := spec.SourcePrefix, spec.DestinationPrefix
+	switch {
+	case src == nil:
+		problemf("source prefix is not set")
+	case !src.IsValid():
+		problemf("source prefix is not valid")
+	}
+	switch {
+	case dst == nil:
+		problemf("destination prefix is not set")
+	case !dst.IsValid():
+		problemf("destination prefix is not valid")
+	}
+	if src != nil && dst != nil && src.IsValid() && dst.IsValid() && src.Addr().Is4() != dst.Addr().Is4() {
+		problemf("source prefix %s and destination prefix %s are of different address families", src, dst)
+	}
+
+	switch f := spec.ProtocolFilter.GetFilter().(type) {
+	case *dpdkproto.ProtocolFilter_Tcp:
+		problems = append(problems, checkPortRange("TCP source", f.Tcp.GetSrcPortLower(), f.Tcp.GetSrcPortUpper())...)
+		problems = append(problems, checkPortRange("TCP destination", f.Tcp.GetDstPortLower(), f.Tcp.GetDstPortUpper())...)
+	case *dpdkproto.ProtocolFilter_Udp:
+		problems = append(problems, checkPortRange("UDP source", f.Udp.GetSrcPortLower(), f.Udp.GetSrcPortUpper())...)
+		problems = append(problems, checkPortRange("UDP destination", f.Udp.GetDstPortLower(), f.Udp.GetDstPortUpper())...)
+	case *dpdkproto.ProtocolFilter_Icmp:
+		if t := f.Icmp.GetIcmpType(); t < -1 || t > 0xff {
+			problemf("ICMP type %d is not -1 or 0-255", t)
+		}
+		if c := f.Icmp.GetIcmpCode(); c < -1 || c > 0xff {
+			problemf("ICMP code %d is not -1 or 0-255", c)
+		}
+	}
+
+	if len(problems) > 0 {
+		return &FirewallRuleError{RuleID: spec.RuleID, Problems: problems}
+	}
+	return nil
+}
+
+// checkPortRange validates the bounds of a port filter, see
+// ValidateFirewallRule.
+func checkPortRange(what string, lower, upper int32) []string {
+	var problems []string
+	if lower < -1 || lower > 0xffff {
+		problems = append(problems, fmt.Sprintf("%s port lower bound %d is not -1 or 0-65535", what, lower))
+	}
+	if upper < -1 || upper > 0xffff {
+		problems = append(problems, fmt.Sprintf("%s port upper bound %d is not -1 or 0-65535", what, upper))
+	}
+	switch {
+	case lower == -1 && upper > 0:
+		problems = append(problems, fmt.Sprintf("%s port upper bound %d is ignored as the lower bound matches any port", what, upper))
+	case lower >= 0 && upper != -1 && upper < lower:
+		problems = append(problems, fmt.Sprintf("%s port upper bound %d is below the lower bound %d", what, upper, lower))
+	}
+	return problems
+}
@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+)
+
+// InterfaceEventType is the kind of change reported by an InterfaceEvent.
+type InterfaceEventType int
+
+const (
+	InterfaceAdded InterfaceEventType = iota
+	InterfaceModified
+	InterfaceDeleted
+)
+
+func (t InterfaceEventType) String() string {
+	switch t {
+	case InterfaceAdded:
+		return "Added"
+	case InterfaceModified:
+		return "Modified"
+	case InterfaceDeleted:
+		return "Deleted"
+	}
+	return "Unknown"
+}
+
+// InterfaceEvent is a change between two polls of Interfaces().WatchDiffs.
+type InterfaceEvent struct {
+	Type InterfaceEventType
+	// Interface is the interface as listed by the poll that found the
+	// change, or, for InterfaceDeleted, as last listed.
+	Interface *api.Interface
+	// Old is the interface as listed by the previous poll, set for
+	// InterfaceModified only.
+	Old *api.Interface
+}
+
+func (c *ifaceClient) WatchDiffs(ctx context.Context, poll time.Duration, opts ...CallOption) (<-chan InterfaceEvent, <-chan error) {
+	events := make(chan InterfaceEvent)
+	errs := make(chan error)
+	go func() {
+		defer close(events)
+		defer close(errs)
+		send := func(ev InterfaceEvent) bool {
+			select {
+			case events <- ev:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+		fail := func(err error) bool {
+			select {
+			case errs <- err:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+		if poll <= 0 {
+			fail(&InvalidArgumentError{Domain: DomainInterfaces, Method: "WatchDiffs", Argument: "poll", Reason: "poll interval is not positive"})
+			return
+		}
+
+		// prev holds the interfaces of the last successful List and order
+		// their IDs in list order, so deletions are sent in a stable order.
+		var prev map[string]*api.Interface
+		var order []string
+		for {
+			list, err := c.List(ctx, opts...)
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				if !fail(err) {
+					return
+				}
+			} else {
+				next := make(map[string]*api.Interface, len(list.Items))
+				nextOrder := make([]string, 0, len(list.Items))
+				for i := range list.Items {
+					iface := &list.Items[i]
+					next[iface.ID] = iface
+					nextOrder = append(nextOrder, iface.ID)
+					old, ok := prev[iface.ID]
+					switch {
+					case !ok:
+						if !send(InterfaceEvent{Type: InterfaceAdded, Interface: iface}) {
+							return
+						}
+					case !reflect.DeepEqual(old, iface):
+						if !send(InterfaceEvent{Type: InterfaceModified, Interface: iface, Old: old}) {
+							return
+						}
+					}
+				}
+				for _, id := range order {
+					if _, ok := next[id]; !ok {
+						if !send(InterfaceEvent{Type: InterfaceDeleted, Interface: prev[id]}) {
+							return
+						}
+					}
+				}
+				prev, order = next, nextOrder
+			}
+
+			timer := time.NewTimer(poll)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+	}()
+	return events, errs
+}
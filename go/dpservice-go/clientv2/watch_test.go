@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ironcore-dev/dpservice/go/dpservice-go/api"
+)
+
+func TestInterfacesWatchDiffs(t *testing.T) {
+	boom := errors.New("boom")
+	var mu sync.Mutex
+	polls := []func() (*api.InterfaceList, error){
+		func() (*api.InterfaceList, error) { return interfaceListOf(1, "vm1", "vm2") },
+		func() (*api.InterfaceList, error) { return interfaceListOf(1, "vm1", "vm2") },
+		func() (*api.InterfaceList, error) { return nil, boom },
+		func() (*api.InterfaceList, error) { return interfaceListOf(2, "vm1", "vm3") },
+	}
+	fake := &fakeLegacy{
+		listInterfaces: func(context.Context) (*api.InterfaceList, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			next := polls[0]
+			if len(polls) > 1 {
+				polls = polls[1:]
+			}
+			return next()
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, errs := AsV2(fake).Interfaces().WatchDiffs(ctx, time.Millisecond)
+
+	var got []string
+	for len(got) < 5 {
+		select {
+		case ev := <-events:
+			got = append(got, ev.Type.String()+" "+ev.Interface.ID)
+			if ev.Type == InterfaceModified && (ev.Old.Spec.VNI != 1 || ev.Interface.Spec.VNI != 2) {
+				t.Errorf("expected old and new interface on modify, got %+v", ev)
+			}
+		case err := <-errs:
+			if !errors.Is(err, boom) {
+				t.Errorf("unexpected error %v", err)
+			}
+			got = append(got, "error")
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out, got %v", got)
+		}
+	}
+	want := []string{"Added vm1", "Added vm2", "error", "Modified vm1", "Added vm3"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got events %v, want %v", got, want)
+		}
+	}
+	select {
+	case ev := <-events:
+		if ev.Type != InterfaceDeleted || ev.Interface.ID != "vm2" {
+			t.Fatalf("expected vm2 to be deleted, got %+v", ev)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the deletion")
+	}
+
+	// Unchanged polls stay silent until ctx is done, then both channels close.
+	select {
+	case ev := <-events:
+		t.Fatalf("expected no further events, got %+v", ev)
+	case <-time.After(20 * time.Millisecond):
+	}
+	cancel()
+	for range events {
+	}
+	for range errs {
+	}
+}
+
+func TestInterfacesWatchDiffsInvalidPoll(t *testing.T) {
+	events, errs := AsV2(&fakeLegacy{}).Interfaces().WatchDiffs(context.Background(), 0)
+	var argErr *InvalidArgumentError
+	if err := <-errs; !errors.As(err, &argErr) {
+		t.Fatalf("expected an *InvalidArgumentError, got %v", err)
+	}
+	if _, ok := <-events; ok {
+		t.Fatal("expected the event channel to be closed")
+	}
+}
+
+func interfaceListOf(vni uint32, ids ...string) (*api.InterfaceList, error) {
+	list := &api.InterfaceList{}
+	for _, id := range ids {
+		list.Items = append(list.Items, api.Interface{InterfaceMeta: api.InterfaceMeta{ID: id}, Spec: api.InterfaceSpec{VNI: vni}})
+	}
+	return list, nil
+}
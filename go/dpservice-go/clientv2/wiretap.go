@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/grpc/stats"
+	"google.golang.org/protobuf/proto"
+)
+
+// WireTapFunc receives the marshaled request and response of an RPC issued
+// by domain.method. resp is nil if no response was received.
+type WireTapFunc func(domain, method string, req, resp []byte)
+
+// WithWireTap passes the protobuf encoding of every request and response of
+// the call to fn, once per RPC, including retried attempts. It is a
+// debugging aid for protocol-level issues and costs an extra marshal of each
+// message.
+//
+// The messages are observed by a gRPC stats handler that only clients
+// owning their connection install, i.e. those built with NewFromAddress or
+// New with WithAddress. For other clients the option has no effect.
+func WithWireTap(fn WireTapFunc) CallOption {
+	return func(o *callOptions) {
+		o.wireTap = fn
+	}
+}
+
+type wireTapKey struct{}
+
+// wireTap is the tap of a call, carried by the call context to the stats
+// handler.
+type wireTap struct {
+	domain, method string
+	fn             WireTapFunc
+}
+
+func withWireTap(ctx context.Context, domain, method string, fn WireTapFunc) context.Context {
+	return context.WithValue(ctx, wireTapKey{}, &wireTap{domain: domain, method: method, fn: fn})
+}
+
+// wireTapRPC collects the messages of a single RPC.
+type wireTapRPC struct {
+	tap       *wireTap
+	mu        sync.Mutex
+	req, resp []byte
+}
+
+// wireTapHandler is the stats handler of owned connections. It ignores RPCs
+// whose context carries no wireTap.
+type wireTapHandler struct{}
+
+func (wireTapHandler) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	tap, ok := ctx.Value(wireTapKey{}).(*wireTap)
+	if !ok {
+		return ctx
+	}
+	return context.WithValue(ctx, wireTapKey{}, &wireTapRPC{tap: tap})
+}
+
+func (wireTapHandler) HandleRPC(ctx context.Context, s stats.RPCStats) {
+	rpc, ok := ctx.Value(wireTapKey{}).(*wireTapRPC)
+	if !ok {
+		return
+	}
+	switch s := s.(type) {
+	case *stats.OutPayload:
+		// The payload may change after HandleRPC returns, so it is marshaled
+		// here. gRPC encodes it with the same proto.Marshal.
+		rpc.set(&rpc.req, s.Payload)
+	case *stats.InPayload:
+		rpc.set(&rpc.resp, s.Payload)
+	case *stats.End:
+		rpc.mu.Lock()
+		req, resp := rpc.req, rpc.resp
+		rpc.mu.Unlock()
+		rpc.tap.fn(rpc.tap.domain, rpc.tap.method, req, resp)
+	}
+}
+
+func (r *wireTapRPC) set(dst *[]byte, payload any) {
+	msg, ok := payload.(proto.Message)
+	if !ok {
+		return
+	}
+	b, err := proto.Marshal(msg)
+	if err != nil {
+		return
+	}
+	r.mu.Lock()
+	*dst = b
+	r.mu.Unlock()
+}
+
+func (wireTapHandler) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (wireTapHandler) HandleConn(context.Context, stats.ConnStats) {}
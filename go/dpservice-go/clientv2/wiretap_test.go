@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2025 The dpservice Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clientv2
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/stats"
+	"google.golang.org/protobuf/proto"
+
+	dpdkproto "github.com/ironcore-dev/dpservice/go/dpservice-go/proto"
+)
+
+func TestWireTapHandler(t *testing.T) {
+	var calls int
+	var gotDomain, gotMethod string
+	var gotReq, gotResp []byte
+	tap := func(domain, method string, req, resp []byte) {
+		calls++
+		gotDomain, gotMethod, gotReq, gotResp = domain, method, req, resp
+	}
+
+	var h wireTapHandler
+	req := &dpdkproto.GetInterfaceRequest{InterfaceId: []byte("vm1")}
+	resp := &dpdkproto.GetInterfaceResponse{Status: &dpdkproto.Status{Code: 201}}
+	ctx := h.TagRPC(withWireTap(context.Background(), DomainInterfaces, "Get", tap), &stats.RPCTagInfo{})
+	h.HandleRPC(ctx, &stats.OutPayload{Client: true, Payload: req})
+	h.HandleRPC(ctx, &stats.InPayload{Client: true, Payload: resp})
+	h.HandleRPC(ctx, &stats.End{Client: true})
+
+	if calls != 1 || gotDomain != DomainInterfaces || gotMethod != "Get" {
+		t.Fatalf("expected one tap of Interfaces.Get, got %d calls of %s.%s", calls, gotDomain, gotMethod)
+	}
+	var decodedReq dpdkproto.GetInterfaceRequest
+	var decodedResp dpdkproto.GetInterfaceResponse
+	if err := proto.Unmarshal(gotReq, &decodedReq); err != nil || string(decodedReq.InterfaceId) != "vm1" {
+		t.Fatalf("expected the marshaled request, got %x, %v", gotReq, err)
+	}
+	if err := proto.Unmarshal(gotResp, &decodedResp); err != nil || decodedResp.Status.GetCode() != 201 {
+		t.Fatalf("expected the marshaled response, got %x, %v", gotResp, err)
+	}
+
+	untapped := h.TagRPC(context.Background(), &stats.RPCTagInfo{})
+	h.HandleRPC(untapped, &stats.OutPayload{Client: true, Payload: req})
+	h.HandleRPC(untapped, &stats.End{Client: true})
+	if calls != 1 {
+		t.Fatalf("expected calls without WithWireTap not to be tapped")
+	}
+}